@@ -0,0 +1,48 @@
+package semver
+
+// Any returns constraints admitting every release, the same as parsing "*".
+func Any() *Constraints {
+	c, _ := newConstraintWithOptions("*", MatchOptions{})
+	return c
+}
+
+// None returns constraints admitting no versions, the same as parsing
+// "<0.0.0-0".
+func None() *Constraints {
+	c, _ := newConstraintWithOptions("<0.0.0-0", MatchOptions{})
+	return c
+}
+
+// IsAny reports if the constraints admit every release, however they are
+// written. For example, *, >=0.0.0, and <1 || >=1 all do. Nil constraints
+// admit no versions. With MetadataEqual, MetadataOrdered, or
+// LegacyPrereleaseOrder, which tell apart versions sharing a precedence,
+// constraints are only reported when they admit every release however the
+// versions they name are told apart.
+func IsAny(c *Constraints) bool {
+	if c == nil {
+		return false
+	}
+	_, must := c.sets()
+	return must.allReleases()
+}
+
+// IsNone reports if the constraints admit no versions, however they are
+// written. For example, <0.0.0-0 and >2 <1 admit none, as do nil constraints.
+// With MetadataEqual, MetadataOrdered, or LegacyPrereleaseOrder constraints
+// are only reported when no version sharing a precedence with those they name
+// could be admitted either, so `=1.2.3+abc, !=1.2.3+def` with MetadataEqual is
+// not.
+func IsNone(c *Constraints) bool {
+	if c == nil {
+		return true
+	}
+
+	// No version is below 0.0.0-0, so intervals ending at or below it, as
+	// that of <0.0.0-0 does, are empty.
+	s, _ := c.sets()
+	releases := Interval{min: bound{v: newCoreVersion(0, 0, 0), inclusive: true}}
+	prereleases := Interval{min: bound{v: newLowestVersion(0, 0, 0), inclusive: true}}
+	return len(intersectIntervals(s.releases, []Interval{releases})) == 0 &&
+		len(intersectIntervals(s.prereleases, []Interval{prereleases})) == 0
+}
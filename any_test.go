@@ -0,0 +1,106 @@
+package semver
+
+import "testing"
+
+func TestAnyNone(t *testing.T) {
+	if !Any().Eq(mustConstraint(t, "*")) || Any().String() != "*" {
+		t.Errorf("expected Any to be * but got %s", Any())
+	}
+	if !None().Eq(mustConstraint(t, "<0.0.0-0")) || None().Check(MustParse("0.0.0")) {
+		t.Errorf("expected None to admit no versions but got %s", None())
+	}
+
+	tests := []struct {
+		constraint string
+		any, none  bool
+	}{
+		{"*", true, false},
+		{"x", true, false},
+		{">=0.0.0", true, false},
+		{"<1 || >=1", true, false},
+		{">=0.0.0-0", true, false},
+		{"^1.2", false, false},
+		{"!=1.2.3", false, false},
+		{"<0.0.0-0", false, true},
+		{">2 <1", false, true},
+		{"1.2.3 - 1.2.0", false, true},
+	}
+	for _, tc := range tests {
+		c := mustConstraint(t, tc.constraint)
+		if IsAny(c) != tc.any {
+			t.Errorf("expected IsAny(%s) to be %t", tc.constraint, tc.any)
+		}
+		if IsNone(c) != tc.none {
+			t.Errorf("expected IsNone(%s) to be %t", tc.constraint, tc.none)
+		}
+	}
+
+	if IsAny(nil) || !IsNone(nil) {
+		t.Error("expected nil constraints to admit no versions")
+	}
+	if !IsAny(Any()) || !IsNone(None()) {
+		t.Error("expected IsAny(Any()) and IsNone(None())")
+	}
+}
+
+func TestAnyAliases(t *testing.T) {
+	for _, s := range []string{"", "latest", "any"} {
+		if _, err := NewConstraint(s); err == nil {
+			t.Errorf("expected %q not to parse without aliases", s)
+		}
+	}
+
+	p := NewParser(MatchOptions{})
+	for _, w := range []string{"", "Latest", " any "} {
+		p.Alias(w, nil)
+	}
+	for _, s := range []string{"", "  ", "latest", "LATEST", " any"} {
+		c, err := p.Parse(s)
+		if err != nil {
+			t.Errorf("expected %q to parse but got %s", s, err)
+			continue
+		}
+		if c.String() != "*" || !IsAny(c) {
+			t.Errorf("expected %q to parse to * but got %s", s, c)
+		}
+	}
+	if _, err := p.Parse("latest || ^1"); err == nil {
+		t.Error("expected an alias within other constraints not to parse")
+	}
+
+	// The aliases of a parser do not apply to other callers.
+	if _, err := NewConstraint("latest"); err == nil {
+		t.Error("expected the aliases not to apply to NewConstraint")
+	}
+	if _, err := NewParser(MatchOptions{}).Parse("latest"); err == nil {
+		t.Error("expected the aliases not to apply to other parsers")
+	}
+}
+
+func TestAnyNonePrecedenceOptions(t *testing.T) {
+	tests := []struct {
+		constraint string
+		opts       MatchOptions
+		any, none  bool
+	}{
+		{"=1.2.3+abc, !=1.2.3+def", MatchOptions{Metadata: MetadataEqual}, false, false},
+		{"!=1.2.3+abc", MatchOptions{Metadata: MetadataEqual}, false, false},
+		{">1.2.3+build.9, <1.2.3+build.11", MatchOptions{Metadata: MetadataOrdered}, false, false},
+		{"<1.2.3 || >=1.2.3", MatchOptions{Metadata: MetadataOrdered}, true, false},
+		{"<1.2.3 || >1.2.3", MatchOptions{Metadata: MetadataOrdered}, false, false},
+		{">1.2.3-100000000000000000000, <1.2.3-99999999999999999999", MatchOptions{LegacyPrereleaseOrder: true}, false, false},
+		{"=1.2.3+abc, >2", MatchOptions{Metadata: MetadataEqual}, false, true},
+	}
+	for _, tc := range tests {
+		c, err := NewConstraintWithOptions(tc.constraint, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if IsAny(c) != tc.any {
+			t.Errorf("expected IsAny of %s with %+v to be %t", tc.constraint, tc.opts, tc.any)
+		}
+		if IsNone(c) != tc.none {
+			t.Errorf("expected IsNone of %s with %+v to be %t", tc.constraint, tc.opts, tc.none)
+		}
+	}
+}
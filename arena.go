@@ -0,0 +1,249 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VersionArena holds a batch of versions parsed by ParseBulk in packed
+// storage. The versions are kept in one slice with their strings in one
+// shared string, so a batch of any size costs a few allocations rather than
+// several per version. The versions are addressed by their index in the
+// batch, and *Version values are only made when asked for.
+type VersionArena struct {
+	text     string
+	versions []Version
+}
+
+// BulkError is returned by ParseBulk for a version that could not be parsed.
+type BulkError struct {
+	Index   int
+	Version string
+	Err     error
+}
+
+// Error returns the error along with the version and its index.
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("%s at index %d: %s", e.Version, e.Index, e.Err)
+}
+
+// Unwrap returns the error parsing the version, such as ErrInvalidSemVer.
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// ParseBulk parses a batch of versions the same way as NewVersion into a
+// VersionArena. It is meant for ingesting versions at registry scale, where
+// allocating each version on its own dominates the time spent collecting
+// garbage. The first version that can not be parsed is returned as a
+// *BulkError.
+func ParseBulk(vs []string) (*VersionArena, error) {
+	n := 0
+	for _, s := range vs {
+		n += len(s)
+	}
+	var b strings.Builder
+	b.Grow(n)
+	for _, s := range vs {
+		b.WriteString(s)
+	}
+
+	a := &VersionArena{
+		text:     b.String(),
+		versions: make([]Version, len(vs)),
+	}
+	start := 0
+	for i, s := range vs {
+		end := start + len(s)
+		if err := parseVersionInto(&a.versions[i], a.text[start:end]); err != nil {
+			return nil, &BulkError{Index: i, Version: s, Err: err}
+		}
+		start = end
+	}
+	return a, nil
+}
+
+// Len returns the number of versions in the arena.
+func (a *VersionArena) Len() int {
+	return len(a.versions)
+}
+
+// Version returns the version at index i. Each call returns a new *Version
+// sharing its string with the arena.
+func (a *VersionArena) Version(i int) *Version {
+	v := a.versions[i]
+	return &v
+}
+
+// Collection returns every version in the arena. The versions are made in
+// one allocation.
+func (a *VersionArena) Collection() Collection {
+	vs := make([]Version, len(a.versions))
+	copy(vs, a.versions)
+	c := make(Collection, len(vs))
+	for i := range vs {
+		c[i] = &vs[i]
+	}
+	return c
+}
+
+// Major returns the major version at index i.
+func (a *VersionArena) Major(i int) uint64 {
+	return a.versions[i].major
+}
+
+// Minor returns the minor version at index i.
+func (a *VersionArena) Minor(i int) uint64 {
+	return a.versions[i].minor
+}
+
+// Patch returns the patch version at index i.
+func (a *VersionArena) Patch(i int) uint64 {
+	return a.versions[i].patch
+}
+
+// Prerelease returns the prerelease of the version at index i.
+func (a *VersionArena) Prerelease(i int) string {
+	return a.versions[i].Prerelease()
+}
+
+// Metadata returns the metadata of the version at index i.
+func (a *VersionArena) Metadata(i int) string {
+	return a.versions[i].Metadata()
+}
+
+// Original returns the string the version at index i was parsed from.
+func (a *VersionArena) Original(i int) string {
+	return a.versions[i].Original()
+}
+
+// Compare compares the versions at indexes i and j the same way as
+// Version.Compare, without making a *Version for either.
+func (a *VersionArena) Compare(i, j int) int {
+	return a.versions[i].Compare(&a.versions[j])
+}
+
+// Check reports if the version at index i satisfies the constraints.
+func (a *VersionArena) Check(i int, c *Constraints) bool {
+	return c.Check(&a.versions[i])
+}
+
+// parseVersionInto parses a version into v following the same rules as
+// NewVersion, without the allocations of matching versionRegex.
+func parseVersionInto(v *Version, s string) error {
+	if err := checkVersionLimits(s); err != nil {
+		return err
+	}
+	segments, pre, metadata, ok := scanVersion(s)
+	if !ok {
+		if err := checkASCII(s); err != nil {
+			return err
+		}
+		return ErrInvalidSemVer
+	}
+
+	var err error
+	for k, p := range []*uint64{&v.major, &v.minor, &v.patch} {
+		if segments[k] == "" {
+			*p = 0
+			continue
+		}
+		if *p, err = strconv.ParseUint(segments[k], 10, 64); err != nil {
+			return fmt.Errorf("Error parsing version segment: %s", err)
+		}
+	}
+
+	if numericStartsZero(pre) {
+		return ErrSegmentStartsZero
+	}
+	v.set(pre, metadata, s)
+	return nil
+}
+
+// scanVersion splits a version into its segments, prerelease, and metadata
+// when it has the syntax matched by versionRegex. Segments left off are
+// empty. It does not allocate.
+func scanVersion(s string) (segments [3]string, pre, metadata string, ok bool) {
+	i := 0
+	if i < len(s) && s[i] == 'v' {
+		i++
+	}
+
+	// The major version is required and the minor and patch optional, each
+	// following a dot.
+	for k := range segments {
+		if k > 0 {
+			if i == len(s) || s[i] != '.' {
+				break
+			}
+			i++
+		}
+		j := i
+		for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		if j == i {
+			return segments, "", "", false
+		}
+		segments[k], i = s[i:j], j
+	}
+
+	if i < len(s) && s[i] == '-' {
+		j := i + 1
+		for j < len(s) && s[j] != '+' {
+			j++
+		}
+		pre, i = s[i+1:j], j
+		if !validIdentifiers(pre) {
+			return segments, "", "", false
+		}
+	}
+	if i < len(s) && s[i] == '+' {
+		metadata, i = s[i+1:], len(s)
+		if !validIdentifiers(metadata) {
+			return segments, "", "", false
+		}
+	}
+	return segments, pre, metadata, i == len(s)
+}
+
+// validIdentifiers reports if s is dot separated identifiers made of
+// [0-9A-Za-z-], none of them empty.
+func validIdentifiers(s string) bool {
+	if s == "" {
+		return false
+	}
+	empty := true
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '.':
+			if empty {
+				return false
+			}
+			empty = true
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+			empty = false
+		default:
+			return false
+		}
+	}
+	return !empty
+}
+
+// numericStartsZero reports if one of the dot separated identifiers of s is
+// numeric with a leading zero, as validatePrerelease does without splitting s.
+func numericStartsZero(s string) bool {
+	for len(s) > 0 {
+		id := s
+		if i := strings.IndexByte(s, '.'); i >= 0 {
+			id, s = s[:i], s[i+1:]
+		} else {
+			s = ""
+		}
+		if len(id) > 1 && id[0] == '0' && containsOnly(id, num) {
+			return true
+		}
+	}
+	return false
+}
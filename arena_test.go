@@ -0,0 +1,118 @@
+package semver
+
+import "testing"
+
+func TestParseBulk(t *testing.T) {
+	tests := []string{
+		"1.2.3",
+		"v1.2.3",
+		"1",
+		"v1.2",
+		"01.02.03",
+		"1.2-5",
+		"1.2.3--",
+		"1.2.3-beta.1+build.5",
+		"1.2.3+build-5.x",
+		"1.2.3-rc1-with-hypen",
+		"1.2.3-0.a.00a",
+		"18446744073709551615.0.0",
+	}
+
+	a, err := ParseBulk(tests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Len() != len(tests) {
+		t.Fatalf("Expected %d versions but got %d", len(tests), a.Len())
+	}
+
+	c := a.Collection()
+	for i, tc := range tests {
+		e, err := NewVersion(tc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, v := range []*Version{a.Version(i), c[i]} {
+			if !v.Equal(e) || v.Metadata() != e.Metadata() || v.Original() != tc {
+				t.Errorf("Expected %s to parse the same as NewVersion but got %s from %q", e, v, v.Original())
+			}
+		}
+		if a.Major(i) != e.Major() || a.Minor(i) != e.Minor() || a.Patch(i) != e.Patch() {
+			t.Errorf("Expected the segments of %s but got %d.%d.%d", e, a.Major(i), a.Minor(i), a.Patch(i))
+		}
+		if a.Prerelease(i) != e.Prerelease() || a.Metadata(i) != e.Metadata() || a.Original(i) != tc {
+			t.Errorf("Expected the parts of %s but got %q, %q, %q", tc, a.Prerelease(i), a.Metadata(i), a.Original(i))
+		}
+	}
+
+	if a.Compare(0, 1) != 0 || a.Compare(2, 0) != -1 || a.Compare(0, 2) != 1 {
+		t.Error("Expected the arena to compare the same as Version.Compare")
+	}
+
+	cs := mustConstraint(t, "^1.2")
+	if !a.Check(0, cs) || a.Check(2, cs) {
+		t.Error("Expected 1.2.3 to satisfy ^1.2 and 1 not to")
+	}
+}
+
+func TestParseBulkInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"v",
+		"V1.2.3",
+		"1.2.3.4",
+		"1..2",
+		"1.2a",
+		"1.2.3-",
+		"1.2.3+",
+		"1.2.3-a..b",
+		"1.2.3+a..b",
+		"1.2.3-a+",
+		"1.2.3-a_b",
+		"1.2.3+a+b",
+		"1.2.3-01",
+		"1.2.3 ",
+		" 1.2.3",
+		"18446744073709551616.0.0",
+	}
+
+	for _, tc := range tests {
+		_, expected := NewVersion(tc)
+		if expected == nil {
+			t.Fatalf("Expected NewVersion to fail for %q", tc)
+		}
+
+		_, err := ParseBulk([]string{"1.0.0", tc})
+		be, ok := err.(*BulkError)
+		if !ok {
+			t.Errorf("Expected a BulkError for %q but got %v", tc, err)
+			continue
+		}
+		if be.Index != 1 || be.Version != tc {
+			t.Errorf("Expected the error for %q at index 1 but got %q at %d", tc, be.Version, be.Index)
+		}
+		if be.Err.Error() != expected.Error() {
+			t.Errorf("Expected error %q for %q but got %q", expected, tc, be.Err)
+		}
+	}
+}
+
+func TestParseBulkAllocs(t *testing.T) {
+	vs := []string{"1.2.3", "v2.0.0-beta.1+build.5", "3.1", "1.0.0-rc.2"}
+	allocs := testing.AllocsPerRun(10, func() {
+		_, _ = ParseBulk(vs)
+	})
+	if allocs > 3 {
+		t.Errorf("Expected at most 3 allocations parsing a batch but got %v", allocs)
+	}
+}
+
+func mustConstraint(t *testing.T, c string) *Constraints {
+	t.Helper()
+	cs, err := NewConstraint(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cs
+}
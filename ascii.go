@@ -0,0 +1,187 @@
+package semver
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NonASCIIError is returned when a version or constraint has a character
+// outside of ASCII. Versions and constraints are written in ASCII, so
+// lookalikes such as a full-width digit or an en dash are rejected rather than
+// being read as something else.
+type NonASCIIError struct {
+	// Input is the version or constraint being parsed.
+	Input string
+
+	// Offset is the byte offset of the character in Input.
+	Offset int
+
+	// Char is the character, or utf8.RuneError for an invalid UTF-8 byte.
+	Char rune
+}
+
+// Error names the character and, when it is a known lookalike, the ASCII it
+// looks like.
+func (e *NonASCIIError) Error() string {
+	msg := fmt.Sprintf("%s has the non-ASCII character %q at offset %d", e.Input, e.Char, e.Offset)
+	if r, ok := confusable(e.Char); ok && r != "" {
+		msg += fmt.Sprintf(", which looks like %q", r)
+	}
+	return msg
+}
+
+// checkASCII returns a *NonASCIIError for the first character of s outside
+// of ASCII, or nil when there is none.
+func checkASCII(s string) error {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			r, _ := utf8.DecodeRuneInString(s[i:])
+			return &NonASCIIError{Input: s, Offset: i, Char: r}
+		}
+	}
+	return nil
+}
+
+// Confusable is a character MapConfusables replaced with the ASCII it looks
+// like.
+type Confusable struct {
+	// Offset is the byte offset of the character in the string mapped.
+	Offset int
+
+	// Char is the character replaced.
+	Char rune
+
+	// Replacement is the ASCII it was replaced with. It is empty for
+	// characters that were dropped, such as a zero width space.
+	Replacement string
+}
+
+// String describes the replacement along with its offset.
+func (c Confusable) String() string {
+	if c.Replacement == "" {
+		return fmt.Sprintf("offset %d: %q dropped", c.Offset, c.Char)
+	}
+	return fmt.Sprintf("offset %d: %q read as %q", c.Offset, c.Char, c.Replacement)
+}
+
+// MapConfusables replaces the characters in s that are commonly mistaken for
+// ASCII, as happens when a version is pasted from a word processor or web
+// page, returning the mapped string and a Confusable for each replacement.
+// Full-width forms, decimal digits of other scripts, Unicode dashes and
+// spaces, and the ≥, ≤, and ≠ signs are mapped. Zero width spaces and byte
+// order marks are dropped. Other characters are left as is, so the mapped
+// string is still rejected by the parsers when it has any.
+func MapConfusables(s string) (string, []Confusable) {
+	if checkASCII(s) == nil {
+		return s, nil
+	}
+
+	var found []Confusable
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if rep, ok := confusable(r); ok && r >= utf8.RuneSelf {
+			found = append(found, Confusable{Offset: i, Char: r, Replacement: rep})
+			out = append(out, rep...)
+		} else {
+			out = append(out, s[i:i+size]...)
+		}
+		i += size
+	}
+	return string(out), found
+}
+
+// LenientNewVersion parses a version the same way as NewVersion after
+// replacing confusable characters with MapConfusables. The replacements made
+// are returned so they can be reported as warnings. Numeric prerelease
+// identifiers written with leading zeros, which NewVersion rejects, are read
+// as the number they hold, so 1.0.0-01 is 1.0.0-1.
+func LenientNewVersion(s string) (*Version, []Confusable, error) {
+	m, found := MapConfusables(s)
+	v, err := NewVersion(m)
+	if err == ErrSegmentStartsZero {
+		if v, err = NewVersion(trimPrereleaseZeros(m)); err == nil {
+			v.set(v.Prerelease(), v.Metadata(), m)
+		}
+	}
+	return v, found, err
+}
+
+// LenientNewConstraint parses constraints the same way as NewConstraint
+// after replacing confusable characters with MapConfusables. The replacements
+// made are returned so they can be reported as warnings.
+func LenientNewConstraint(c string) (*Constraints, []Confusable, error) {
+	m, found := MapConfusables(c)
+	cs, err := NewConstraint(m)
+	return cs, found, err
+}
+
+// confusables maps the characters other than full-width forms and decimal
+// digits that are commonly mistaken for ASCII.
+var confusables = map[rune]string{
+	'\u00a0': " ", // no-break space
+	'\u2000': " ", // en quad
+	'\u2001': " ", // em quad
+	'\u2002': " ", // en space
+	'\u2003': " ", // em space
+	'\u2004': " ", // three-per-em space
+	'\u2005': " ", // four-per-em space
+	'\u2006': " ", // six-per-em space
+	'\u2007': " ", // figure space
+	'\u2008': " ", // punctuation space
+	'\u2009': " ", // thin space
+	'\u200a': " ", // hair space
+	'\u202f': " ", // narrow no-break space
+	'\u205f': " ", // medium mathematical space
+	'\u3000': " ", // ideographic space
+	'\u200b': "",  // zero width space
+	'\u2060': "",  // word joiner
+	'\ufeff': "",  // byte order mark
+	'\u2010': "-", // hyphen
+	'\u2011': "-", // non-breaking hyphen
+	'\u2012': "-", // figure dash
+	'\u2013': "-", // en dash
+	'\u2014': "-", // em dash
+	'\u2212': "-", // minus sign
+	'\ufe63': "-", // small hyphen-minus
+	'\u2024': ".", // one dot leader
+	'\u3002': ".", // ideographic full stop
+	'\u2264': "<=",
+	'\u2265': ">=",
+	'\u2260': "!=",
+	'\u02dc': "~", // small tilde
+	'\u02c6': "^", // modifier letter circumflex
+}
+
+// confusable returns the ASCII r is commonly mistaken for.
+func confusable(r rune) (string, bool) {
+	// Full-width forms of the printable ASCII characters.
+	if r >= '\uff01' && r <= '\uff5e' {
+		return string(r - 0xfee0), true
+	}
+	if rep, ok := confusables[r]; ok {
+		return rep, true
+	}
+	if unicode.Is(unicode.Nd, r) {
+		return string(rune('0' + digitValue(r))), true
+	}
+	return "", false
+}
+
+// digitValue returns the value of a Unicode decimal digit. The decimal
+// digits of each script are assigned in order from zero, and the ranges of
+// unicode.Nd start at a zero.
+func digitValue(r rune) rune {
+	for _, rg := range unicode.Nd.R16 {
+		if r >= rune(rg.Lo) && r <= rune(rg.Hi) {
+			return (r - rune(rg.Lo)) % 10
+		}
+	}
+	for _, rg := range unicode.Nd.R32 {
+		if r >= rune(rg.Lo) && r <= rune(rg.Hi) {
+			return (r - rune(rg.Lo)) % 10
+		}
+	}
+	return 0
+}
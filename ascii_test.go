@@ -0,0 +1,186 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNonASCIIRejected(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+		char   rune
+	}{
+		{"１.2.3", 0, '１'},                   // full-width 1
+		{"1.2.٣", 4, '٣'},                   // Arabic-Indic 3
+		{"1.२.3", 2, '२'},                   // Devanagari 2
+		{"1.2.3‐beta", 5, '‐'},              // hyphen
+		{"1.2.3–beta", 5, '–'},              // en dash
+		{"1.2．3", 3, '．'},                   // full-width full stop
+		{"1.2.3\u00a0", 5, '\u00a0'},        // no-break space
+		{"\ufeff1.2.3", 0, '\ufeff'},        // byte order mark
+		{"1.2.3-ß", 6, 'ß'},                 // sharp s
+		{"1.2.3+é", 6, 'é'},                 // e acute
+		{"1.2.3-beta\xff", 10, '\ufffd'},    // invalid UTF-8
+		{"v1.2.3-rc.1\u200b", 11, '\u200b'}, // zero width space
+	}
+
+	for _, tc := range tests {
+		parsers := map[string]func(string) error{
+			"NewVersion": func(s string) error {
+				_, err := NewVersion(s)
+				return err
+			},
+			"StrictNewVersion": func(s string) error {
+				_, err := StrictNewVersion(s)
+				return err
+			},
+			"ParseBulk": func(s string) error {
+				_, err := ParseBulk([]string{s})
+				if be, ok := err.(*BulkError); ok {
+					return be.Err
+				}
+				return err
+			},
+		}
+		for name, parse := range parsers {
+			err := parse(tc.input)
+			ne, ok := err.(*NonASCIIError)
+			if !ok {
+				t.Errorf("%s: expected a NonASCIIError for %q but got %v", name, tc.input, err)
+				continue
+			}
+			if ne.Input != tc.input || ne.Offset != tc.offset || ne.Char != tc.char {
+				t.Errorf("%s: expected %q at offset %d of %q but got %q at %d of %q", name, tc.char, tc.offset, tc.input, ne.Char, ne.Offset, ne.Input)
+			}
+		}
+		if LooksLikeVersion(tc.input) {
+			t.Errorf("expected %q not to look like a version", tc.input)
+		}
+	}
+}
+
+func TestNonASCIIConstraintRejected(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+		char   rune
+	}{
+		{"≥1.2", 0, '≥'},
+		{">=１.2", 2, '１'},
+		{"＾1.2", 0, '＾'},
+		{"^1.2\u00a0|| ^2", 4, '\u00a0'},
+		{"^1.2 ||\u00a0", 7, '\u00a0'},
+		{"^1.2\u3000,<2", 4, '\u3000'},
+		{"1.2 – 1.4", 4, '–'},
+		{"^1.2 ｜｜ ^2", 5, '｜'},
+	}
+
+	for _, tc := range tests {
+		for _, parse := range []func(string) (*Constraints, error){NewConstraint, StrictNewConstraint} {
+			_, err := parse(tc.input)
+			ne, ok := err.(*NonASCIIError)
+			if !ok {
+				t.Errorf("expected a NonASCIIError for %q but got %v", tc.input, err)
+				continue
+			}
+			if ne.Offset != tc.offset || ne.Char != tc.char {
+				t.Errorf("expected %q at offset %d of %q but got %q at %d", tc.char, tc.offset, tc.input, ne.Char, ne.Offset)
+			}
+		}
+	}
+}
+
+func TestNonASCIIErrorMessage(t *testing.T) {
+	_, err := NewVersion("1.2.3–beta")
+	expected := "1.2.3–beta has the non-ASCII character '–' at offset 5, which looks like \"-\""
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected the error %q but got %v", expected, err)
+	}
+
+	_, err = NewVersion("1.2.3-ß")
+	expected = "1.2.3-ß has the non-ASCII character 'ß' at offset 6"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected the error %q but got %v", expected, err)
+	}
+}
+
+func TestMapConfusables(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		found    []Confusable
+	}{
+		{"1.2.3", "1.2.3", nil},
+		{"１.２.３", "1.2.3", []Confusable{{0, '１', "1"}, {4, '２', "2"}, {8, '３', "3"}}},
+		{"1.2.٣", "1.2.3", []Confusable{{4, '٣', "3"}}},
+		{"१०.0.0", "10.0.0", []Confusable{{0, '१', "1"}, {3, '०', "0"}}},
+		{"1.0.0–rc.1", "1.0.0-rc.1", []Confusable{{5, '–', "-"}}},
+		{"1.0.0−rc", "1.0.0-rc", []Confusable{{5, '−', "-"}}},
+		{"\ufeff1.2.3", "1.2.3", []Confusable{{0, '\ufeff', ""}}},
+		{"≥1.2\u00a0≤2", ">=1.2 <=2", []Confusable{{0, '≥', ">="}, {6, '\u00a0', " "}, {8, '≤', "<="}}},
+		{"≠1.3", "!=1.3", []Confusable{{0, '≠', "!="}}},
+		{"＾1 ｜｜ ～2", "^1 || ~2", []Confusable{{0, '＾', "^"}, {5, '｜', "|"}, {8, '｜', "|"}, {12, '～', "~"}}},
+		{"1.2.3-ß", "1.2.3-ß", nil},
+		{"1.2\xff", "1.2\xff", nil},
+	}
+
+	for _, tc := range tests {
+		m, found := MapConfusables(tc.input)
+		if m != tc.expected {
+			t.Errorf("expected %q to map to %q but got %q", tc.input, tc.expected, m)
+		}
+		if !reflect.DeepEqual(found, tc.found) {
+			t.Errorf("expected %q to report %v but got %v", tc.input, tc.found, found)
+		}
+	}
+}
+
+func TestDigitValue(t *testing.T) {
+	for _, zero := range []rune{'0', '٠', '۰', '०', '０', '\U0001d7ce', '\U0001d7d8'} {
+		for d := rune(0); d < 10; d++ {
+			if got := digitValue(zero + d); got != d {
+				t.Errorf("expected %q to have the value %d but got %d", zero+d, d, got)
+			}
+		}
+	}
+}
+
+func TestLenientNewVersion(t *testing.T) {
+	v, found, err := LenientNewVersion("１.2.3‐beta")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v.String() != "1.2.3-beta" {
+		t.Errorf("expected 1.2.3-beta but got %s", v)
+	}
+	if len(found) != 2 || found[0].String() != `offset 0: '１' read as "1"` {
+		t.Errorf("unexpected replacements %v", found)
+	}
+
+	v, found, err = LenientNewVersion("1.2.3")
+	if err != nil || v.String() != "1.2.3" || found != nil {
+		t.Errorf("expected 1.2.3 without replacements but got %v %v %v", v, found, err)
+	}
+
+	_, _, err = LenientNewVersion("1.2.3-ß")
+	if _, ok := err.(*NonASCIIError); !ok {
+		t.Errorf("expected characters without a mapping to be rejected but got %v", err)
+	}
+}
+
+func TestLenientNewConstraint(t *testing.T) {
+	c, found, err := LenientNewConstraint("≥1.2\u3000＜2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if c.String() != ">=1.2 <2" {
+		t.Errorf("expected >=1.2 <2 but got %s", c)
+	}
+	if len(found) != 3 {
+		t.Errorf("expected 3 replacements but got %v", found)
+	}
+	if !c.Check(MustParse("1.5.0")) {
+		t.Errorf("expected %s to admit 1.5.0", c)
+	}
+}
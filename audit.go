@@ -0,0 +1,73 @@
+package semver
+
+import "fmt"
+
+// AuditSequence checks versions in the order they were published, such as a
+// list of tags, for publishing mistakes. It reports:
+//
+//   - downgrades, versions lower than one already published in the same minor
+//     series, as backports to older series are expected
+//   - skipped versions, where a version is not the next patch, minor, or major
+//     version after the greatest release below it
+//   - duplicates, versions published again differing at most in metadata
+//   - prereleases published after the release they precede
+//
+// Each version is reported at most once, with the first of the issues above
+// that applies in the order duplicate, prerelease after release, downgrade,
+// and skipped. The first version published is never reported as skipped.
+func AuditSequence(vs []*Version) []Finding {
+	var f []Finding
+	for k, v := range vs {
+		prior := vs[:k]
+		report := func(kind FindingKind, format string, args ...interface{}) {
+			f = append(f, Finding{
+				Kind:    kind,
+				Branch:  k,
+				Clause:  v.Original(),
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		core := newCoreVersion(v.major, v.minor, v.patch)
+		var dup, final, greatest, below *Version
+		for _, p := range prior {
+			switch {
+			case dup == nil && p.Equal(v):
+				dup = p
+			case v.Prerelease() != "" && p.Prerelease() == "" && p.Equal(core):
+				final = p
+			}
+			if p.major == v.major && p.minor == v.minor && (greatest == nil || p.GreaterThan(greatest)) {
+				greatest = p
+			}
+			if p.Prerelease() == "" && p.LessThan(core) && (below == nil || p.GreaterThan(below)) {
+				below = p
+			}
+		}
+
+		switch {
+		case dup != nil:
+			report(FindingDuplicate, "%s duplicates %s, differing at most in metadata", v.Original(), dup.Original())
+		case final != nil:
+			report(FindingPrereleaseAfterRelease, "%s is published after its release %s", v.Original(), final.Original())
+		case greatest != nil && v.LessThan(greatest):
+			report(FindingDowngrade, "%s is published after %s in the same series", v.Original(), greatest.Original())
+		case below != nil && !nextAfter(below, core):
+			report(FindingSkipped, "%s skips over versions after %s", v.Original(), below.Original())
+		}
+	}
+	return f
+}
+
+// nextAfter reports if the release core is the next patch, minor, or major
+// release after the release v.
+func nextAfter(v, core *Version) bool {
+	switch {
+	case core.major == v.major && core.minor == v.minor:
+		return core.patch == v.patch+1
+	case core.major == v.major:
+		return core.minor == v.minor+1 && core.patch == 0
+	default:
+		return core.major == v.major+1 && core.minor == 0 && core.patch == 0
+	}
+}
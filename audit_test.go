@@ -0,0 +1,48 @@
+package semver
+
+import "testing"
+
+func TestAuditSequence(t *testing.T) {
+	tests := []struct {
+		sequence []string
+		expected []string
+	}{
+		{[]string{"1.0.0", "1.0.1", "1.1.0-rc.1", "1.1.0", "2.0.0", "1.1.1", "v2.1.0"}, nil},
+		{[]string{"1.0.0", "1.0.2", "1.0.1"}, []string{
+			"skipped: 1.0.2 skips over versions after 1.0.0",
+			"downgrade: 1.0.1 is published after 1.0.2 in the same series",
+		}},
+		{[]string{"1.2.4", "2.0.0", "1.4.0"}, []string{
+			"skipped: 1.4.0 skips over versions after 1.2.4",
+		}},
+		{[]string{"1.0.0", "3.0.0-beta.1"}, []string{
+			"skipped: 3.0.0-beta.1 skips over versions after 1.0.0",
+		}},
+		{[]string{"1.2.3+build.1", "1.2.3+build.2", "1.2.3"}, []string{
+			"duplicate: 1.2.3+build.2 duplicates 1.2.3+build.1, differing at most in metadata",
+			"duplicate: 1.2.3 duplicates 1.2.3+build.1, differing at most in metadata",
+		}},
+		{[]string{"1.0.0", "1.1.0-rc.2", "1.1.0-rc.1", "1.1.0", "1.1.0-rc.3"}, []string{
+			"downgrade: 1.1.0-rc.1 is published after 1.1.0-rc.2 in the same series",
+			"prerelease-after-release: 1.1.0-rc.3 is published after its release 1.1.0",
+		}},
+		{nil, nil},
+	}
+
+	for _, tc := range tests {
+		var vs []*Version
+		for _, s := range tc.sequence {
+			vs = append(vs, MustParse(s))
+		}
+		var got []string
+		for _, f := range AuditSequence(vs) {
+			got = append(got, f.String())
+			if vs[f.Branch].Original() != f.Clause {
+				t.Errorf("expected the finding %q to be at the index of %s but it is at %d", f, f.Clause, f.Branch)
+			}
+		}
+		if !equalStrings(got, tc.expected) {
+			t.Errorf("expected the findings for %q to be %q but got %q", tc.sequence, tc.expected, got)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package semver
 
 import (
+	"fmt"
+	"runtime"
 	"testing"
 )
 
@@ -245,3 +247,170 @@ func BenchmarkStrictNewVersionMetaDash(b *testing.B) {
 	b.ResetTimer()
 	benchStrictNewVersion("1.0.0-alpha.1+meta.data", b)
 }
+
+/* Pre-validation benchmarks */
+
+// benchGarbage is a list of tags of which most are not versions.
+var benchGarbage = []string{"main", "release/1.2", "3f2a9c1d", "feature-x", "v1.2.3", "nightly", "latest", "1.2.3-rc.1"}
+
+func BenchmarkLooksLikeVersion(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range benchGarbage {
+			_ = LooksLikeVersion(s)
+		}
+	}
+}
+
+func BenchmarkNewVersionGarbage(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range benchGarbage {
+			_, _ = NewVersion(s)
+		}
+	}
+}
+
+/* Bulk parsing benchmarks */
+
+func benchBulkInputs() []string {
+	inputs := make([]string, 10000)
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("1.%d.%d-beta.%d", i/100, i%100, i%7)
+	}
+	return inputs
+}
+
+func BenchmarkParseBulk(b *testing.B) {
+	inputs := benchBulkInputs()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseBulk(inputs)
+	}
+}
+
+func BenchmarkParseEach(b *testing.B) {
+	inputs := benchBulkInputs()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vs := make([]*Version, len(inputs))
+		for k, s := range inputs {
+			vs[k], _ = NewVersion(s)
+		}
+	}
+}
+
+/* Union benchmarks */
+
+// These benchmarks build a union of 1000 constraints one part at a time, the
+// way advisories are aggregated.
+
+func benchUnionParts(b *testing.B) []*Constraints {
+	parts := make([]*Constraints, 1000)
+	for i := range parts {
+		c, err := NewConstraint(fmt.Sprintf(">=%d.0.0, <%d.5.0", i, i))
+		if err != nil {
+			b.Fatal(err)
+		}
+		parts[i] = c
+	}
+	return parts
+}
+
+func BenchmarkUnionIncremental(b *testing.B) {
+	parts := benchUnionParts(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u := parts[0]
+		for _, p := range parts[1:] {
+			u = Union(u, p)
+		}
+	}
+}
+
+func BenchmarkUnionIncrementalBranching(b *testing.B) {
+	parts := benchUnionParts(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Each union is first extended by a union that is thrown away, so
+		// extending it again copies. This is the worst case for Union.
+		u := parts[0]
+		for _, p := range parts[1:] {
+			_ = Union(u, parts[0])
+			u = Union(u, p)
+		}
+	}
+}
+
+/* Memory benchmarks */
+
+// BenchmarkVersionMemory reports the bytes held per version when keeping many
+// parsed versions, the Version itself and the string it was parsed from
+// included. A Version takes 56 bytes on 64-bit platforms as the prerelease
+// and metadata are kept as offsets into the original.
+func BenchmarkVersionMemory(b *testing.B) {
+	const n = 10000
+	inputs := make([]string, n)
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("v1.%d.%d-beta.%d+build.%d", i/100, i%100, i%7, i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	var held []*Version
+	for i := 0; i < b.N; i++ {
+		var before, after runtime.MemStats
+		held = nil
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		held = make([]*Version, n)
+		for k, s := range inputs {
+			held[k], _ = NewVersion(s)
+		}
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/n, "heap-B/version")
+	}
+	runtime.KeepAlive(held)
+}
+
+/* Startup benchmarks */
+
+// The regular expressions are compiled on first use rather than when the
+// package is initialized. These benchmarks measure the first parse in a
+// process, compiling the regular expressions it uses included.
+
+// resetRegexps replaces the regular expressions with uncompiled copies.
+func resetRegexps(rs ...**lazyRegexp) {
+	for _, r := range rs {
+		*r = newLazyRegexpFunc((*r).expr)
+	}
+}
+
+func BenchmarkStartupNewVersion(b *testing.B) {
+	saved := versionRegex
+	defer func() { versionRegex = saved }()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resetRegexps(&versionRegex)
+		_, _ = NewVersion("1.2.3")
+	}
+}
+
+func BenchmarkStartupNewConstraint(b *testing.B) {
+	saved := []*lazyRegexp{versionRegex, constraintRegex, findConstraintRegex, validConstraintRegex}
+	defer func() {
+		versionRegex, constraintRegex, findConstraintRegex, validConstraintRegex = saved[0], saved[1], saved[2], saved[3]
+	}()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resetRegexps(&versionRegex, &constraintRegex, &findConstraintRegex, &validConstraintRegex)
+		_, _ = NewConstraint("^1.2.3")
+	}
+}
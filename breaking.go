@@ -0,0 +1,146 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+)
+
+// BreakingRange is a run of consecutive breaking change boundaries. A boundary
+// is the first release of a series of compatible versions: a major version
+// such as 2.0.0, or in 0.x, where each minor version may break the one before,
+// a minor version such as 0.3.0. The boundaries are ordered with the 0.x minor
+// versions before 1.0.0, so a run from 0.9.0 to 2.0.0 holds 0.9.0, 0.10.0, and
+// every later 0.x minor version followed by 1.0.0 and 2.0.0.
+type BreakingRange struct {
+	// First is the first boundary in the run.
+	First *Version
+
+	// Last is the last boundary in the run, or nil when the run holds every
+	// boundary from First on.
+	Last *Version
+}
+
+// String returns the range as a single boundary such as 2.0.0, a hyphen range
+// such as 0.3.0 - 1.0.0, or a range with no end such as >=2.0.0.
+func (r BreakingRange) String() string {
+	switch {
+	case r.Last == nil:
+		return ">=" + r.First.String()
+	case r.First.Equal(r.Last):
+		return r.First.String()
+	}
+	return fmt.Sprintf("%s - %s", r.First, r.Last)
+}
+
+// SpansBreakingChange reports if the constraints admit releases from more
+// than one series of compatible versions, so they cross a major version
+// boundary or, in 0.x, a minor version boundary. `^1.2` and `~0.3` do not,
+// while `>=1.2 <3` and `^1 || ^2` do. BreakingBoundaries returns the
+// boundaries crossed. A nil c admits no versions.
+func SpansBreakingChange(c *Constraints) bool {
+	return len(BreakingBoundaries(c)) > 0
+}
+
+// BreakingBoundaries returns the boundaries the constraints cross, as ranges
+// in order. A boundary is crossed when the constraints admit a release in its
+// series and one in an earlier series, so `>=1.2 <4` crosses 2.0.0 - 3.0.0
+// and `^1 || ^3` crosses 3.0.0. The constraints are read with the options they
+// were created with. Only releases are considered, as with DiffConstraints.
+// The releases are those the constraints may admit, as with Ranges, so with
+// MetadataEqual `=1.2.3+abc, !=1.2.3+def || ^2` crosses 2.0.0.
+func BreakingBoundaries(c *Constraints) []BreakingRange {
+	var out []BreakingRange
+	var covered series
+	may, _ := orNone(c, nil).sets()
+	for k, i := range may.releases {
+		lo := seriesOf(0, 0)
+		if i.min.v != nil {
+			lo = seriesOf(i.min.v.major, i.min.v.minor)
+		}
+		if k == 0 {
+			covered = lo
+		}
+
+		// The series up to the one covered so far were already crossed or
+		// are the lowest.
+		first, ok := lo, lo.after(covered)
+		if !ok {
+			if first, ok = covered.next(); !ok {
+				return out
+			}
+		}
+
+		if i.max.v == nil {
+			return appendBreaking(out, first, nil)
+		}
+		hi := seriesBelow(i.max.v)
+		if !first.after(hi) {
+			out = appendBreaking(out, first, hi.start())
+			covered = hi
+		}
+	}
+	return out
+}
+
+// appendBreaking appends the run from first to last, extending the final run
+// when first directly follows it.
+func appendBreaking(out []BreakingRange, first series, last *Version) []BreakingRange {
+	if n := len(out); n > 0 && out[n-1].Last != nil {
+		prev := seriesOf(out[n-1].Last.major, out[n-1].Last.minor)
+		if next, ok := prev.next(); ok && next == first {
+			out[n-1].Last = last
+			return out
+		}
+	}
+	return append(out, BreakingRange{First: first.start(), Last: last})
+}
+
+// series is a series of compatible versions, identified by its major version
+// or, in 0.x, its minor version.
+type series struct {
+	major, minor uint64
+}
+
+// seriesOf returns the series holding the version major.minor.
+func seriesOf(major, minor uint64) series {
+	if major == 0 {
+		return series{minor: minor}
+	}
+	return series{major: major}
+}
+
+// seriesBelow returns the series holding the release just below the release
+// v, which must not be 0.0.0.
+func seriesBelow(v *Version) series {
+	switch {
+	case v.patch > 0:
+		return seriesOf(v.major, v.minor)
+	case v.minor > 0:
+		return seriesOf(v.major, v.minor-1)
+	}
+	return seriesOf(v.major-1, math.MaxUint64)
+}
+
+// after reports if s comes after o.
+func (s series) after(o series) bool {
+	if s.major != o.major {
+		return s.major > o.major
+	}
+	return s.minor > o.minor
+}
+
+// next returns the series following s, or false when s is the last.
+func (s series) next() (series, bool) {
+	switch {
+	case s.major == 0 && s.minor < math.MaxUint64:
+		return series{minor: s.minor + 1}, true
+	case s.major < math.MaxUint64:
+		return series{major: s.major + 1}, true
+	}
+	return s, false
+}
+
+// start returns the first release of the series.
+func (s series) start() *Version {
+	return newCoreVersion(s.major, s.minor, 0)
+}
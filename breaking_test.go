@@ -0,0 +1,74 @@
+package semver
+
+import "testing"
+
+func TestBreakingBoundaries(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   []string
+	}{
+		{"^1.2", nil},
+		{"~0.3", nil},
+		{"^0.0.3", nil},
+		{"1.2.3", nil},
+		{"1.0.0 - 1.5.0 || 1.8 - 1.9", nil},
+		{"<0.0.0", nil},
+		{"<0.0.0-0", nil},
+		{">=1.2 <3", []string{"2.0.0"}},
+		{">=1.2 <3.0.0-0", []string{"2.0.0"}},
+		{">=1.2 <4", []string{"2.0.0 - 3.0.0"}},
+		{">=1.2 <=2.0.0", []string{"2.0.0"}},
+		{">=0.2.0 <0.4.0", []string{"0.3.0"}},
+		{">=0.9.5 <1.0.1", []string{"0.10.0 - 1.0.0"}},
+		{"<=2.0.0", []string{"0.1.0 - 2.0.0"}},
+		{"^1 || ^2", []string{"2.0.0"}},
+		{"^1 || ^3", []string{"3.0.0"}},
+		{"^1 || ^3 || ^4", []string{"3.0.0 - 4.0.0"}},
+		{"^0.1 || ^0.3 || ^0.4", []string{"0.3.0 - 0.4.0"}},
+		{"^1 || ^3 || ^5", []string{"3.0.0", "5.0.0"}},
+		{"^1 || >=1.5 <2 || ^3", []string{"3.0.0"}},
+		{">=1.2", []string{">=2.0.0"}},
+		{"^1 || >=3", []string{">=3.0.0"}},
+		{"*", []string{">=0.1.0"}},
+		{">=18446744073709551615.0.0", nil},
+		{">=18446744073709551614.5.0", []string{">=18446744073709551615.0.0"}},
+	}
+
+	for _, tc := range tests {
+		c := mustConstraint(t, tc.constraint)
+		var got []string
+		for _, r := range BreakingBoundaries(c) {
+			got = append(got, r.String())
+		}
+		if !equalStrings(got, tc.expected) {
+			t.Errorf("expected %s to cross %q but got %q", tc.constraint, tc.expected, got)
+		}
+		if SpansBreakingChange(c) != (len(tc.expected) > 0) {
+			t.Errorf("expected SpansBreakingChange of %s to be %t", tc.constraint, len(tc.expected) > 0)
+		}
+	}
+
+	c, err := NewConstraintWithOptions("=1.2.3+abc, !=1.2.3+def || ^2", MatchOptions{Metadata: MetadataEqual})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := BreakingBoundaries(c); len(r) != 1 || r[0].String() != "2.0.0" {
+		t.Errorf("expected 1.2.3+abc to cross 2.0.0 but got %v", r)
+	}
+
+	if SpansBreakingChange(nil) || SpansBreakingChange(None()) {
+		t.Error("expected constraints admitting nothing to cross no boundaries")
+	}
+}
+
+func TestBreakingRange(t *testing.T) {
+	r := BreakingBoundaries(mustConstraint(t, ">=0.9.5 <2"))
+	if len(r) != 1 || r[0].First.String() != "0.10.0" || r[0].Last.String() != "1.0.0" {
+		t.Fatalf("unexpected ranges %v", r)
+	}
+
+	r = BreakingBoundaries(mustConstraint(t, ">=1"))
+	if len(r) != 1 || r[0].First.String() != "2.0.0" || r[0].Last != nil {
+		t.Fatalf("unexpected ranges %v", r)
+	}
+}
@@ -0,0 +1,246 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CommitMeta is the part of a commit that decides the next version under
+// Conventional Commits. It can be filled in directly or parsed from a commit
+// message with ParseCommitMessage.
+type CommitMeta struct {
+	// Type is the type of the commit, such as "feat" or "fix".
+	Type string
+
+	// Scope is the optional scope of the commit, such as "parser" in
+	// "fix(parser): ...".
+	Scope string
+
+	// Breaking reports if the commit makes a breaking change, marked by a !
+	// after the type or scope or by a BREAKING CHANGE footer.
+	Breaking bool
+}
+
+var commitHeaderRegex = newLazyRegexp(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: `)
+
+// ParseCommitMessage parses the header and footers of a commit message
+// following Conventional Commits. A message that does not follow it has no
+// type and is not breaking.
+func ParseCommitMessage(msg string) CommitMeta {
+	var c CommitMeta
+	if m := commitHeaderRegex.FindStringSubmatch(msg); m != nil {
+		c.Type = strings.ToLower(m[1])
+		c.Scope = m[2]
+		c.Breaking = m[3] != ""
+	}
+	for _, line := range strings.Split(msg, "\n")[1:] {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			c.Breaking = true
+		}
+	}
+	return c
+}
+
+// The levels of change a release can make, from least to most.
+const (
+	bumpNone = iota
+	bumpPatch
+	bumpMinor
+	bumpMajor
+)
+
+// NextVersion returns the version following current for a release holding the
+// commits. A breaking change bumps the major version, a feat commit the minor
+// version, and a fix commit the patch version. Before 1.0.0 the public API is
+// not considered stable so a breaking change bumps the minor version instead.
+// Commits of other types do not call for a release and when none of the
+// commits do, a copy of current is returned.
+//
+// When current is a prerelease, the release it precedes is used when it
+// already makes a big enough change. For example, a feat commit on top of
+// 1.3.0-rc.1 gives 1.3.0 while a breaking change gives 2.0.0.
+//
+// Build metadata is dropped from the returned version.
+func NextVersion(current *Version, commits []CommitMeta) *Version {
+	level := bumpNone
+	for _, c := range commits {
+		l := bumpNone
+		switch {
+		case c.Breaking && current.major == 0:
+			l = bumpMinor
+		case c.Breaking:
+			l = bumpMajor
+		case strings.EqualFold(c.Type, "feat"):
+			l = bumpMinor
+		case strings.EqualFold(c.Type, "fix"):
+			l = bumpPatch
+		}
+		if l > level {
+			level = l
+		}
+	}
+
+	var next Version
+	switch {
+	case level == bumpNone:
+		next = *current
+	case current.Prerelease() != "" && level <= prereleaseLevel(current):
+		// Releasing the prerelease already makes the change.
+		next = current.IncPatch()
+	case level == bumpMajor:
+		next = current.IncMajor()
+	case level == bumpMinor:
+		next = current.IncMinor()
+	default:
+		next = current.IncPatch()
+	}
+	return &next
+}
+
+// prereleaseLevel returns the change made by releasing the prerelease v over
+// the release before it.
+func prereleaseLevel(v *Version) int {
+	switch {
+	case v.patch > 0:
+		return bumpPatch
+	case v.minor > 0:
+		return bumpMinor
+	}
+	return bumpMajor
+}
+
+// IncrementPrerelease returns the next prerelease in a sequence labelled by
+// label, such as rc. The number after the label is incremented, so
+// 1.5.0-rc.1 becomes 1.5.0-rc.2. A version without a prerelease starts the
+// sequence at 1, so 1.5.0 becomes 1.5.0-rc.1. That is a prerelease of 1.5.0
+// itself, so to start the prereleases of the next release bump the version
+// first, as in IncrementPrerelease(&next, "rc") where next is v.IncMinor().
+//
+// A prerelease with a different label starts the new label at 1, so
+// 1.5.0-beta.3 becomes 1.5.0-rc.1, as long as the new prerelease comes after
+// the old one. An empty label increments the number ending the current
+// prerelease whatever its label.
+//
+// An error is returned when the prerelease does not end in a number to
+// increment, when the label is not a valid prerelease, when the number would
+// overflow, or when changing the label would go backwards. Build metadata is
+// dropped from the returned version.
+func IncrementPrerelease(v *Version, label string) (*Version, error) {
+	if label != "" {
+		if err := validatePrerelease(label); err != nil {
+			return nil, fmt.Errorf("%q is not a valid prerelease label: %s", label, err)
+		}
+	}
+
+	var pre string
+	switch {
+	case v.Prerelease() == "" && label == "":
+		return nil, fmt.Errorf("%s is not a prerelease and no label was given to start one", v)
+	case v.Prerelease() == "":
+		pre = label + ".1"
+	case label == "" || strings.HasPrefix(v.Prerelease(), label+"."):
+		i := strings.LastIndex(v.Prerelease(), ".")
+		head, tail := v.Prerelease()[:i+1], v.Prerelease()[i+1:]
+		if !containsOnly(tail, num) {
+			return nil, fmt.Errorf("%s can not be incremented as its prerelease does not end in a number", v)
+		}
+		n, err := strconv.ParseUint(tail, 10, 64)
+		if err != nil || n == math.MaxUint64 {
+			return nil, fmt.Errorf("%s can not be incremented as its prerelease number is too large", v)
+		}
+		pre = head + strconv.FormatUint(n+1, 10)
+	case v.Prerelease() == label:
+		pre = label + ".1"
+	default:
+		pre = label + ".1"
+		if comparePrerelease(pre, v.Prerelease(), false) <= 0 {
+			return nil, fmt.Errorf("changing the prerelease of %s to %s would go backwards", v, pre)
+		}
+	}
+
+	next := *v
+	next.setCanonical(pre, "", v.originalVPrefix())
+	return &next, nil
+}
+
+// Promote returns the release a prerelease precedes, turning 2.0.0-rc.3 into
+// 2.0.0. Build metadata is dropped unless keepMetadata is true, in which case
+// 2.0.0-rc.3+build.9 becomes 2.0.0+build.9. An error is returned when v is not
+// a prerelease as there is nothing to promote.
+func Promote(v *Version, keepMetadata bool) (*Version, error) {
+	if v.Prerelease() == "" {
+		return nil, fmt.Errorf("%s is not a prerelease", v)
+	}
+
+	metadata := ""
+	if keepMetadata {
+		metadata = v.Metadata()
+	}
+	next := *v
+	next.setCanonical("", metadata, v.originalVPrefix())
+	return &next, nil
+}
+
+// ReleaseTarget is the release a sequence of prereleases leads to, relative
+// to the current release.
+type ReleaseTarget int
+
+const (
+	// TargetPatch is the next patch release.
+	TargetPatch ReleaseTarget = iota
+
+	// TargetMinor is the next minor release.
+	TargetMinor
+
+	// TargetMajor is the next major release.
+	TargetMajor
+)
+
+// NextPrereleaseToward returns the next prerelease leading to the target
+// release after current, taking the prereleases already published into
+// account. For example, with a current release of 1.4.2 and TargetMajor, the
+// first prerelease labelled alpha is 2.0.0-alpha.1. Once that is published the
+// next is 2.0.0-alpha.2.
+//
+// The highest published prerelease of the target release is incremented the
+// same way as IncrementPrerelease, so moving from alpha to beta starts at
+// beta.1 and an error is returned when the label would go backwards. Published
+// versions that are not prereleases of the target release are ignored. An
+// error is returned when current is itself a prerelease.
+func NextPrereleaseToward(current *Version, target ReleaseTarget, label string, published []*Version) (*Version, error) {
+	if current.Prerelease() != "" {
+		return nil, fmt.Errorf("%s is a prerelease rather than a release", current)
+	}
+
+	var release Version
+	switch target {
+	case TargetMajor:
+		release = current.IncMajor()
+	case TargetMinor:
+		release = current.IncMinor()
+	default:
+		release = current.IncPatch()
+	}
+
+	var highest *Version
+	for _, p := range published {
+		if p.Prerelease() == "" || p.major != release.major || p.minor != release.minor || p.patch != release.patch {
+			continue
+		}
+		if highest == nil || p.Compare(highest) > 0 {
+			highest = p
+		}
+	}
+	if highest == nil {
+		return IncrementPrerelease(&release, label)
+	}
+
+	next, err := IncrementPrerelease(highest, label)
+	if err != nil {
+		return nil, err
+	}
+	next.setCanonical(next.Prerelease(), next.Metadata(), release.originalVPrefix())
+	return next, nil
+}
@@ -0,0 +1,194 @@
+package semver
+
+import "testing"
+
+func TestParseCommitMessage(t *testing.T) {
+	tests := []struct {
+		msg      string
+		expected CommitMeta
+	}{
+		{"feat: add a thing", CommitMeta{Type: "feat"}},
+		{"fix(parser): handle x", CommitMeta{Type: "fix", Scope: "parser"}},
+		{"feat!: drop support", CommitMeta{Type: "feat", Breaking: true}},
+		{"refactor(api)!: rename", CommitMeta{Type: "refactor", Scope: "api", Breaking: true}},
+		{"Fix: capitalized", CommitMeta{Type: "fix"}},
+		{"feat: thing\n\nBody text.\n\nBREAKING CHANGE: the old thing is gone", CommitMeta{Type: "feat", Breaking: true}},
+		{"chore: deps\n\nBREAKING-CHANGE: node 18", CommitMeta{Type: "chore", Breaking: true}},
+		{"BREAKING CHANGE: in the header", CommitMeta{}},
+		{"Update the readme", CommitMeta{}},
+		{"feat:missing space", CommitMeta{}},
+	}
+
+	for _, tc := range tests {
+		if got := ParseCommitMessage(tc.msg); got != tc.expected {
+			t.Errorf("expected %q to parse as %+v but got %+v", tc.msg, tc.expected, got)
+		}
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	fix := CommitMeta{Type: "fix"}
+	feat := CommitMeta{Type: "feat"}
+	breaking := CommitMeta{Type: "fix", Breaking: true}
+	chore := CommitMeta{Type: "chore"}
+
+	tests := []struct {
+		current  string
+		commits  []CommitMeta
+		expected string
+	}{
+		{"1.2.3", []CommitMeta{fix}, "1.2.4"},
+		{"1.2.3", []CommitMeta{fix, feat}, "1.3.0"},
+		{"1.2.3", []CommitMeta{feat, breaking, fix}, "2.0.0"},
+		{"1.2.3", []CommitMeta{chore}, "1.2.3"},
+		{"1.2.3", nil, "1.2.3"},
+		{"v1.2.3", []CommitMeta{feat}, "v1.3.0"},
+		{"1.2.3+build.5", []CommitMeta{fix}, "1.2.4"},
+		{"0.4.1", []CommitMeta{breaking}, "0.5.0"},
+		{"0.4.1", []CommitMeta{feat}, "0.5.0"},
+		{"0.4.1", []CommitMeta{fix}, "0.4.2"},
+		{"1.3.0-rc.1", []CommitMeta{fix}, "1.3.0"},
+		{"1.3.0-rc.1", []CommitMeta{feat}, "1.3.0"},
+		{"1.3.0-rc.1", []CommitMeta{breaking}, "2.0.0"},
+		{"1.3.2-rc.1", []CommitMeta{fix}, "1.3.2"},
+		{"1.3.2-rc.1", []CommitMeta{feat}, "1.4.0"},
+		{"2.0.0-beta.2", []CommitMeta{breaking}, "2.0.0"},
+		{"1.3.0-rc.1", []CommitMeta{chore}, "1.3.0-rc.1"},
+	}
+
+	for _, tc := range tests {
+		current := MustParse(tc.current)
+		got := NextVersion(current, tc.commits)
+		if got.Original() != tc.expected {
+			t.Errorf("expected the version after %s with %+v to be %s but got %s", tc.current, tc.commits, tc.expected, got.Original())
+		}
+		if got == current {
+			t.Errorf("expected a new version to be returned for %s", tc.current)
+		}
+	}
+}
+
+func TestIncrementPrerelease(t *testing.T) {
+	tests := []struct {
+		version  string
+		label    string
+		expected string
+		err      bool
+	}{
+		{"1.5.0-rc.1", "rc", "1.5.0-rc.2", false},
+		{"1.5.0-rc.9", "rc", "1.5.0-rc.10", false},
+		{"v1.5.0-rc.1+build.3", "rc", "v1.5.0-rc.2", false},
+		{"1.5.0", "rc", "1.5.0-rc.1", false},
+		{"1.5.0-rc", "rc", "1.5.0-rc.1", false},
+		{"1.5.0-beta.3", "rc", "1.5.0-rc.1", false},
+		{"1.5.0-beta.3", "", "1.5.0-beta.4", false},
+		{"1.5.0-4", "", "1.5.0-5", false},
+		{"1.5.0-1", "rc", "1.5.0-rc.1", false},
+		{"1.5.0-rc.1.2", "rc", "1.5.0-rc.1.3", false},
+		{"1.5.0-rc.final", "rc", "", true},
+		{"1.5.0-rc", "", "", true},
+		{"1.5.0", "", "", true},
+		{"1.5.0-rc.1", "alpha", "", true},
+		{"1.5.0-rc.1", "r c", "", true},
+		{"1.5.0-rc.18446744073709551615", "rc", "", true},
+	}
+
+	for _, tc := range tests {
+		got, err := IncrementPrerelease(MustParse(tc.version), tc.label)
+		if tc.err {
+			if err == nil {
+				t.Errorf("expected an error incrementing %s with %q but got %s", tc.version, tc.label, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error incrementing %s with %q: %s", tc.version, tc.label, err)
+			continue
+		}
+		if got.Original() != tc.expected {
+			t.Errorf("expected %s incremented with %q to be %s but got %s", tc.version, tc.label, tc.expected, got.Original())
+		}
+	}
+
+	_, err := IncrementPrerelease(MustParse("1.5.0-rc.final"), "rc")
+	expected := "1.5.0-rc.final can not be incremented as its prerelease does not end in a number"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected the error %q but got %v", expected, err)
+	}
+}
+
+func TestPromote(t *testing.T) {
+	tests := []struct {
+		version      string
+		keepMetadata bool
+		expected     string
+		err          bool
+	}{
+		{"2.0.0-rc.3+build.9", false, "2.0.0", false},
+		{"2.0.0-rc.3+build.9", true, "2.0.0+build.9", false},
+		{"v2.0.0-rc.3", false, "v2.0.0", false},
+		{"1.2.3-0", true, "1.2.3", false},
+		{"2.0.0", false, "", true},
+		{"2.0.0+build.9", true, "", true},
+	}
+
+	for _, tc := range tests {
+		got, err := Promote(MustParse(tc.version), tc.keepMetadata)
+		if tc.err {
+			if err == nil {
+				t.Errorf("expected an error promoting %s but got %s", tc.version, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error promoting %s: %s", tc.version, err)
+			continue
+		}
+		if got.Original() != tc.expected {
+			t.Errorf("expected %s promoted to be %s but got %s", tc.version, tc.expected, got.Original())
+		}
+	}
+}
+
+func TestNextPrereleaseToward(t *testing.T) {
+	tests := []struct {
+		current   string
+		target    ReleaseTarget
+		label     string
+		published []string
+		expected  string
+		err       bool
+	}{
+		{"1.4.2", TargetMajor, "alpha", nil, "2.0.0-alpha.1", false},
+		{"1.4.2", TargetMajor, "alpha", []string{"2.0.0-alpha.1"}, "2.0.0-alpha.2", false},
+		{"1.4.2", TargetMajor, "alpha", []string{"2.0.0-alpha.2", "2.0.0-alpha.10", "1.5.0-alpha.20"}, "2.0.0-alpha.11", false},
+		{"1.4.2", TargetMinor, "rc", []string{"2.0.0-rc.3", "1.5.0-rc.1+build.2"}, "1.5.0-rc.2", false},
+		{"1.4.2", TargetPatch, "rc", []string{"1.4.2", "1.4.3"}, "1.4.3-rc.1", false},
+		{"v1.4.2", TargetMajor, "beta", []string{"v2.0.0-alpha.4"}, "v2.0.0-beta.1", false},
+		{"1.4.2", TargetMajor, "alpha", []string{"2.0.0-beta.1"}, "", true},
+		{"1.4.2", TargetMajor, "", nil, "", true},
+		{"2.0.0-alpha.1", TargetMajor, "alpha", nil, "", true},
+	}
+
+	for _, tc := range tests {
+		var published []*Version
+		for _, p := range tc.published {
+			published = append(published, MustParse(p))
+		}
+
+		got, err := NextPrereleaseToward(MustParse(tc.current), tc.target, tc.label, published)
+		if tc.err {
+			if err == nil {
+				t.Errorf("expected an error after %s with %v but got %s", tc.current, tc.published, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error after %s with %v: %s", tc.current, tc.published, err)
+			continue
+		}
+		if got.Original() != tc.expected {
+			t.Errorf("expected the prerelease after %s with %v to be %s but got %s", tc.current, tc.published, tc.expected, got.Original())
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package semver
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConstraintCache parses constraints once and returns the same *Constraints
+// each time they are asked for again. Machine-generated constraints are often
+// spaced inconsistently, so constraints are cached on a normalized form with
+// the spacing around commas, || separators, and operators removed. For
+// example, ">=1.2.0, <2.0.0" and ">= 1.2.0,<2.0.0" share one entry. The
+// normalized form is what is parsed, so the constraints returned do not
+// depend on which spelling was seen first.
+//
+// The constraints returned are shared and must not be modified. Errors are
+// cached as well, so invalid constraints are not parsed again, apart from
+// constraints over the Limits, which are never cached. It is safe for
+// concurrent use.
+type ConstraintCache struct {
+	opts MatchOptions
+	size int
+
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	c   *Constraints
+	err error
+}
+
+// NewConstraintCache creates a cache parsing constraints with the options. It
+// holds up to size entries, and is emptied when it is full and a constraint
+// not in it is parsed. A size of 0 or less does not limit the cache.
+func NewConstraintCache(opts MatchOptions, size int) *ConstraintCache {
+	return &ConstraintCache{
+		opts:    opts,
+		size:    size,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+// Get returns the parsed constraints, parsing them when they are not cached.
+func (cc *ConstraintCache) Get(c string) (*Constraints, error) {
+	h := currentHooks()
+	if h == nil {
+		cs, _, err := cc.get(c)
+		return cs, err
+	}
+	start := time.Now()
+	cs, hit, err := cc.get(c)
+	parseHook(h, ParseConstraint, c, start, hit, err)
+	return cs, err
+}
+
+// get returns the parsed constraints along with whether they were cached.
+func (cc *ConstraintCache) get(c string) (*Constraints, bool, error) {
+	// Inputs over the limits are not cached so they cannot fill the cache.
+	if err := checkConstraintLimits(c); err != nil {
+		return nil, false, err
+	}
+	key := constraintKey(c)
+
+	cc.mu.RLock()
+	e, ok := cc.entries[key]
+	cc.mu.RUnlock()
+	if ok {
+		return e.c, true, e.err
+	}
+
+	e.c, e.err = newConstraintWithOptions(key, cc.opts)
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if prev, ok := cc.entries[key]; ok {
+		// Another goroutine parsed the same constraints first.
+		return prev.c, true, prev.err
+	}
+	if cc.size > 0 && len(cc.entries) >= cc.size {
+		cc.entries = map[string]cacheEntry{}
+	}
+	cc.entries[key] = e
+	return e.c, false, e.err
+}
+
+// Len returns the number of cached entries.
+func (cc *ConstraintCache) Len() int {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return len(cc.entries)
+}
+
+// constraintKey normalizes the spacing of constraints. Leading and trailing
+// whitespace and whitespace next to a comma, a |, or between an operator and
+// a version is removed, and other runs of whitespace, such as those around
+// the hyphen of 1.2 - 1.4, become a single space. Whitespace within an
+// operator, as in "> =1.2", is kept so invalid constraints stay invalid.
+func constraintKey(c string) string {
+	var b strings.Builder
+	b.Grow(len(c))
+
+	// last is the last byte written and space is set after whitespace.
+	var last byte
+	space := false
+	for i := 0; i < len(c); i++ {
+		ch := c[i]
+		if isSpace(ch) {
+			space = true
+			continue
+		}
+		if space && last != 0 && keepSpace(last, ch) {
+			b.WriteByte(' ')
+		}
+		space, last = false, ch
+		b.WriteByte(ch)
+	}
+	return b.String()
+}
+
+// keepSpace reports if whitespace between two bytes is kept. It is removed
+// next to a comma or a | and after an operator, unless the bytes on both
+// sides are of the same kind.
+func keepSpace(before, after byte) bool {
+	switch {
+	case isKeySeparator(before) || isKeySeparator(after):
+		return isKeySeparator(before) && isKeySeparator(after)
+	case isKeyOperator(before):
+		return isKeyOperator(after)
+	}
+	return true
+}
+
+func isKeySeparator(c byte) bool {
+	return c == ',' || c == '|'
+}
+
+func isKeyOperator(c byte) bool {
+	switch c {
+	case '=', '<', '>', '~', '^', '!':
+		return true
+	}
+	return false
+}
+
+// defaultConstraintCache is the cache used by the package level functions
+// that cache constraints, such as ExpandTemplate.
+var defaultConstraintCache = NewConstraintCache(MatchOptions{}, 4096)
@@ -0,0 +1,113 @@
+package semver
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConstraintKey(t *testing.T) {
+	tests := []struct {
+		c, key string
+	}{
+		{">=1.2.0, <2.0.0", ">=1.2.0,<2.0.0"},
+		{">=1.2.0,<2.0.0", ">=1.2.0,<2.0.0"},
+		{"  >= 1.2.0 ,  < 2.0.0  ", ">=1.2.0,<2.0.0"},
+		{">=1.2 <2", ">=1.2 <2"},
+		{">=1.2\t\t<2", ">=1.2 <2"},
+		{"1.2 || 2.x", "1.2||2.x"},
+		{"1.2   -   1.4", "1.2 - 1.4"},
+		{"1.2.3-beta.1 - 2", "1.2.3-beta.1 - 2"},
+		{"~> 1.2", "~>1.2"},
+		{"! = 1.2", "! =1.2"},
+		{"1.2 ,, 1.3", "1.2,,1.3"},
+		{"1.2 | | 2.x", "1.2| |2.x"},
+		{"^ 1.2, != 1.3.0", "^1.2,!=1.3.0"},
+		{"", ""},
+		{"   ", ""},
+	}
+
+	for _, tc := range tests {
+		if key := constraintKey(tc.c); key != tc.key {
+			t.Errorf("Expected key %q for %q but got %q", tc.key, tc.c, key)
+		}
+	}
+}
+
+func TestConstraintCache(t *testing.T) {
+	cc := NewConstraintCache(MatchOptions{}, 0)
+
+	c1, err := cc.Get(">=1.2.0, <2.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := cc.Get(" >= 1.2.0,<2.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 != c2 {
+		t.Error("Expected differently spaced constraints to share an entry")
+	}
+	if cc.Len() != 1 {
+		t.Errorf("Expected 1 entry but got %d", cc.Len())
+	}
+
+	c3, err := cc.Get("1.2 - 1.4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c3.Check(MustParse("1.5.0")) || !c3.Check(MustParse("1.3.0")) {
+		t.Errorf("Expected the hyphen range to be kept but got %s", c3)
+	}
+
+	_, err1 := cc.Get("foo")
+	_, err2 := cc.Get(" foo ")
+	if err1 == nil || err1 != err2 {
+		t.Errorf("Expected the error to be cached but got %v and %v", err1, err2)
+	}
+	if cc.Len() != 3 {
+		t.Errorf("Expected 3 entries but got %d", cc.Len())
+	}
+}
+
+func TestConstraintCacheOptions(t *testing.T) {
+	cc := NewConstraintCache(NpmOptions(), 0)
+	c, err := cc.Get("~1.2.3-beta.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Options() != NpmOptions() {
+		t.Error("Expected the constraints to be parsed with the options of the cache")
+	}
+}
+
+func TestConstraintCacheSize(t *testing.T) {
+	cc := NewConstraintCache(MatchOptions{}, 2)
+	for _, c := range []string{"^1", "^2", "^2", "^3"} {
+		if _, err := cc.Get(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cc.Len() != 1 {
+		t.Errorf("Expected the full cache to be emptied leaving 1 entry but got %d", cc.Len())
+	}
+}
+
+func TestConstraintCacheConcurrent(t *testing.T) {
+	cc := NewConstraintCache(MatchOptions{}, 0)
+	results := make([]*Constraints, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = cc.Get(">=1.2.0, <2.0.0")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, c := range results {
+		if c != results[0] {
+			t.Error("Expected every goroutine to get the same constraints")
+		}
+	}
+}
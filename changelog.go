@@ -0,0 +1,46 @@
+package semver
+
+import "sort"
+
+// ChangelogGroup is a section of a changelog holding the releases of a minor
+// series, such as "1.4".
+type ChangelogGroup struct {
+	Series  string
+	Entries []ChangelogEntry
+}
+
+// ChangelogEntry is an entry of a changelog section holding a release and
+// the prereleases leading to it. Core is the release, such as 1.4.2, which
+// may not be among the versions when only its prereleases are published.
+type ChangelogEntry struct {
+	Core     *Version
+	Versions []*Version
+}
+
+// ChangelogGroups groups versions into changelog sections. The versions are
+// split by minor series, and each prerelease is grouped with the release it
+// precedes, so 1.5.0-rc.1 and 1.5.0 share an entry in the 1.5 section. The
+// sections, their entries, and the versions of each entry are ordered newest
+// first, as changelogs are read. The versions do not need to be sorted.
+func ChangelogGroups(vs []*Version) []ChangelogGroup {
+	sorted := make([]*Version, len(vs))
+	copy(sorted, vs)
+	sort.Stable(sort.Reverse(Collection(sorted)))
+
+	var groups []ChangelogGroup
+	for _, v := range sorted {
+		series := Series(v)
+		if len(groups) == 0 || groups[len(groups)-1].Series != series {
+			groups = append(groups, ChangelogGroup{Series: series})
+		}
+		g := &groups[len(groups)-1]
+
+		n := len(g.Entries)
+		if n == 0 || g.Entries[n-1].Core.patch != v.patch {
+			g.Entries = append(g.Entries, ChangelogEntry{Core: newCoreVersion(v.major, v.minor, v.patch)})
+			n++
+		}
+		g.Entries[n-1].Versions = append(g.Entries[n-1].Versions, v)
+	}
+	return groups
+}
@@ -0,0 +1,36 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestChangelogGroups(t *testing.T) {
+	var vs []*Version
+	for _, v := range []string{"1.4.0", "1.5.0-rc.1", "1.4.1", "2.0.0-beta.1", "1.5.0", "1.4.1-rc.1", "1.5.0-rc.2", "1.4.0+build.2"} {
+		vs = append(vs, MustParse(v))
+	}
+
+	var got []string
+	for _, g := range ChangelogGroups(vs) {
+		for _, e := range g.Entries {
+			got = append(got, fmt.Sprintf("%s %s %s", g.Series, e.Core, e.Versions))
+		}
+	}
+	expected := []string{
+		"2.0 2.0.0 [2.0.0-beta.1]",
+		"1.5 1.5.0 [1.5.0 1.5.0-rc.2 1.5.0-rc.1]",
+		"1.4 1.4.1 [1.4.1 1.4.1-rc.1]",
+		"1.4 1.4.0 [1.4.0 1.4.0+build.2]",
+	}
+	if !equalStrings(got, expected) {
+		t.Errorf("expected the changelog groups %q but got %q", expected, got)
+	}
+
+	if vs[0].String() != "1.4.0" {
+		t.Errorf("expected the versions not to be reordered but the first is %s", vs[0])
+	}
+	if g := ChangelogGroups(nil); len(g) != 0 {
+		t.Errorf("expected no changelog groups without versions but got %v", g)
+	}
+}
@@ -0,0 +1,82 @@
+package semver
+
+// Closest returns the candidate nearest to the target, for suggesting a
+// version that exists when the one asked for does not, or nil when there are
+// no candidates.
+//
+// The distance between two versions is the difference between their major
+// versions, then their minor versions, then their patch versions, and last
+// their prereleases, each only deciding between candidates tied on those
+// before it. So for 1.4.2, 1.4.9 is closer than 1.5.2 and 1.9.0 is closer than
+// 2.4.2. The prerelease distance is 0 when the prereleases are the same, 1
+// when both have a different prerelease, and 2 when only one has a
+// prerelease, so for 1.4.2-rc.3 the order is 1.4.2-rc.1 and then 1.4.2.
+// Metadata is ignored. Candidates at the same distance, such as 1.4.1 and
+// 1.4.3 for 1.4.2, are decided in favor of the greater version and then the
+// earlier candidate. Nil candidates are skipped, and with a nil target the
+// greatest candidate is returned.
+func Closest(target *Version, candidates []*Version) *Version {
+	var best *Version
+	var bestDist distance
+	for _, v := range candidates {
+		if v == nil {
+			continue
+		}
+		if target == nil {
+			if best == nil || v.Compare(best) > 0 {
+				best = v
+			}
+			continue
+		}
+		d := versionDistance(target, v)
+		if best == nil || d.less(bestDist) || (d == bestDist && v.Compare(best) > 0) {
+			best, bestDist = v, d
+		}
+	}
+	return best
+}
+
+// distance is the distance between two versions as described by Closest.
+type distance struct {
+	major, minor, patch uint64
+	pre                 int
+}
+
+// versionDistance returns the distance between a and b.
+func versionDistance(a, b *Version) distance {
+	d := distance{
+		major: absDiff(a.major, b.major),
+		minor: absDiff(a.minor, b.minor),
+		patch: absDiff(a.patch, b.patch),
+	}
+	ap, bp := a.Prerelease(), b.Prerelease()
+	switch {
+	case ap == bp:
+	case ap != "" && bp != "":
+		d.pre = 1
+	default:
+		d.pre = 2
+	}
+	return d
+}
+
+// less reports if d is a shorter distance than o.
+func (d distance) less(o distance) bool {
+	if d.major != o.major {
+		return d.major < o.major
+	}
+	if d.minor != o.minor {
+		return d.minor < o.minor
+	}
+	if d.patch != o.patch {
+		return d.patch < o.patch
+	}
+	return d.pre < o.pre
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
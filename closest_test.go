@@ -0,0 +1,51 @@
+package semver
+
+import "testing"
+
+func TestClosest(t *testing.T) {
+	tests := []struct {
+		target     string
+		candidates []string
+		expected   string
+	}{
+		{"1.4.2", []string{"1.4.1", "1.4.9", "1.5.2"}, "1.4.1"},
+		{"1.4.2", []string{"1.5.2", "1.4.9"}, "1.4.9"},
+		{"1.4.2", []string{"2.4.2", "1.9.0"}, "1.9.0"},
+		{"1.4.2", []string{"0.4.2", "2.4.2"}, "2.4.2"},
+		{"1.4.2", []string{"1.4.1", "1.4.3"}, "1.4.3"},
+		{"1.4.2", []string{"1.4.2-rc.1", "1.4.2+build", "1.4.3"}, "1.4.2+build"},
+		{"1.4.2-rc.3", []string{"1.4.2", "1.4.2-rc.1"}, "1.4.2-rc.1"},
+		{"1.4.2-rc.3", []string{"1.4.2", "1.4.2-rc.3"}, "1.4.2-rc.3"},
+		{"1.4.2", []string{"1.4.2-rc.1", "1.4.2-beta"}, "1.4.2-rc.1"},
+		{"0.0.0", []string{"18446744073709551615.0.0", "3.0.0"}, "3.0.0"},
+	}
+
+	for _, tc := range tests {
+		candidates := make([]*Version, len(tc.candidates))
+		for i, s := range tc.candidates {
+			candidates[i] = MustParse(s)
+		}
+		got := Closest(MustParse(tc.target), candidates)
+		if got == nil || got.Original() != tc.expected {
+			t.Errorf("expected %s to be closest to %s but got %v", tc.expected, tc.target, got)
+		}
+	}
+}
+
+func TestClosestEdges(t *testing.T) {
+	if Closest(MustParse("1.0.0"), nil) != nil {
+		t.Error("expected nil without candidates")
+	}
+	if Closest(MustParse("1.0.0"), []*Version{nil}) != nil {
+		t.Error("expected nil candidates to be skipped")
+	}
+
+	first, second := MustParse("1.2.0"), MustParse("v1.2.0")
+	if got := Closest(MustParse("1.2.1"), Collection{nil, first, second}); got != first {
+		t.Errorf("expected the earlier of equal candidates but got %s", got.Original())
+	}
+
+	if got := Closest(nil, []*Version{MustParse("1.0.0"), MustParse("3.0.0"), MustParse("2.0.0")}); got.String() != "3.0.0" {
+		t.Errorf("expected the greatest candidate for a nil target but got %s", got)
+	}
+}
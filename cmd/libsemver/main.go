@@ -0,0 +1,92 @@
+// Command libsemver exports the semver package through a C ABI so tools in
+// other languages, such as Python and Ruby through their FFI libraries, can
+// link against this implementation. Build it as a shared library with:
+//
+//	go build -buildmode=c-shared -o libsemver.so ./cmd/libsemver
+//
+// Strings returned through the char** arguments are allocated with malloc and
+// must be released with SemverFree.
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import "unsafe"
+
+// SemverParse parses a version. On success it returns 0 and sets result to
+// the canonical form of the version. Otherwise it returns -1 and sets result
+// to the error message.
+//
+//export SemverParse
+func SemverParse(version *C.char, result **C.char) C.int {
+	r, s := parse(C.GoString(version))
+	setString(result, s)
+	return C.int(r)
+}
+
+// SemverCompare returns -1, 0, or 1 as a is less than, equal to, or greater
+// than b. When a version is invalid it returns -2 and sets err to the error
+// message.
+//
+//export SemverCompare
+func SemverCompare(a, b *C.char, err **C.char) C.int {
+	r, s := compare(C.GoString(a), C.GoString(b))
+	setString(err, s)
+	return C.int(r)
+}
+
+// SemverSatisfies returns 1 when version satisfies constraint and 0 when it
+// does not. When either is invalid it returns -1 and sets err to the error
+// message.
+//
+//export SemverSatisfies
+func SemverSatisfies(version, constraint *C.char, err **C.char) C.int {
+	r, s := satisfies(C.GoString(version), C.GoString(constraint))
+	setString(err, s)
+	return C.int(r)
+}
+
+// SemverMaxSatisfying selects the highest of the n versions that satisfies
+// constraint. It returns 1 and sets result to the version as it was written
+// when one is found, or 0 when none are. When n is negative, or a version or
+// the constraint is invalid, it returns -1 and sets result to the error
+// message.
+//
+//export SemverMaxSatisfying
+func SemverMaxSatisfying(versions **C.char, n C.int, constraint *C.char, result **C.char) C.int {
+	if e := checkCount(int(n)); e != "" {
+		setString(result, e)
+		return -1
+	}
+	vs := make([]string, int(n))
+	if n > 0 {
+		ptrs := (*[maxVersions]*C.char)(unsafe.Pointer(versions))[:n:n]
+		for k, p := range ptrs {
+			vs[k] = C.GoString(p)
+		}
+	}
+
+	r, s := maxSatisfying(vs, C.GoString(constraint))
+	setString(result, s)
+	return C.int(r)
+}
+
+// setString sets p to a copy of s allocated with malloc, leaving it as is
+// when s is "".
+func setString(p **C.char, s string) {
+	if s != "" {
+		*p = C.CString(s)
+	}
+}
+
+// SemverFree releases a string returned by one of the other functions.
+//
+//export SemverFree
+func SemverFree(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+// main is required for building a c-shared library but is never called.
+func main() {}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jesseduffield/semver/v3/semverflat"
+)
+
+// The functions here hold the logic of the exported functions without cgo so
+// it can be tested. Each returns the code the exported function returns and
+// the string it sets, or "" when it sets none.
+
+// maxVersions is the most versions SemverMaxSatisfying reads, the length of
+// the array the C array of versions is viewed as.
+const maxVersions = 1 << 28
+
+func parse(version string) (int, string) {
+	v, err := semverflat.Normalize(version)
+	if err != "" {
+		return -1, err
+	}
+	return 0, v
+}
+
+func compare(a, b string) (int, string) {
+	r, err := semverflat.Compare(a, b)
+	if err != "" {
+		return -2, err
+	}
+	return r, ""
+}
+
+func satisfies(version, constraint string) (int, string) {
+	ok, err := semverflat.Satisfies(version, constraint)
+	switch {
+	case err != "":
+		return -1, err
+	case ok:
+		return 1, ""
+	}
+	return 0, ""
+}
+
+// checkCount returns the error message for a count of versions that cannot
+// be read, or "" when n can be.
+func checkCount(n int) string {
+	if n < 0 || n > maxVersions {
+		return fmt.Sprintf("the count of versions %d is not between 0 and %d", n, maxVersions)
+	}
+	return ""
+}
+
+func maxSatisfying(versions []string, constraint string) (int, string) {
+	v, err := semverflat.MaxSatisfying(versions, constraint)
+	switch {
+	case err != "":
+		return -1, err
+	case v == "":
+		return 0, ""
+	}
+	return 1, v
+}
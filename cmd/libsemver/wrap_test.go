@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestWrappers(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func() (int, string)
+		code     int
+		expected string
+	}{
+		{"parse", func() (int, string) { return parse("v1.2") }, 0, "1.2.0"},
+		{"parse invalid", func() (int, string) { return parse("banana") }, -1, "Invalid Semantic Version"},
+		{"compare", func() (int, string) { return compare("1.2.3", "1.10.0") }, -1, ""},
+		{"compare equal", func() (int, string) { return compare("1.2.3", "v1.2.3") }, 0, ""},
+		{"compare invalid", func() (int, string) { return compare("1.2.3", "banana") }, -2, "Invalid Semantic Version"},
+		{"satisfies", func() (int, string) { return satisfies("1.4.0", "^1.2") }, 1, ""},
+		{"satisfies not", func() (int, string) { return satisfies("2.0.0", "^1.2") }, 0, ""},
+		{"satisfies invalid", func() (int, string) { return satisfies("1.4.0", "^banana") }, -1, "improper constraint: ^banana"},
+		{"max", func() (int, string) { return maxSatisfying([]string{"1.2.0", "v1.4.0", "2.0.0"}, "^1.2") }, 1, "v1.4.0"},
+		{"max none", func() (int, string) { return maxSatisfying([]string{"2.0.0"}, "^1.2") }, 0, ""},
+		{"max empty", func() (int, string) { return maxSatisfying(nil, "^1.2") }, 0, ""},
+		{"max invalid", func() (int, string) { return maxSatisfying([]string{"banana"}, "^1.2") }, -1, "Invalid Semantic Version"},
+	}
+
+	for _, tc := range tests {
+		code, s := tc.call()
+		if code != tc.code || s != tc.expected {
+			t.Errorf("%s: expected %d %q but got %d %q", tc.name, tc.code, tc.expected, code, s)
+		}
+	}
+}
+
+func TestCheckCount(t *testing.T) {
+	for _, n := range []int{0, 1, maxVersions} {
+		if e := checkCount(n); e != "" {
+			t.Errorf("unexpected error for %d: %s", n, e)
+		}
+	}
+	for _, n := range []int{-1, -1 << 31, maxVersions + 1} {
+		if e := checkCount(n); e == "" {
+			t.Errorf("expected an error for %d", n)
+		}
+	}
+}
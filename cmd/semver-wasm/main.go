@@ -0,0 +1,17 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Command semver-wasm exposes the semverflat functions to JavaScript as a
+// global named semver. Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o semver.wasm ./cmd/semver-wasm
+package main
+
+import "github.com/jesseduffield/semver/v3/semverflat"
+
+func main() {
+	semverflat.Register("semver")
+
+	// Keep the functions available for as long as the page is loaded.
+	select {}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+const bumpUsage = "usage: semver bump [-preid id] [-json] major|minor|patch|pre <file-or-version>"
+
+// bumpResult is the JSON form of the bump command.
+type bumpResult struct {
+	Previous *versionJSON `json:"previous"`
+	Version  *versionJSON `json:"version"`
+	File     string       `json:"file,omitempty"`
+}
+
+func runBump(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("bump", stderr)
+	preid := fs.String("preid", "", "identifier used for prerelease bumps, such as rc")
+	asJSON := jsonFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 2 {
+		fmt.Fprintln(stderr, bumpUsage)
+		return exitUsage
+	}
+	part, target := fs.Arg(0), fs.Arg(1)
+
+	// The target is a file when one exists at the path. Otherwise it is a
+	// version literal and the result is only printed.
+	input, file := target, false
+	if fi, err := os.Stat(target); err == nil && fi.Mode().IsRegular() {
+		b, err := ioutil.ReadFile(target)
+		if err != nil {
+			writeError(stderr, *asJSON, "", err)
+			return exitFailure
+		}
+		input, file = strings.TrimSpace(string(b)), true
+	}
+
+	v, err := semver.NewVersion(input)
+	if err != nil {
+		writeError(stderr, *asJSON, input, err)
+		return exitFailure
+	}
+
+	next, err := bump(*v, part, *preid)
+	if err != nil {
+		writeError(stderr, *asJSON, "", err)
+		if err == errUnknownPart {
+			fmt.Fprintln(stderr, bumpUsage)
+			return exitUsage
+		}
+		return exitFailure
+	}
+
+	if file {
+		fi, err := os.Stat(target)
+		if err != nil {
+			writeError(stderr, *asJSON, "", err)
+			return exitFailure
+		}
+		if err := ioutil.WriteFile(target, []byte(next.Original()+"\n"), fi.Mode().Perm()); err != nil {
+			writeError(stderr, *asJSON, "", err)
+			return exitFailure
+		}
+	}
+
+	if *asJSON {
+		r := bumpResult{Previous: newVersionJSON(v), Version: newVersionJSON(&next)}
+		if file {
+			r.File = target
+		}
+		return writeJSON(stdout, stderr, r, exitOK)
+	}
+	fmt.Fprintln(stdout, next.Original())
+	return exitOK
+}
+
+var errUnknownPart = fmt.Errorf("the part to bump must be one of major, minor, patch, or pre")
+
+// bump returns the version after incrementing part. Prerelease bumps use
+// the same sequence as the library, so a release starts the prereleases of
+// the next patch, 1.2.3 -> 1.2.4-rc.1 -> 1.2.4-rc.2 with a preid of rc, and a
+// prerelease moves to the next in its sequence.
+func bump(v semver.Version, part, preid string) (semver.Version, error) {
+	switch part {
+	case "major":
+		return v.IncMajor(), nil
+	case "minor":
+		return v.IncMinor(), nil
+	case "patch":
+		return v.IncPatch(), nil
+	case "pre":
+		next, err := bumpPrerelease(&v, preid)
+		if err != nil {
+			return v, err
+		}
+		return *next, nil
+	default:
+		return v, errUnknownPart
+	}
+}
+
+func bumpPrerelease(v *semver.Version, preid string) (*semver.Version, error) {
+	if v.Prerelease() == "" {
+		return semver.NextPrereleaseToward(v, semver.TargetPatch, preid, nil)
+	}
+	return semver.IncrementPrerelease(v, preid)
+}
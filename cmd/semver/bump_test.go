@@ -0,0 +1,107 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		version  string
+		part     string
+		preid    string
+		expected string
+	}{
+		{"1.2.3", "major", "", "2.0.0"},
+		{"1.2.3", "minor", "", "1.3.0"},
+		{"1.2.3", "patch", "", "1.2.4"},
+		{"1.2.3-beta.1", "patch", "", "1.2.3"},
+		{"v1.2.3", "minor", "", "v1.3.0"},
+		{"1.2.3+build.4", "patch", "", "1.2.4"},
+		{"1.2.3", "pre", "rc", "1.2.4-rc.1"},
+		{"v1.2.3", "pre", "rc", "v1.2.4-rc.1"},
+		{"1.2.4-rc.1", "pre", "rc", "1.2.4-rc.2"},
+		{"1.2.4-rc.9", "pre", "", "1.2.4-rc.10"},
+		{"1.2.4-alpha.3", "pre", "beta", "1.2.4-beta.1"},
+		{"1.2.4-beta", "pre", "beta", "1.2.4-beta.1"},
+		{"1.2.4-0", "pre", "", "1.2.4-1"},
+	}
+
+	for _, tc := range tests {
+		v := semver.MustParse(tc.version)
+		next, err := bump(*v, tc.part, tc.preid)
+		if err != nil {
+			t.Errorf("unexpected error bumping %s %s: %s", tc.version, tc.part, err)
+			continue
+		}
+		if a := next.Original(); a != tc.expected {
+			t.Errorf("expected %s bumped %s (preid %q) to be %s but got %s", tc.version, tc.part, tc.preid, tc.expected, a)
+		}
+	}
+
+	for _, tc := range []struct{ version, preid string }{
+		{"1.2.3-rc.18446744073709551615", ""},
+		{"1.2.3", ""},
+		{"1.2.4-beta", ""},
+		{"1.2.4-rcx.1", "rc"},
+	} {
+		if next, err := bump(*semver.MustParse(tc.version), "pre", tc.preid); err == nil {
+			t.Errorf("expected an error bumping the prerelease of %s (preid %q) but got %s", tc.version, tc.preid, next.Original())
+		}
+	}
+
+	// The CLI and the library agree on the next prerelease.
+	v := semver.MustParse("2.0.0")
+	next, err := bump(*v, "pre", "rc")
+	want, _ := semver.NextPrereleaseToward(v, semver.TargetPatch, "rc", nil)
+	if err != nil || !next.Equal(want) {
+		t.Errorf("expected %s but got %s %v", want, next.Original(), err)
+	}
+}
+
+func TestRunBumpLiteral(t *testing.T) {
+	code, stdout, _ := runCmd("", "bump", "-preid", "rc", "pre", "2.0.0")
+	if code != exitOK || stdout != "2.0.1-rc.1\n" {
+		t.Errorf("unexpected bump output %d: %q", code, stdout)
+	}
+
+	if code, _, _ := runCmd("", "bump", "micro", "2.0.0"); code != exitUsage {
+		t.Errorf("expected usage error for an unknown part but got %d", code)
+	}
+	if code, _, _ := runCmd("", "bump", "patch", "nope"); code != exitFailure {
+		t.Errorf("expected failure for an invalid version but got %d", code)
+	}
+	if code, _, _ := runCmd("", "bump", "patch"); code != exitUsage {
+		t.Errorf("expected usage error but got %d", code)
+	}
+}
+
+func TestRunBumpFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "semver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "VERSION")
+	if err := ioutil.WriteFile(path, []byte("v1.4.2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCmd("", "bump", "minor", path)
+	if code != exitOK || stdout != "v1.5.0\n" {
+		t.Fatalf("unexpected bump output %d: %q %q", code, stdout, stderr)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "v1.5.0\n" {
+		t.Errorf("expected the file to contain the bumped version but got %q", b)
+	}
+}
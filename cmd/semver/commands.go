@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// newFlagSet creates a flag set for a command that reports errors to stderr.
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("semver "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// readLines returns the non-empty lines read from r with surrounding
+// whitespace removed.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		l := strings.TrimSpace(s.Text())
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines, s.Err()
+}
+
+// parseVersion parses a version using the strict parser when strict is true.
+func parseVersion(v string, strict bool) (*semver.Version, error) {
+	if strict {
+		return semver.StrictNewVersion(v)
+	}
+	return semver.NewVersion(v)
+}
+
+// validateResult is the JSON form of a single validated version.
+type validateResult struct {
+	Input   string       `json:"input"`
+	Valid   bool         `json:"valid"`
+	Error   string       `json:"error,omitempty"`
+	Version *versionJSON `json:"version,omitempty"`
+}
+
+func runValidate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("validate", stderr)
+	strict := fs.Bool("strict", false, "only accept strict semantic versions")
+	asJSON := jsonFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(stderr, "usage: semver validate [-strict] [-json] <version>...")
+		return exitUsage
+	}
+
+	code := exitOK
+	results := make([]validateResult, 0, fs.NArg())
+	for _, a := range fs.Args() {
+		v, err := parseVersion(a, *strict)
+		if err != nil {
+			if !*asJSON {
+				fmt.Fprintf(stderr, "%s: %s\n", a, err)
+			}
+			results = append(results, validateResult{Input: a, Error: err.Error()})
+			code = exitFailure
+			continue
+		}
+		results = append(results, validateResult{Input: a, Valid: true, Version: newVersionJSON(v)})
+	}
+
+	if *asJSON {
+		return writeJSON(stdout, stderr, results, code)
+	}
+	return code
+}
+
+// compareResult is the JSON form of the compare command.
+type compareResult struct {
+	A      *versionJSON `json:"a"`
+	B      *versionJSON `json:"b"`
+	Result int          `json:"result"`
+}
+
+func runCompare(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("compare", stderr)
+	asJSON := jsonFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 2 {
+		fmt.Fprintln(stderr, "usage: semver compare [-json] <version> <version>")
+		return exitUsage
+	}
+
+	a, err := semver.NewVersion(fs.Arg(0))
+	if err != nil {
+		writeError(stderr, *asJSON, fs.Arg(0), err)
+		return exitFailure
+	}
+	b, err := semver.NewVersion(fs.Arg(1))
+	if err != nil {
+		writeError(stderr, *asJSON, fs.Arg(1), err)
+		return exitFailure
+	}
+
+	r := a.Compare(b)
+	if *asJSON {
+		return writeJSON(stdout, stderr, compareResult{A: newVersionJSON(a), B: newVersionJSON(b), Result: r}, exitOK)
+	}
+	fmt.Fprintln(stdout, r)
+	return exitOK
+}
+
+// readVersions parses the versions read from stdin. Lines that are not
+// versions are reported to stderr and cause the returned code to be a failure.
+func readVersions(stdin io.Reader, stderr io.Writer, asJSON bool) ([]*semver.Version, int) {
+	lines, err := readLines(stdin)
+	if err != nil {
+		writeError(stderr, asJSON, "", fmt.Errorf("reading input: %s", err))
+		return nil, exitFailure
+	}
+
+	code := exitOK
+	vs := make([]*semver.Version, 0, len(lines))
+	for _, l := range lines {
+		v, err := semver.NewVersion(l)
+		if err != nil {
+			writeError(stderr, asJSON, l, err)
+			code = exitFailure
+			continue
+		}
+		vs = append(vs, v)
+	}
+	return vs, code
+}
+
+func runSort(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("sort", stderr)
+	reverse := fs.Bool("r", false, "sort in descending order")
+	asJSON := jsonFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprintln(stderr, "usage: semver sort [-r] [-json] < versions")
+		return exitUsage
+	}
+
+	vs, code := readVersions(stdin, stderr, *asJSON)
+	if *reverse {
+		sort.Stable(sort.Reverse(semver.Collection(vs)))
+	} else {
+		sort.Stable(semver.Collection(vs))
+	}
+
+	if *asJSON {
+		out := make([]*versionJSON, len(vs))
+		for k, v := range vs {
+			out[k] = newVersionJSON(v)
+		}
+		return writeJSON(stdout, stderr, out, code)
+	}
+	for _, v := range vs {
+		fmt.Fprintln(stdout, v.Original())
+	}
+	return code
+}
+
+// filterResult is the JSON form of a version checked by the filter command.
+type filterResult struct {
+	versionJSON
+	Matched bool `json:"matched"`
+}
+
+func runFilter(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("filter", stderr)
+	asJSON := jsonFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: semver filter [-json] <constraint> < versions")
+		return exitUsage
+	}
+
+	c, err := semver.NewConstraint(fs.Arg(0))
+	if err != nil {
+		writeError(stderr, *asJSON, fs.Arg(0), err)
+		return exitFailure
+	}
+
+	vs, code := readVersions(stdin, stderr, *asJSON)
+
+	// The JSON output includes the verdict for every version so tools can
+	// see what was filtered out as well.
+	if *asJSON {
+		out := make([]filterResult, len(vs))
+		for k, v := range vs {
+			out[k] = filterResult{versionJSON: *newVersionJSON(v), Matched: c.Check(v)}
+		}
+		return writeJSON(stdout, stderr, out, code)
+	}
+	for _, v := range vs {
+		if c.Check(v) {
+			fmt.Fprintln(stdout, v.Original())
+		}
+	}
+	return code
+}
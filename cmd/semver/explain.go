@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func runExplain(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("explain", stderr)
+	asJSON := jsonFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	args = fs.Args()
+	if len(args) != 1 && len(args) != 2 {
+		fmt.Fprintln(stderr, "usage: semver explain [-json] <constraint> [version]")
+		return exitUsage
+	}
+
+	c, err := semver.NewConstraint(args[0])
+	if err != nil {
+		writeError(stderr, *asJSON, args[0], err)
+		return exitFailure
+	}
+
+	var v *semver.Version
+	if len(args) == 2 {
+		if v, err = semver.NewVersion(args[1]); err != nil {
+			writeError(stderr, *asJSON, args[1], err)
+			return exitFailure
+		}
+	}
+
+	e := semver.Explain(c, v)
+	if *asJSON {
+		return writeJSON(stdout, stderr, e, exitOK)
+	}
+	fmt.Fprint(stdout, e)
+	return exitOK
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	code, stdout, _ := runCmd("", "explain", ">=1.2, !=1.4.2")
+	if code != exitOK || !strings.Contains(stdout, "releases: >=1.2.0\n") || !strings.Contains(stdout, "excluding: 1.4.2\n") {
+		t.Errorf("unexpected explain output %d:\n%s", code, stdout)
+	}
+
+	code, stdout, _ = runCmd("", "explain", ">=1.2, !=1.4.2", "1.4.2")
+	if code != exitOK || !strings.Contains(stdout, "!=1.4.2: op=!= version=1.4.2 (1.4.2 is equal to 1.4.2)") ||
+		!strings.Contains(stdout, "1.4.2 does not satisfy the constraint") {
+		t.Errorf("unexpected explain output with a version %d:\n%s", code, stdout)
+	}
+
+	if code, _, _ := runCmd("", "explain", "nope"); code != exitFailure {
+		t.Errorf("expected failure for an invalid constraint but got %d", code)
+	}
+	if code, _, _ := runCmd("", "explain", "^1", "nope"); code != exitFailure {
+		t.Errorf("expected failure for an invalid version but got %d", code)
+	}
+	if code, _, _ := runCmd("", "explain"); code != exitUsage {
+		t.Errorf("expected usage error but got %d", code)
+	}
+}
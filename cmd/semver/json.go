@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// versionJSON is the JSON form of a parsed version.
+type versionJSON struct {
+	Original   string `json:"original"`
+	Version    string `json:"version"`
+	Major      uint64 `json:"major"`
+	Minor      uint64 `json:"minor"`
+	Patch      uint64 `json:"patch"`
+	Prerelease string `json:"prerelease,omitempty"`
+	Metadata   string `json:"metadata,omitempty"`
+}
+
+func newVersionJSON(v *semver.Version) *versionJSON {
+	return &versionJSON{
+		Original:   v.Original(),
+		Version:    v.String(),
+		Major:      v.Major(),
+		Minor:      v.Minor(),
+		Patch:      v.Patch(),
+		Prerelease: v.Prerelease(),
+		Metadata:   v.Metadata(),
+	}
+}
+
+// jsonFlag adds the -json flag shared by every command.
+func jsonFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("json", false, "write the result as JSON")
+}
+
+// writeJSON writes v as indented JSON and returns code, or a failure when
+// encoding fails.
+func writeJSON(stdout, stderr io.Writer, v interface{}, code int) int {
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintf(stderr, "semver: %s\n", err)
+		return exitFailure
+	}
+	return code
+}
+
+// errorJSON is the JSON form of an error, written to stderr with -json.
+type errorJSON struct {
+	Input string `json:"input,omitempty"`
+	Error string `json:"error"`
+}
+
+// writeError reports the error for input to stderr, as a line of JSON when
+// asJSON is set so tools reading the JSON output can parse failures too. An
+// empty input is for errors not tied to one, such as reading stdin.
+func writeError(stderr io.Writer, asJSON bool, input string, err error) {
+	if asJSON {
+		if b, jerr := json.Marshal(errorJSON{Input: input, Error: err.Error()}); jerr == nil {
+			fmt.Fprintf(stderr, "%s\n", b)
+			return
+		}
+	}
+	if input == "" {
+		input = "semver"
+	}
+	fmt.Fprintf(stderr, "%s: %s\n", input, err)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// runJSON runs the CLI and decodes its output into v.
+func runJSON(t *testing.T, v interface{}, stdin string, args ...string) int {
+	t.Helper()
+	code, stdout, stderr := runCmd(stdin, args...)
+	if err := json.Unmarshal([]byte(stdout), v); err != nil {
+		t.Fatalf("unable to decode output of %v: %s\nstdout: %s\nstderr: %s", args, err, stdout, stderr)
+	}
+	return code
+}
+
+func TestValidateJSON(t *testing.T) {
+	var out []validateResult
+	code := runJSON(t, &out, "", "validate", "-json", "v1.2.3-beta.1+b5", "nope")
+	if code != exitFailure || len(out) != 2 {
+		t.Fatalf("unexpected validate output %d: %+v", code, out)
+	}
+
+	v := out[0].Version
+	if !out[0].Valid || v == nil || v.Original != "v1.2.3-beta.1+b5" || v.Version != "1.2.3-beta.1+b5" ||
+		v.Major != 1 || v.Minor != 2 || v.Patch != 3 || v.Prerelease != "beta.1" || v.Metadata != "b5" {
+		t.Errorf("unexpected parsed version: %+v %+v", out[0], v)
+	}
+	if out[1].Valid || out[1].Input != "nope" || out[1].Error == "" || out[1].Version != nil {
+		t.Errorf("unexpected invalid version result: %+v", out[1])
+	}
+}
+
+func TestCompareJSON(t *testing.T) {
+	var out compareResult
+	if code := runJSON(t, &out, "", "compare", "--json", "1.2.3", "1.10.0"); code != exitOK {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	if out.Result != -1 || out.A.Version != "1.2.3" || out.B.Minor != 10 {
+		t.Errorf("unexpected compare output: %+v", out)
+	}
+}
+
+func TestSortJSON(t *testing.T) {
+	var out []versionJSON
+	if code := runJSON(t, &out, "2.0.0\nv1.0\n1.5.0-rc.1\n", "sort", "-json"); code != exitOK {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+
+	var order []string
+	for _, v := range out {
+		order = append(order, v.Original)
+	}
+	if !equalStrings(order, []string{"v1.0", "1.5.0-rc.1", "2.0.0"}) {
+		t.Errorf("unexpected sort order %q", order)
+	}
+}
+
+func TestFilterJSON(t *testing.T) {
+	var out []filterResult
+	if code := runJSON(t, &out, "1.2.3\n2.0.0\n", "filter", "-json", "^1"); code != exitOK {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	if len(out) != 2 || out[0].Original != "1.2.3" || !out[0].Matched || out[1].Original != "2.0.0" || out[1].Matched {
+		t.Errorf("unexpected filter output: %+v %+v", out[0], out[1])
+	}
+}
+
+func TestBumpJSON(t *testing.T) {
+	var out bumpResult
+	if code := runJSON(t, &out, "", "bump", "-json", "minor", "1.2.3"); code != exitOK {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	if out.Previous.Version != "1.2.3" || out.Version.Version != "1.3.0" || out.File != "" {
+		t.Errorf("unexpected bump output: %+v", out)
+	}
+}
+
+func TestExplainJSON(t *testing.T) {
+	var out struct {
+		Constraint string
+		Version    string
+		Matched    bool
+		Branches   []struct {
+			Releases   []string
+			Exclusions []string
+			Clauses    []struct {
+				Clause  string
+				Matched bool
+				Reason  string
+			}
+		}
+	}
+	if code := runJSON(t, &out, "", "explain", "-json", ">=1.2, !=1.4.2", "1.4.2"); code != exitOK {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	if out.Version != "1.4.2" || out.Matched || len(out.Branches) != 1 {
+		t.Fatalf("unexpected explain output: %+v", out)
+	}
+	b := out.Branches[0]
+	if !equalStrings(b.Releases, []string{">=1.2.0"}) || !equalStrings(b.Exclusions, []string{"1.4.2"}) ||
+		b.Clauses[1].Clause != "!=1.4.2" || b.Clauses[1].Matched || b.Clauses[1].Reason == "" {
+		t.Errorf("unexpected explain branch: %+v", b)
+	}
+}
+
+func TestErrorsJSON(t *testing.T) {
+	tests := []struct {
+		stdin  string
+		args   []string
+		code   int
+		inputs []string
+	}{
+		{"", []string{"compare", "-json", "1.2.3", "nope"}, exitFailure, []string{"nope"}},
+		{"1.2.3\nbanana\n", []string{"filter", "-json", "^1"}, exitFailure, []string{"banana"}},
+		{"", []string{"filter", "-json", "<<1"}, exitFailure, []string{"<<1"}},
+		{"banana\n", []string{"sort", "-json"}, exitFailure, []string{"banana"}},
+		{"", []string{"explain", "-json", "<<1"}, exitFailure, []string{"<<1"}},
+		{"", []string{"explain", "-json", "^1", "nope"}, exitFailure, []string{"nope"}},
+		{"", []string{"satisfies", "-json", "nope", "^1"}, exitInvalid, []string{"nope"}},
+		{"", []string{"bump", "-json", "patch", "nope"}, exitFailure, []string{"nope"}},
+		{"", []string{"bump", "-json", "pre", "1.2.3"}, exitFailure, []string{""}},
+	}
+
+	for _, tc := range tests {
+		code, _, stderr := runCmd(tc.stdin, tc.args...)
+		if code != tc.code {
+			t.Errorf("%v: expected exit code %d but got %d", tc.args, tc.code, code)
+		}
+		lines := strings.Split(strings.TrimSpace(stderr), "\n")
+		if len(lines) != len(tc.inputs) {
+			t.Errorf("%v: expected %d errors but got %q", tc.args, len(tc.inputs), stderr)
+			continue
+		}
+		for k, l := range lines {
+			var e errorJSON
+			if err := json.Unmarshal([]byte(l), &e); err != nil || e.Input != tc.inputs[k] || e.Error == "" {
+				t.Errorf("%v: expected a JSON error for %q but got %q", tc.args, tc.inputs[k], l)
+			}
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+// Command semver validates, compares, sorts, and filters semantic versions
+// using the same rules as the semver package.
+//
+// Usage:
+//
+//	semver validate [-strict] <version>...
+//	semver compare <version> <version>
+//	semver sort [-r] < versions
+//	semver filter <constraint> < versions
+//	semver bump [-preid id] major|minor|patch|pre <file-or-version>
+//	semver explain <constraint> [version]
+//	semver satisfies <version> <constraint>
+//	semver translate [-from dialect] -to dialect <range>
+//	semver serve [-addr host:port]
+//
+// Commands reading versions from stdin expect one version per line. Every
+// command other than serve accepts -json, before its arguments, to write
+// structured results for other tools rather than plain text. With -json the
+// errors are written to stderr as JSON too, one object per line.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// The exit codes returned by the commands.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+
+	// exitInvalid is returned by satisfies when the version or constraint
+	// can not be parsed.
+	exitInvalid = 3
+)
+
+const usage = `usage: semver <command> [arguments]
+
+Commands:
+  validate [-strict] <version>...  check that versions can be parsed
+  compare <version> <version>      print -1, 0, or 1 comparing two versions
+  sort [-r] < versions             sort versions read from stdin
+  filter <constraint> < versions   print versions from stdin matching a constraint
+  bump [-preid id] <part> <target> increment a version or the version in a file
+  explain <constraint> [version]   show how a constraint is interpreted
+  satisfies <version> <constraint> exit 0 if the version satisfies the constraint,
+                                   1 if it does not, and 3 if either is invalid
+  translate [-from d] -to d <range> rewrite a range in another dialect:
+                                   semver, npm, or maven
+  serve [-addr host:port]          serve validate, compare, match, and
+                                   max-satisfying endpoints over HTTP
+
+Every command other than serve accepts -json to write its result as JSON.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// command is a subcommand of the CLI. It returns the exit code.
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"validate":  runValidate,
+	"compare":   runCompare,
+	"sort":      runSort,
+	"filter":    runFilter,
+	"bump":      runBump,
+	"explain":   runExplain,
+	"satisfies": runSatisfies,
+	"translate": runTranslate,
+	"serve":     runServe,
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "semver: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	return cmd(args[1:], stdin, stdout, stderr)
+}
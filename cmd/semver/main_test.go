@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// runCmd runs the CLI with the arguments and stdin returning the exit code
+// and output.
+func runCmd(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunUsage(t *testing.T) {
+	if code, _, stderr := runCmd(""); code != exitUsage || !strings.Contains(stderr, "usage:") {
+		t.Errorf("expected usage with no arguments but got %d: %q", code, stderr)
+	}
+	if code, _, stderr := runCmd("", "nope"); code != exitUsage || !strings.Contains(stderr, `unknown command "nope"`) {
+		t.Errorf("expected unknown command error but got %d: %q", code, stderr)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"1.2.3"}, exitOK},
+		{[]string{"1.2.3", "v1.2"}, exitOK},
+		{[]string{"-strict", "v1.2"}, exitFailure},
+		{[]string{"1.2.3", "foo"}, exitFailure},
+		{[]string{}, exitUsage},
+	}
+
+	for _, tc := range tests {
+		code, _, stderr := runCmd("", append([]string{"validate"}, tc.args...)...)
+		if code != tc.code {
+			t.Errorf("expected validate %v to exit %d but got %d: %q", tc.args, tc.code, code, stderr)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		code   int
+		stdout string
+	}{
+		{"1.2.3", "1.2.4", exitOK, "-1\n"},
+		{"v1.2", "1.2.0", exitOK, "0\n"},
+		{"2.0.0", "2.0.0-rc.1", exitOK, "1\n"},
+		{"2.0.0", "foo", exitFailure, ""},
+	}
+
+	for _, tc := range tests {
+		code, stdout, _ := runCmd("", "compare", tc.a, tc.b)
+		if code != tc.code || stdout != tc.stdout {
+			t.Errorf("expected compare %s %s to exit %d with %q but got %d with %q", tc.a, tc.b, tc.code, tc.stdout, code, stdout)
+		}
+	}
+
+	if code, _, _ := runCmd("", "compare", "1.2.3"); code != exitUsage {
+		t.Errorf("expected usage error but got %d", code)
+	}
+}
+
+func TestSort(t *testing.T) {
+	in := "1.2.3\nv1.0\n\n2.0.0-rc.1\n2.0.0\n0.4.2\n"
+
+	code, stdout, _ := runCmd(in, "sort")
+	if code != exitOK || stdout != "0.4.2\nv1.0\n1.2.3\n2.0.0-rc.1\n2.0.0\n" {
+		t.Errorf("unexpected sort output %d: %q", code, stdout)
+	}
+
+	code, stdout, _ = runCmd(in, "sort", "-r")
+	if code != exitOK || stdout != "2.0.0\n2.0.0-rc.1\n1.2.3\nv1.0\n0.4.2\n" {
+		t.Errorf("unexpected reverse sort output %d: %q", code, stdout)
+	}
+
+	code, stdout, stderr := runCmd("1.2.3\nmain\n1.0.0\n", "sort")
+	if code != exitFailure || stdout != "1.0.0\n1.2.3\n" || !strings.Contains(stderr, "main:") {
+		t.Errorf("unexpected sort output with invalid input %d: %q %q", code, stdout, stderr)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	in := "1.2.3\n1.4.0\n2.0.0\n1.5.0-beta.1\n"
+
+	code, stdout, _ := runCmd(in, "filter", "^1.2")
+	if code != exitOK || stdout != "1.2.3\n1.4.0\n" {
+		t.Errorf("unexpected filter output %d: %q", code, stdout)
+	}
+
+	if code, _, _ := runCmd(in, "filter", "nope"); code != exitFailure {
+		t.Errorf("expected failure for an invalid constraint but got %d", code)
+	}
+	if code, _, _ := runCmd(in, "filter"); code != exitUsage {
+		t.Errorf("expected usage error but got %d", code)
+	}
+}
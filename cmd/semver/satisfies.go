@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// satisfiesResult is the JSON form of the satisfies command.
+type satisfiesResult struct {
+	Version    *versionJSON `json:"version"`
+	Constraint string       `json:"constraint"`
+	Satisfied  bool         `json:"satisfied"`
+	Reasons    []string     `json:"reasons,omitempty"`
+}
+
+// runSatisfies exits with exitOK when the version satisfies the constraint
+// and exitFailure when it does not, so CI steps can gate on the status alone.
+// An invalid version or constraint exits with exitInvalid to tell it apart
+// from a version that does not match.
+func runSatisfies(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("satisfies", stderr)
+	asJSON := jsonFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 2 {
+		fmt.Fprintln(stderr, "usage: semver satisfies [-json] <version> <constraint>")
+		return exitUsage
+	}
+
+	v, err := semver.NewVersion(fs.Arg(0))
+	if err != nil {
+		writeError(stderr, *asJSON, fs.Arg(0), err)
+		return exitInvalid
+	}
+	c, err := semver.NewConstraint(fs.Arg(1))
+	if err != nil {
+		writeError(stderr, *asJSON, fs.Arg(1), err)
+		return exitInvalid
+	}
+
+	ok, errs := c.Validate(v)
+	code := exitOK
+	if !ok {
+		code = exitFailure
+	}
+
+	if *asJSON {
+		r := satisfiesResult{Version: newVersionJSON(v), Constraint: c.String(), Satisfied: ok}
+		for _, e := range errs {
+			r.Reasons = append(r.Reasons, e.Error())
+		}
+		return writeJSON(stdout, stderr, r, code)
+	}
+	for _, e := range errs {
+		fmt.Fprintln(stderr, e)
+	}
+	return code
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		version, constraint string
+		code                int
+	}{
+		{"1.2.3", "^1.2", exitOK},
+		{"v1.4.0", ">=1.2, <2 || 3", exitOK},
+		{"2.0.0", "^1.2", exitFailure},
+		{"1.3.0-beta", "^1.2", exitFailure},
+		{"nope", "^1.2", exitInvalid},
+		{"1.2.3", "nope", exitInvalid},
+	}
+
+	for _, tc := range tests {
+		code, stdout, stderr := runCmd("", "satisfies", tc.version, tc.constraint)
+		if code != tc.code {
+			t.Errorf("expected %s satisfies %q to exit %d but got %d: %q", tc.version, tc.constraint, tc.code, code, stderr)
+		}
+		if stdout != "" {
+			t.Errorf("expected no output on stdout but got %q", stdout)
+		}
+	}
+
+	if _, _, stderr := runCmd("", "satisfies", "2.0.0", "^1.2"); !strings.Contains(stderr, "2.0.0 does not have same major version as 1.2") {
+		t.Errorf("expected the reason on stderr but got %q", stderr)
+	}
+	if code, _, _ := runCmd("", "satisfies", "1.2.3"); code != exitUsage {
+		t.Errorf("expected usage error but got %d", code)
+	}
+}
+
+func TestSatisfiesJSON(t *testing.T) {
+	var out satisfiesResult
+	if code := runJSON(t, &out, "", "satisfies", "-json", "2.0.0", "^1.2"); code != exitFailure {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	if out.Satisfied || out.Constraint != "^1.2" || out.Version.Version != "2.0.0" || len(out.Reasons) == 0 {
+		t.Errorf("unexpected satisfies output: %+v", out)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jesseduffield/semver/v3/semverhttp"
+)
+
+// listenAndServe starts the HTTP server. It is a variable so tests can avoid
+// listening on a port.
+var listenAndServe = http.ListenAndServe
+
+func runServe(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("serve", stderr)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprintln(stderr, "usage: semver serve [-addr host:port]")
+		return exitUsage
+	}
+
+	fmt.Fprintf(stderr, "semver: listening on %s\n", *addr)
+	if err := listenAndServe(*addr, semverhttp.NewHandler()); err != nil {
+		fmt.Fprintf(stderr, "semver: %s\n", err)
+		return exitFailure
+	}
+	return exitOK
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServe(t *testing.T) {
+	defer func(f func(string, http.Handler) error) { listenAndServe = f }(listenAndServe)
+
+	var addr string
+	var h http.Handler
+	listenAndServe = func(a string, handler http.Handler) error {
+		addr, h = a, handler
+		return http.ErrServerClosed
+	}
+
+	if code, _, _ := runCmd("", "serve", "-addr", ":9999"); code != exitFailure {
+		t.Errorf("expected the server error to fail but got %d", code)
+	}
+	if addr != ":9999" || h == nil {
+		t.Fatalf("expected to serve on :9999 but got %q", addr)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/match", strings.NewReader(`{"constraint": "^1", "version": "1.2.3"}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"matched":true`) {
+		t.Errorf("unexpected response %d: %s", w.Code, w.Body)
+	}
+
+	listenAndServe = func(string, http.Handler) error { return errors.New("address in use") }
+	if code, _, stderr := runCmd("", "serve"); code != exitFailure || !strings.Contains(stderr, "address in use") {
+		t.Errorf("expected a listen error but got %d: %q", code, stderr)
+	}
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// dialect is the range syntax of an ecosystem. A range is read into
+// constraints with parse and written back from the intervals of releases it
+// admits with render, so any dialect can be translated to any other.
+type dialect struct {
+	parse  func(string) (*semver.Constraints, error)
+	render func([]semver.Interval) string
+}
+
+var dialects = map[string]dialect{
+	"semver": {parse: semver.NewConstraint, render: renderConstraints},
+	"npm":    {parse: parseNpm, render: renderConstraints},
+	"maven":  {parse: parseMaven, render: renderMaven},
+}
+
+// dialectNames returns the names of the dialects in order.
+func dialectNames() []string {
+	names := make([]string, 0, len(dialects))
+	for n := range dialects {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// translateResult is the JSON form of the translate command.
+type translateResult struct {
+	Input  string `json:"input"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Output string `json:"output"`
+}
+
+// runTranslate rewrites a range from one dialect to another. Only the releases
+// the range admits are translated, as the dialects do not agree on which
+// prereleases a range admits.
+func runTranslate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("translate", stderr)
+	from := fs.String("from", "semver", "the dialect the range is written in")
+	to := fs.String("to", "", "the dialect to write the range in")
+	asJSON := jsonFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 || *to == "" {
+		fmt.Fprintln(stderr, "usage: semver translate [-from dialect] -to dialect [-json] <range>")
+		return exitUsage
+	}
+	src, ok := dialects[*from]
+	if !ok {
+		fmt.Fprintf(stderr, "semver: unknown dialect %q, expected one of %s\n", *from, strings.Join(dialectNames(), ", "))
+		return exitUsage
+	}
+	dst, ok := dialects[*to]
+	if !ok {
+		fmt.Fprintf(stderr, "semver: unknown dialect %q, expected one of %s\n", *to, strings.Join(dialectNames(), ", "))
+		return exitUsage
+	}
+
+	c, err := src.parse(fs.Arg(0))
+	if err != nil {
+		writeError(stderr, *asJSON, fs.Arg(0), err)
+		return exitFailure
+	}
+	in := releaseIntervals(c)
+	if len(in) == 0 {
+		writeError(stderr, *asJSON, fs.Arg(0), errors.New("the range admits no releases"))
+		return exitFailure
+	}
+
+	out := dst.render(in)
+	if *asJSON {
+		return writeJSON(stdout, stderr, translateResult{Input: fs.Arg(0), From: *from, To: *to, Output: out}, exitOK)
+	}
+	fmt.Fprintln(stdout, out)
+	return exitOK
+}
+
+// releaseIntervals returns the intervals of releases the constraints admit,
+// splitting ranges around the versions they exclude as not every dialect can
+// exclude a version. An interval holding a single release is returned as that
+// release alone.
+func releaseIntervals(c *semver.Constraints) []semver.Interval {
+	var out []semver.Interval
+	add := func(min *semver.Version, includeMin bool, max *semver.Version, includeMax bool) {
+		// Ranges have an exclusive max, so [1.2.3, 1.2.4) is only 1.2.3.
+		if min != nil && max != nil && !includeMax {
+			if next := min.IncPatch(); next.Equal(max) {
+				max, includeMax = min, true
+			}
+		}
+		out = append(out, semver.NewInterval(min, includeMin, max, includeMax))
+	}
+	for _, r := range c.Ranges() {
+		min, includeMin := r.Min(), r.IncludesMin()
+		for _, v := range r.Excluded() {
+			add(min, includeMin, v, false)
+			next := v.IncPatch()
+			min, includeMin = &next, true
+		}
+		add(min, includeMin, r.Max(), r.IncludesMax())
+	}
+	return out
+}
+
+// parseNpm parses an npm range with the options of node-semver.
+func parseNpm(r string) (*semver.Constraints, error) {
+	return semver.NewConstraintWithOptions(r, semver.NpmOptions())
+}
+
+// renderConstraints writes intervals as constraints, such as
+// `>=1.2.0 <2.0.0 || >=3.0.0`, which npm reads the same way.
+func renderConstraints(in []semver.Interval) string {
+	s := make([]string, len(in))
+	for k, i := range in {
+		s[k] = i.String()
+	}
+	return strings.Join(s, " || ")
+}
+
+// parseMaven parses a Maven version range, such as `[1.2,2.0)` or
+// `(,1.0],[1.2,)`. A bare version, which Maven takes as a soft requirement,
+// is read as that version alone.
+func parseMaven(r string) (*semver.Constraints, error) {
+	s := strings.TrimSpace(r)
+	if s == "" {
+		return nil, errors.New("empty range")
+	}
+	if s[0] != '[' && s[0] != '(' {
+		v, err := semver.NewVersion(s)
+		if err != nil {
+			return nil, err
+		}
+		return semver.NewConstraint("=" + v.String())
+	}
+
+	var groups []string
+	for s != "" {
+		end := strings.IndexAny(s, "])")
+		if end < 0 || (s[0] != '[' && s[0] != '(') {
+			return nil, fmt.Errorf("invalid range %q", r)
+		}
+		g, err := mavenGroup(s[1:end], s[0] == '[', s[end] == ']')
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+
+		s = strings.TrimSpace(s[end+1:])
+		if s != "" {
+			if s[0] != ',' {
+				return nil, fmt.Errorf("invalid range %q", r)
+			}
+			s = strings.TrimSpace(s[1:])
+		}
+	}
+	return semver.NewConstraint(strings.Join(groups, " || "))
+}
+
+// mavenGroup returns the constraints for the bounds between the brackets of
+// a Maven range, such as `1.2,2.0`, and whether each bracket is inclusive.
+func mavenGroup(bounds string, includeMin, includeMax bool) (string, error) {
+	k := strings.Index(bounds, ",")
+	if k < 0 {
+		if !includeMin || !includeMax {
+			return "", fmt.Errorf("invalid range %q, a single version must be in []", bounds)
+		}
+		v, err := semver.NewVersion(strings.TrimSpace(bounds))
+		if err != nil {
+			return "", err
+		}
+		return "=" + v.String(), nil
+	}
+
+	var clauses []string
+	if lo := strings.TrimSpace(bounds[:k]); lo != "" {
+		v, err := semver.NewVersion(lo)
+		if err != nil {
+			return "", err
+		}
+		op := ">"
+		if includeMin {
+			op = ">="
+		}
+		clauses = append(clauses, op+v.String())
+	}
+	if hi := strings.TrimSpace(bounds[k+1:]); hi != "" {
+		v, err := semver.NewVersion(hi)
+		if err != nil {
+			return "", err
+		}
+		op := "<"
+		if includeMax {
+			op = "<="
+		}
+		clauses = append(clauses, op+v.String())
+	}
+	if len(clauses) == 0 {
+		return "*", nil
+	}
+	return strings.Join(clauses, " "), nil
+}
+
+// renderMaven writes intervals as a Maven version range, such as
+// `[1.2.0,2.0.0),[3.0.0,)`.
+func renderMaven(in []semver.Interval) string {
+	s := make([]string, len(in))
+	for k, i := range in {
+		min, max := i.Min(), i.Max()
+		switch {
+		case min != nil && max != nil && i.IncludesMin() && i.IncludesMax() && min.Equal(max):
+			s[k] = "[" + min.String() + "]"
+			continue
+		case min == nil && max == nil:
+			// Maven has no range without bounds, and no version is below
+			// 0.0.0.
+			s[k] = "[0.0.0,)"
+			continue
+		}
+
+		var b strings.Builder
+		switch {
+		case min == nil:
+			b.WriteString("(")
+		case i.IncludesMin():
+			b.WriteString("[" + min.String())
+		default:
+			b.WriteString("(" + min.String())
+		}
+		b.WriteString(",")
+		switch {
+		case max == nil:
+			b.WriteString(")")
+		case i.IncludesMax():
+			b.WriteString(max.String() + "]")
+		default:
+			b.WriteString(max.String() + ")")
+		}
+		s[k] = b.String()
+	}
+	return strings.Join(s, ",")
+}
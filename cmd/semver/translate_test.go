@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		from, to string
+		in       string
+		out      string
+	}{
+		{"npm", "maven", "^1.2.3", "[1.2.3,2.0.0)"},
+		{"npm", "maven", "~1.2 || >=3", "[1.2.0,1.3.0),[3.0.0,)"},
+		{"npm", "maven", "<2", "(,2.0.0)"},
+		{"npm", "maven", "*", "[0.0.0,)"},
+		{"npm", "maven", "1.2.3", "[1.2.3]"},
+		{"semver", "maven", ">=1.2, !=1.4.2, <2", "[1.2.0,1.4.2),[1.4.3,2.0.0)"},
+		{"maven", "npm", "[1.2,2.0),[3.0,)", ">=1.2.0 <2.0.0 || >=3.0.0"},
+		{"maven", "npm", "(1.0,2.0]", ">=1.0.1 <2.0.1"},
+		{"maven", "npm", "[1.5]", "1.5.0"},
+		{"maven", "npm", "1.5", "1.5.0"},
+		{"maven", "npm", "(,)", "*"},
+		{"maven", "maven", " [ 1.0 , 2.0 ) ", "[1.0.0,2.0.0)"},
+	}
+
+	for _, tc := range tests {
+		code, stdout, stderr := runCmd("", "translate", "-from", tc.from, "-to", tc.to, tc.in)
+		if code != exitOK || stdout != tc.out+"\n" {
+			t.Errorf("expected %q from %s to %s to be %q but got %d %q: %s", tc.in, tc.from, tc.to, tc.out, code, stdout, stderr)
+		}
+	}
+}
+
+func TestTranslateErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"-to", "maven", "nope"}, exitFailure},
+		{[]string{"-to", "maven", ">2 <1"}, exitFailure},
+		{[]string{"-from", "maven", "-to", "npm", "[1.5)"}, exitFailure},
+		{[]string{"-from", "maven", "-to", "npm", "[1.0,2.0"}, exitFailure},
+		{[]string{"-from", "maven", "-to", "npm", "[1.0,2.0) [3.0,)"}, exitFailure},
+		{[]string{"-from", "maven", "-to", "npm", "[1.0,nope)"}, exitFailure},
+		{[]string{"-from", "cobol", "-to", "npm", "^1"}, exitUsage},
+		{[]string{"-to", "cobol", "^1"}, exitUsage},
+		{[]string{"^1"}, exitUsage},
+		{[]string{"-to", "npm"}, exitUsage},
+	}
+
+	for _, tc := range tests {
+		if code, _, stderr := runCmd("", append([]string{"translate"}, tc.args...)...); code != tc.code {
+			t.Errorf("expected translate %v to exit %d but got %d: %q", tc.args, tc.code, code, stderr)
+		}
+	}
+}
+
+func TestTranslateJSON(t *testing.T) {
+	code, stdout, _ := runCmd("", "translate", "-json", "-from", "npm", "-to", "maven", "~1.2")
+	if code != exitOK || !strings.Contains(stdout, `"output": "[1.2.0,1.3.0)"`) || !strings.Contains(stdout, `"from": "npm"`) {
+		t.Errorf("unexpected translate output %d:\n%s", code, stdout)
+	}
+}
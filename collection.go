@@ -1,5 +1,7 @@
 package semver
 
+import "strings"
+
 // Collection is a collection of Version instances and implements the sort
 // interface. See the sort package for more details.
 // https://golang.org/pkg/sort/
@@ -22,3 +24,30 @@ func (c Collection) Less(i, j int) bool {
 func (c Collection) Swap(i, j int) {
 	c[i], c[j] = c[j], c[i]
 }
+
+// CompareVersions compares two versions, returning -1, 0, or 1 as
+// Version.Compare does. Its signature is the one slices.SortFunc and
+// slices.BinarySearchFunc expect, so a []*Version can be sorted without a
+// closure. A nil version sorts before every other version.
+func CompareVersions(a, b *Version) int {
+	return a.Compare(b)
+}
+
+// CompareStringsFunc compares two version strings with the signature
+// slices.SortFunc expects, for sorting tags and the like as they are. The
+// strings are parsed with NewVersion on each call, so sorting many strings is
+// faster when they are parsed first. Strings that are not versions sort after
+// every version, in byte order among themselves.
+func CompareStringsFunc(a, b string) int {
+	va, errA := newVersion(a)
+	vb, errB := newVersion(b)
+	switch {
+	case errA == nil && errB == nil:
+		return va.Compare(vb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -44,3 +44,46 @@ func TestCollection(t *testing.T) {
 		t.Error("Sorting Collection failed")
 	}
 }
+
+func TestCompareVersions(t *testing.T) {
+	vs := []*Version{MustParse("1.10.0"), nil, MustParse("1.2.0"), MustParse("1.2.0-rc.1"), MustParse("v1.2")}
+	sort.SliceStable(vs, func(i, j int) bool { return CompareVersions(vs[i], vs[j]) < 0 })
+
+	var got []string
+	for _, v := range vs {
+		if v == nil {
+			got = append(got, "nil")
+			continue
+		}
+		got = append(got, v.Original())
+	}
+	if e := []string{"nil", "1.2.0-rc.1", "1.2.0", "v1.2", "1.10.0"}; !reflect.DeepEqual(got, e) {
+		t.Errorf("expected %q but got %q", e, got)
+	}
+}
+
+func TestCompareStringsFunc(t *testing.T) {
+	s := []string{"v1.10.0", "latest", "1.2.0", "banana", "1.2.0-rc.1", "0.9", "v1.2"}
+	sort.SliceStable(s, func(i, j int) bool { return CompareStringsFunc(s[i], s[j]) < 0 })
+
+	if e := []string{"0.9", "1.2.0-rc.1", "1.2.0", "v1.2", "v1.10.0", "banana", "latest"}; !reflect.DeepEqual(s, e) {
+		t.Errorf("expected %q but got %q", e, s)
+	}
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.2.0", "v1.2", 0},
+		{"1.2.0", "1.10.0", -1},
+		{"banana", "1.0.0", 1},
+		{"1.0.0", "banana", -1},
+		{"apple", "banana", -1},
+		{"banana", "banana", 0},
+	}
+	for _, tc := range tests {
+		if got := CompareStringsFunc(tc.a, tc.b); got != tc.expected {
+			t.Errorf("expected %q compared to %q to be %d but got %d", tc.a, tc.b, tc.expected, got)
+		}
+	}
+}
@@ -1,27 +1,131 @@
 package semver
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 )
 
 // Constraints is one or more constraint that a semantic version can be
 // checked against.
 type Constraints struct {
 	constraints [][]*constraint
+
+	// groups tracks the backing array of constraints when it is shared by
+	// constraints built with Union. It is nil otherwise.
+	groups *groupArray
+
+	// The options used by Check and Validate
+	opts MatchOptions
 }
 
 // NewConstraint returns a Constraints instance that a Version instance can
 // be checked against. If there is a parse error it will be returned.
 func NewConstraint(c string) (*Constraints, error) {
+	return NewConstraintWithOptions(c, MatchOptions{})
+}
+
+// StrictNewConstraint parses constraints the same way as NewConstraint but
+// rejects degenerate syntax rather than collapsing it. An empty || branch, as
+// in `^1 ||`, and an empty clause between commas, as in `^1,,^2` or `^1,`,
+// are errors.
+func StrictNewConstraint(c string) (*Constraints, error) {
+	return StrictNewConstraintWithOptions(c, MatchOptions{})
+}
+
+// StrictNewConstraintWithOptions parses constraints the same way as
+// StrictNewConstraint keeping the options with them as
+// NewConstraintWithOptions does.
+func StrictNewConstraintWithOptions(c string, opts MatchOptions) (*Constraints, error) {
+	h := currentHooks()
+	if h == nil {
+		return strictNewConstraintWithOptions(c, opts)
+	}
+	start := time.Now()
+	cs, err := strictNewConstraintWithOptions(c, opts)
+	parseHook(h, ParseConstraint, c, start, false, err)
+	return cs, err
+}
+
+func strictNewConstraintWithOptions(c string, opts MatchOptions) (*Constraints, error) {
+	cs, err := newConstraint(c, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	cs.opts = opts
+	return cs, nil
+}
+
+// NewConstraintWithOptions returns a Constraints instance the same way as
+// NewConstraint. The options are kept with the constraints and used by Check,
+// Validate, and any other operation on them, so they behave consistently
+// wherever the constraints are passed.
+func NewConstraintWithOptions(c string, opts MatchOptions) (*Constraints, error) {
+	h := currentHooks()
+	if h == nil {
+		return newConstraintWithOptions(c, opts)
+	}
+	start := time.Now()
+	cs, err := newConstraintWithOptions(c, opts)
+	parseHook(h, ParseConstraint, c, start, false, err)
+	return cs, err
+}
+
+func newConstraintWithOptions(c string, opts MatchOptions) (*Constraints, error) {
+	cs, err := newConstraint(c, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	cs.opts = opts
+	return cs, nil
+}
+
+// newConstraint parses constraints recording each step into t when it is not
+// nil. When strict is true degenerate separators are errors rather than
+// being collapsed.
+func newConstraint(c string, t *Trace, strict bool) (*Constraints, error) {
+	if err := checkConstraintLimits(c); err != nil {
+		return nil, err
+	}
+
+	// Expand the operators registered with RegisterOperator. The expansion
+	// may be longer than the input so the limits are checked again.
+	ec, err := expandOperators(c)
+	if err != nil {
+		return nil, err
+	}
+	if ec != c {
+		t.record(TraceOperator, c, ec)
+		if err := checkConstraintLimits(ec); err != nil {
+			return nil, err
+		}
+		c = ec
+	}
+
+	if err := checkASCII(c); err != nil {
+		return nil, err
+	}
+
+	// Drop empty || branches and clauses between commas.
+	cc, err := collapseSeparators(c, strict)
+	if err != nil {
+		return nil, err
+	}
+	if cc != c {
+		t.record(TraceCollapse, c, cc)
+		c = cc
+	}
 
 	// Rewrite - ranges into a comparison operation.
-	c = rewriteRange(c)
+	rc := rewriteRange(c)
+	t.record(TraceRewriteRange, c, rc)
+	c = rc
 
 	ors := strings.Split(c, "||")
+	t.record(TraceSplitOr, c, fmt.Sprintf("%q", ors))
 	or := make([][]*constraint, len(ors))
 	for k, v := range ors {
 
@@ -36,12 +140,14 @@ func NewConstraint(c string) (*Constraints, error) {
 		if cs == nil {
 			cs = append(cs, v)
 		}
+		t.record(TraceSplitAnd, v, fmt.Sprintf("%q", cs))
 		result := make([]*constraint, len(cs))
 		for i, s := range cs {
 			pc, err := parseConstraint(s)
 			if err != nil {
 				return nil, err
 			}
+			t.record(TraceParseClause, s, pc.describe())
 
 			result[i] = pc
 		}
@@ -49,24 +155,155 @@ func NewConstraint(c string) (*Constraints, error) {
 	}
 
 	o := &Constraints{constraints: or}
+	t.record(TraceResult, c, o.structure())
 	return o, nil
 }
 
-// Check tests if a version satisfies the constraints.
+// collapseSeparators removes the empty || branches and the empty clauses
+// between commas from c, so `^1 || || ^2,` becomes `^1 || ^2`. Input without
+// any is returned as is, as is blank input, which is left to be rejected by
+// the parser. When strict is true an empty branch or clause is an error
+// instead. Input with nothing but separators is always an error.
+func collapseSeparators(c string, strict bool) (string, error) {
+	if strings.TrimSpace(c) == "" {
+		return c, nil
+	}
+
+	collapsed := false
+	branches := strings.Split(c, "||")
+	kept := branches[:0]
+	for _, b := range branches {
+		if strings.TrimSpace(b) == "" {
+			if strict {
+				return "", fmt.Errorf("%s has an empty || branch", c)
+			}
+			collapsed = true
+			continue
+		}
+
+		clauses := strings.Split(b, ",")
+		keptClauses := clauses[:0]
+		for i, cl := range clauses {
+			if strings.TrimSpace(cl) != "" {
+				keptClauses = append(keptClauses, cl)
+				continue
+			}
+			if strict {
+				switch {
+				case i == 0:
+					return "", fmt.Errorf("%s has a leading comma", c)
+				case i == len(clauses)-1:
+					return "", fmt.Errorf("%s has a trailing comma", c)
+				default:
+					return "", fmt.Errorf("%s has an empty clause between commas", c)
+				}
+			}
+			collapsed = true
+		}
+		if len(keptClauses) == 0 {
+			collapsed = true
+			continue
+		}
+		kept = append(kept, strings.Join(keptClauses, ","))
+	}
+
+	if len(kept) == 0 {
+		return "", fmt.Errorf("%s has no constraints", c)
+	}
+	if !collapsed {
+		return c, nil
+	}
+	return strings.Join(kept, "||"), nil
+}
+
+// Check tests if a version satisfies the constraints. A nil version
+// satisfies none.
 func (cs Constraints) Check(v *Version) bool {
+	return cs.CheckWithOptions(v, cs.opts)
+}
+
+// Matches tests if a version satisfies the constraints the same way as Check,
+// returning a *MatchError when the version cannot be checked, such as when
+// it is nil.
+func (cs Constraints) Matches(v *Version) (bool, error) {
+	if v == nil {
+		return false, &MatchError{Constraint: cs.String(), Err: ErrNilVersion}
+	}
+	return cs.Check(v), nil
+}
+
+// MatchError is returned by Matches when a version cannot be checked against
+// constraints.
+type MatchError struct {
+	// Constraint is the rendering of the constraints.
+	Constraint string
+
+	// Err is the reason, such as ErrNilVersion.
+	Err error
+}
+
+// Error returns the constraints and the reason.
+func (e *MatchError) Error() string {
+	return fmt.Sprintf("unable to match %s: %s", e.Constraint, e.Err)
+}
+
+// Unwrap returns the reason so it can be checked with errors.Is.
+func (e *MatchError) Unwrap() error {
+	return e.Err
+}
+
+// Options returns the options the constraints were created with. For
+// constraints combined by Union or Intersect from constraints with different
+// options it is the options chosen for the result, while each clause is still
+// checked with the options it was created with.
+func (cs Constraints) Options() MatchOptions {
+	return cs.opts
+}
+
+// CheckWithOptions tests if a version satisfies the constraints using the
+// passed in options rather than the ones they were created with. Every
+// clause is checked with them, except that passing the options returned by
+// Options is the same as Check.
+func (cs Constraints) CheckWithOptions(v *Version, opts MatchOptions) bool {
+	h := currentHooks()
+	if h == nil {
+		return cs.checkWithOptions(v, opts)
+	}
+	start := time.Now()
+	ok := cs.checkWithOptions(v, opts)
+	c := cs
+	matchHook(h, &c, v, ok, start, false)
+	return ok
+}
+
+func (cs Constraints) checkWithOptions(v *Version, opts MatchOptions) bool {
+	if v == nil {
+		return false
+	}
+
 	// TODO(mattfarina): For v4 of this library consolidate the Check and Validate
 	// functions as the underlying functions make that possible now.
 	// loop over the ORs and check the inner ANDs
+	own := opts == cs.opts
 	for _, o := range cs.constraints {
-		joy := true
-		for _, c := range o {
-			if check, _ := c.check(v); !check {
-				joy = false
-				break
+		if own && mixedGroup(o) {
+			// The options are copied so cs does not escape when the group
+			// is not mixed.
+			base := cs.opts
+			joy := true
+			for _, p := range groupParts(o, &base, true) {
+				if !checkGroup(v, p.clauses, *p.opts) {
+					joy = false
+					break
+				}
 			}
+			if joy {
+				return true
+			}
+			continue
 		}
 
-		if joy {
+		if checkGroup(v, o, opts) {
 			return true
 		}
 	}
@@ -74,6 +311,21 @@ func (cs Constraints) Check(v *Version) bool {
 	return false
 }
 
+// checkGroup reports if a version satisfies every constraint in an AND group
+// checked with the options.
+func checkGroup(v *Version, group []*constraint, opts MatchOptions) bool {
+	gopts, ok := groupOptions(v, group, opts)
+	if !ok {
+		return false
+	}
+	for _, c := range group {
+		if check, _ := c.matches(v, &gopts); !check {
+			return false
+		}
+	}
+	return true
+}
+
 const (
 	BAD = iota
 	BAD_AHEAD
@@ -91,7 +343,7 @@ func (cs Constraints) Status(v *Version) int {
 		return GOOD
 	}
 
-	if len(cs.constraints) > 1 || len(cs.constraints[0]) > 1 {
+	if v == nil || len(cs.constraints) != 1 || len(cs.constraints[0]) > 1 {
 		return BAD
 	}
 
@@ -108,31 +360,74 @@ func (cs Constraints) Status(v *Version) int {
 // Validate checks if a version satisfies a constraint. If not a slice of
 // reasons for the failure are returned in addition to a bool.
 func (cs Constraints) Validate(v *Version) (bool, []error) {
+	return cs.ValidateWithOptions(v, cs.opts)
+}
+
+// ValidateWithOptions checks if a version satisfies a constraint using the
+// passed in options rather than the ones they were created with. If not a
+// slice of reasons for the failure are returned in addition to a bool.
+func (cs Constraints) ValidateWithOptions(v *Version, opts MatchOptions) (bool, []error) {
+	h := currentHooks()
+	if h == nil {
+		return cs.validateWithOptions(v, opts)
+	}
+	start := time.Now()
+	ok, errs := cs.validateWithOptions(v, opts)
+	c := cs
+	matchHook(h, &c, v, ok, start, false)
+	return ok, errs
+}
+
+func (cs Constraints) validateWithOptions(v *Version, opts MatchOptions) (bool, []error) {
+	if v == nil {
+		return false, []error{ErrNilVersion}
+	}
+
 	// loop over the ORs and check the inner ANDs
 	var e []error
 
 	// Capture the prerelease message only once. When it happens the first time
 	// this var is marked
 	var prerelesase bool
+	own := opts == cs.opts
 	for _, o := range cs.constraints {
 		joy := true
-		for _, c := range o {
-			// Before running the check handle the case there the version is
-			// a prerelease and the check is not searching for prereleases.
-			if c.con.pre == "" && v.pre != "" {
+		parts := groupParts(o, &opts, own)
+		for _, p := range parts {
+			if _, ok := groupOptions(v, p.clauses, *p.opts); !ok {
+				joy = false
 				if !prerelesase {
-					em := fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+					em := fmt.Errorf("%s is a prerelease version and the constraint does not name a prerelease of %d.%d.%d", v, v.major, v.minor, v.patch)
 					e = append(e, em)
 					prerelesase = true
 				}
-				joy = false
+			}
+		}
+		if !joy && len(parts) == 1 {
+			continue
+		}
 
-			} else {
+		for _, c := range o {
+			p := partOf(parts, c, &opts)
+			gopts, ok := groupOptions(v, p.clauses, *p.opts)
+			if !ok {
+				continue
+			}
+			if _, err := c.check(v, &gopts); err != nil {
+				joy = false
 
-				if _, err := c.check(v); err != nil {
-					e = append(e, err)
-					joy = false
+				// Handle the case where the version is a prerelease and the
+				// check is not searching for prereleases. The check is still
+				// run first as an exact != admits prereleases.
+				if c.skipPrerelease(v, &gopts) {
+					if !prerelesase {
+						em := fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+						e = append(e, em)
+						prerelesase = true
+					}
+					continue
 				}
+				e = append(e, err)
 			}
 		}
 
@@ -144,36 +439,95 @@ func (cs Constraints) Validate(v *Version) (bool, []error) {
 	return false, e
 }
 
+// String renders the constraints. The || separated branches are rendered in a
+// canonical order, by the lowest version each admits and then by how they are
+// written, so constraints differing only in the order of their branches render
+// the same.
 func (cs Constraints) String() string {
-	buf := make([]string, len(cs.constraints))
-	var tmp bytes.Buffer
-
-	for k, v := range cs.constraints {
-		tmp.Reset()
-		vlen := len(v)
-		for kk, c := range v {
-			tmp.WriteString(c.string())
-
-			// Space separate the AND conditions
-			if vlen > 1 && kk < vlen-1 {
-				tmp.WriteString(" ")
-			}
+	return cs.Render(RenderOptions{})
+}
+
+// sortedGroups returns the || separated groups in the order String renders
+// them.
+func (cs Constraints) sortedGroups() [][]*constraint {
+	if len(cs.constraints) < 2 {
+		return cs.constraints
+	}
+
+	type key struct {
+		group []*constraint
+		min   bound
+		empty bool
+		s     string
+	}
+	keys := make([]key, len(cs.constraints))
+	for k, group := range cs.constraints {
+		keys[k] = key{group: group, s: groupString(group)}
+		set, _ := andSets(group, &cs.opts)
+		switch {
+		case set.empty():
+			keys[k].empty = true
+		case len(set.releases) == 0:
+			keys[k].min = set.prereleases[0].min
+		case len(set.prereleases) == 0 || compareMin(set.releases[0].min, set.prereleases[0].min) <= 0:
+			keys[k].min = set.releases[0].min
+		default:
+			keys[k].min = set.prereleases[0].min
 		}
-		buf[k] = tmp.String()
 	}
 
-	return strings.Join(buf, " || ")
+	// Groups admitting no versions have no lowest version and go last.
+	sort.SliceStable(keys, func(a, b int) bool {
+		ka, kb := keys[a], keys[b]
+		if ka.empty != kb.empty {
+			return kb.empty
+		}
+		if d := compareMin(ka.min, kb.min); d != 0 && !ka.empty {
+			return d < 0
+		}
+		return ka.s < kb.s
+	})
+
+	out := make([][]*constraint, len(keys))
+	for k, key := range keys {
+		out[k] = key.group
+	}
+	return out
+}
+
+// Disjuncts returns each || separated branch of the constraints as constraints
+// of its own, in the order they were written. The branches keep the options
+// of c. Constraints without a || have a single branch, while nil constraints
+// have none.
+func Disjuncts(c *Constraints) []*Constraints {
+	c = orNone(c, nil)
+	out := make([]*Constraints, len(c.constraints))
+	for k, group := range c.constraints {
+		out[k] = &Constraints{
+			constraints: [][]*constraint{group},
+			opts:        c.opts,
+		}
+	}
+	return out
 }
 
 var constraintOps map[string]cfunc
-var constraintRegex *regexp.Regexp
-var constraintRangeRegex *regexp.Regexp
+
+// The regular expressions are compiled the first time constraints are
+// parsed.
+var constraintRegex = newLazyRegexpFunc(func() string {
+	return fmt.Sprintf(`^\s*(%s)\s*(%s)\s*$`, constraintOpsRegex(), cvRegex)
+})
 
 // Used to find individual constraints within a multi-constraint string
-var findConstraintRegex *regexp.Regexp
+var findConstraintRegex = newLazyRegexpFunc(func() string {
+	return fmt.Sprintf(`(%s)\s*(%s)`, constraintOpsRegex(), cvRegex)
+})
 
 // Used to validate an segment of ANDs is valid
-var validConstraintRegex *regexp.Regexp
+var validConstraintRegex = newLazyRegexpFunc(func() string {
+	return fmt.Sprintf(`^(\s*(%s)\s*(%s)\s*\,?)+$`, constraintOpsRegex(), cvRegex)
+})
 
 const cvRegex string = `v?([0-9|x|X|\*]+)(\.[0-9|x|X|\*]+)?(\.[0-9|x|X|\*]+)?` +
 	`(-([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?` +
@@ -194,30 +548,15 @@ func init() {
 		"~>": constraintTilde,
 		"^":  constraintCaret,
 	}
+}
 
+// constraintOpsRegex returns an alternation matching the operators.
+func constraintOpsRegex() string {
 	ops := make([]string, 0, len(constraintOps))
 	for k := range constraintOps {
 		ops = append(ops, regexp.QuoteMeta(k))
 	}
-
-	constraintRegex = regexp.MustCompile(fmt.Sprintf(
-		`^\s*(%s)\s*(%s)\s*$`,
-		strings.Join(ops, "|"),
-		cvRegex))
-
-	constraintRangeRegex = regexp.MustCompile(fmt.Sprintf(
-		`\s*(%s)\s+-\s+(%s)\s*`,
-		cvRegex, cvRegex))
-
-	findConstraintRegex = regexp.MustCompile(fmt.Sprintf(
-		`(%s)\s*(%s)`,
-		strings.Join(ops, "|"),
-		cvRegex))
-
-	validConstraintRegex = regexp.MustCompile(fmt.Sprintf(
-		`^(\s*(%s)\s*(%s)\s*\,?)+$`,
-		strings.Join(ops, "|"),
-		cvRegex))
+	return strings.Join(ops, "|")
 }
 
 // An individual constraint
@@ -236,11 +575,67 @@ type constraint struct {
 	minorDirty bool
 	dirty      bool
 	patchDirty bool
+
+	// When the version is dirty because segments were left off (e.g., 1.2)
+	// rather than because an x was used
+	omitted bool
+
+	// The options the constraint is checked with when they differ from
+	// those of the constraints holding it, as happens when Union or
+	// Intersect combine constraints created with different options. It is
+	// nil otherwise.
+	opts *MatchOptions
+
+	// The AND group the constraint was joined from by Intersect when it is
+	// checked with PrereleaseScoped. Clauses from different groups are
+	// checked apart so each group still only admits the prereleases it
+	// names. It is 0 otherwise.
+	scope int
 }
 
 // Check if a version meets the constraint
-func (c *constraint) check(v *Version) (bool, error) {
-	return constraintOps[c.origfunc](v, c)
+func (c *constraint) check(v *Version, o *MatchOptions) (bool, error) {
+	ok, reason := c.matches(v, o)
+	if reason == reasonNone {
+		return ok, nil
+	}
+	return ok, &checkError{reason: reason, v: v, orig: c.orig}
+}
+
+// matches reports if a version meets the constraint along with the reason it
+// does not. Unlike check it does not allocate an error for a version that
+// fails, so Check does not pay for the reasons it discards.
+func (c *constraint) matches(v *Version, o *MatchOptions) (bool, checkReason) {
+	c = c.resolve(o)
+	return constraintOps[c.origfunc](v, c, *o)
+}
+
+// resolve returns the constraint as it should be interpreted with the options.
+func (c *constraint) resolve(o *MatchOptions) *constraint {
+	if o.FoldPrereleaseCase {
+		if f := foldPrerelease(c.con); f != c.con {
+			z := *c
+			z.con = f
+			c = &z
+		}
+	}
+
+	if o.Partial == PartialZero && c.omitted && c.origfunc != "^" &&
+		c.origfunc != "~" && c.origfunc != "~>" {
+
+		// Treat the left off segments as 0 instead of as a wildcard.
+		z := *c
+		z.dirty, z.minorDirty, z.patchDirty = false, false, false
+		return &z
+	}
+	return c
+}
+
+// skipPrerelease reports if v is a prerelease the constraint should not
+// consider. By default prereleases are only considered when the constraint
+// names one.
+func (c *constraint) skipPrerelease(v *Version, o *MatchOptions) bool {
+	return o.Prerelease == PrereleaseExplicit && v.Prerelease() != "" && c.con.Prerelease() == ""
 }
 
 // String prints an individual constraint into a string
@@ -248,7 +643,9 @@ func (c *constraint) string() string {
 	return c.origfunc + c.orig
 }
 
-type cfunc func(v *Version, c *constraint) (bool, error)
+// cfunc checks a version against a constraint. The options are passed by
+// value so checking does not move them to the heap.
+type cfunc func(v *Version, c *constraint, o MatchOptions) (bool, checkReason)
 
 func parseConstraint(c string) (*constraint, error) {
 	if len(c) > 0 {
@@ -279,8 +676,11 @@ func parseConstraint(c string) (*constraint, error) {
 			ver = fmt.Sprintf("%s%s.0%s", m[3], m[4], m[6])
 		}
 
-		con, err := NewVersion(ver)
+		con, err := newVersion(ver)
 		if err != nil {
+			if _, ok := err.(*InputTooLargeError); ok {
+				return nil, err
+			}
 
 			// The constraintRegex should catch any regex parsing errors. So,
 			// we should never get here.
@@ -291,13 +691,15 @@ func parseConstraint(c string) (*constraint, error) {
 		cs.minorDirty = minorDirty
 		cs.patchDirty = patchDirty
 		cs.dirty = dirty
+		cs.omitted = dirty && !isX(m[3]) && !isX(strings.TrimPrefix(m[4], ".")) &&
+			!isX(strings.TrimPrefix(m[5], "."))
 
 		return cs, nil
 	}
 
 	// The rest is the special case where an empty string was passed in which
 	// is equivalent to * or >=0.0.0
-	con, err := StrictNewVersion("0.0.0")
+	con, err := strictNewVersion("0.0.0")
 	if err != nil {
 
 		// The constraintRegex should catch any regex parsing errors. So,
@@ -316,149 +718,203 @@ func parseConstraint(c string) (*constraint, error) {
 	return cs, nil
 }
 
+// checkReason is the reason a version fails a constraint. The constraint
+// functions return a reason rather than an error, and the message is only
+// formatted when checkError.Error is called.
+type checkReason uint8
+
+const (
+	reasonNone checkReason = iota
+	reasonPrerelease
+	reasonDifferentRelease
+	reasonEqual
+	reasonNotEqual
+	reasonLess
+	reasonLessOrEqual
+	reasonGreater
+	reasonGreaterOrEqual
+	reasonMajor
+	reasonMajorMinor
+	reasonMinor
+	reasonPatch
+)
+
+// reasonFormats holds the message of each reason, formatted with the version
+// and the constraint.
+var reasonFormats = [...]string{
+	reasonPrerelease:       "%s is a prerelease version and the constraint is only looking for release versions",
+	reasonDifferentRelease: "%s is a prerelease of a different release than %s",
+	reasonEqual:            "%s is equal to %s",
+	reasonNotEqual:         "%s is not equal to %s",
+	reasonLess:             "%s is less than %s",
+	reasonLessOrEqual:      "%s is less than or equal to %s",
+	reasonGreater:          "%s is greater than %s",
+	reasonGreaterOrEqual:   "%s is greater than or equal to %s",
+	reasonMajor:            "%s does not have same major version as %s",
+	reasonMajorMinor:       "%s does not have same major and minor version as %s",
+	reasonMinor:            "%s does not have same minor version as %s. Expected minor versions to match when constraint major version is 0",
+	reasonPatch:            "%s does not equal %s. Expect version and constraint to equal when major and minor versions are 0",
+}
+
+// checkError is the error for a version failing a constraint. It holds the
+// reason, the version, and the constraint, and formats the message when it
+// is asked for.
+type checkError struct {
+	reason checkReason
+	v      *Version
+	orig   string
+}
+
+func (e *checkError) Error() string {
+	if e.reason == reasonPrerelease {
+		return fmt.Sprintf(reasonFormats[e.reason], e.v)
+	}
+	return fmt.Sprintf(reasonFormats[e.reason], e.v, e.orig)
+}
+
 // Constraint functions
-func constraintNotEqual(v *Version, c *constraint) (bool, error) {
+func constraintNotEqual(v *Version, c *constraint, o MatchOptions) (bool, checkReason) {
 	if c.dirty {
 
 		// If there is a pre-release on the version but the constraint isn't looking
 		// for them assume that pre-releases are not compatible. See issue 21 for
 		// more details.
-		if v.Prerelease() != "" && c.con.Prerelease() == "" {
-			return false, fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+		if c.skipPrerelease(v, &o) {
+			return false, reasonPrerelease
 		}
 
 		if c.con.Major() != v.Major() {
-			return true, nil
+			return true, reasonNone
 		}
 		if c.con.Minor() != v.Minor() && !c.minorDirty {
-			return true, nil
+			return true, reasonNone
 		} else if c.minorDirty {
-			return false, fmt.Errorf("%s is equal to %s", v, c.orig)
+			return false, reasonEqual
 		} else if c.con.Patch() != v.Patch() && !c.patchDirty {
-			return true, nil
+			return true, reasonNone
 		} else if c.patchDirty {
 			// Need to handle prereleases if present
 			if v.Prerelease() != "" || c.con.Prerelease() != "" {
-				eq := comparePrerelease(v.Prerelease(), c.con.Prerelease()) != 0
+				eq := comparePrerelease(v.Prerelease(), c.con.Prerelease(), o.LegacyPrereleaseOrder) != 0
 				if eq {
-					return true, nil
+					return true, reasonNone
 				}
-				return false, fmt.Errorf("%s is equal to %s", v, c.orig)
+				return false, reasonEqual
 			}
-			return false, fmt.Errorf("%s is equal to %s", v, c.orig)
+			return false, reasonEqual
 		}
 	}
 
-	eq := v.Equal(c.con)
+	eq := o.equal(v, c.con)
 	if eq {
-		return false, fmt.Errorf("%s is equal to %s", v, c.orig)
+		return false, reasonEqual
 	}
 
-	return true, nil
+	return true, reasonNone
 }
 
-func constraintGreaterThan(v *Version, c *constraint) (bool, error) {
+func constraintGreaterThan(v *Version, c *constraint, o MatchOptions) (bool, checkReason) {
 
 	// If there is a pre-release on the version but the constraint isn't looking
 	// for them assume that pre-releases are not compatible. See issue 21 for
 	// more details.
-	if v.Prerelease() != "" && c.con.Prerelease() == "" {
-		return false, fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+	if c.skipPrerelease(v, &o) {
+		return false, reasonPrerelease
 	}
 
 	var eq bool
 
 	if !c.dirty {
-		eq = v.Compare(c.con) == 1
+		eq = o.compare(v, c.con) == 1
 		if eq {
-			return true, nil
+			return true, reasonNone
 		}
-		return false, fmt.Errorf("%s is less than or equal to %s", v, c.orig)
+		return false, reasonLessOrEqual
 	}
 
 	if v.Major() > c.con.Major() {
-		return true, nil
+		return true, reasonNone
 	} else if v.Major() < c.con.Major() {
-		return false, fmt.Errorf("%s is less than or equal to %s", v, c.orig)
+		return false, reasonLessOrEqual
 	} else if c.minorDirty {
 		// This is a range case such as >11. When the version is something like
 		// 11.1.0 is it not > 11. For that we would need 12 or higher
-		return false, fmt.Errorf("%s is less than or equal to %s", v, c.orig)
+		return false, reasonLessOrEqual
 	} else if c.patchDirty {
 		// This is for ranges such as >11.1. A version of 11.1.1 is not greater
 		// which one of 11.2.1 is greater
 		eq = v.Minor() > c.con.Minor()
 		if eq {
-			return true, nil
+			return true, reasonNone
 		}
-		return false, fmt.Errorf("%s is less than or equal to %s", v, c.orig)
+		return false, reasonLessOrEqual
 	}
 
 	// If we have gotten here we are not comparing pre-preleases and can use the
 	// Compare function to accomplish that.
-	eq = v.Compare(c.con) == 1
+	eq = o.compare(v, c.con) == 1
 	if eq {
-		return true, nil
+		return true, reasonNone
 	}
-	return false, fmt.Errorf("%s is less than or equal to %s", v, c.orig)
+	return false, reasonLessOrEqual
 }
 
-func constraintLessThan(v *Version, c *constraint) (bool, error) {
+func constraintLessThan(v *Version, c *constraint, o MatchOptions) (bool, checkReason) {
 	// If there is a pre-release on the version but the constraint isn't looking
 	// for them assume that pre-releases are not compatible. See issue 21 for
 	// more details.
-	if v.Prerelease() != "" && c.con.Prerelease() == "" {
-		return false, fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+	if c.skipPrerelease(v, &o) {
+		return false, reasonPrerelease
 	}
 
-	eq := v.Compare(c.con) < 0
+	eq := o.compare(v, c.con) < 0
 	if eq {
-		return true, nil
+		return true, reasonNone
 	}
-	return false, fmt.Errorf("%s is greater than or equal to %s", v, c.orig)
+	return false, reasonGreaterOrEqual
 }
 
-func constraintGreaterThanEqual(v *Version, c *constraint) (bool, error) {
+func constraintGreaterThanEqual(v *Version, c *constraint, o MatchOptions) (bool, checkReason) {
 
 	// If there is a pre-release on the version but the constraint isn't looking
 	// for them assume that pre-releases are not compatible. See issue 21 for
 	// more details.
-	if v.Prerelease() != "" && c.con.Prerelease() == "" {
-		return false, fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+	if c.skipPrerelease(v, &o) {
+		return false, reasonPrerelease
 	}
 
-	eq := v.Compare(c.con) >= 0
+	eq := o.compare(v, c.con) >= 0
 	if eq {
-		return true, nil
+		return true, reasonNone
 	}
-	return false, fmt.Errorf("%s is less than %s", v, c.orig)
+	return false, reasonLess
 }
 
-func constraintLessThanEqual(v *Version, c *constraint) (bool, error) {
+func constraintLessThanEqual(v *Version, c *constraint, o MatchOptions) (bool, checkReason) {
 	// If there is a pre-release on the version but the constraint isn't looking
 	// for them assume that pre-releases are not compatible. See issue 21 for
 	// more details.
-	if v.Prerelease() != "" && c.con.Prerelease() == "" {
-		return false, fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+	if c.skipPrerelease(v, &o) {
+		return false, reasonPrerelease
 	}
 
 	var eq bool
 
 	if !c.dirty {
-		eq = v.Compare(c.con) <= 0
+		eq = o.compare(v, c.con) <= 0
 		if eq {
-			return true, nil
+			return true, reasonNone
 		}
-		return false, fmt.Errorf("%s is greater than %s", v, c.orig)
+		return false, reasonGreater
 	}
 
 	if v.Major() > c.con.Major() {
-		return false, fmt.Errorf("%s is greater than %s", v, c.orig)
+		return false, reasonGreater
 	} else if v.Major() == c.con.Major() && v.Minor() > c.con.Minor() && !c.minorDirty {
-		return false, fmt.Errorf("%s is greater than %s", v, c.orig)
+		return false, reasonGreater
 	}
 
-	return true, nil
+	return true, reasonNone
 }
 
 // ~*, ~>* --> >= 0.0.0 (any)
@@ -467,56 +923,72 @@ func constraintLessThanEqual(v *Version, c *constraint) (bool, error) {
 // ~1.2, ~1.2.x, ~>1.2, ~>1.2.x --> >=1.2.0, <1.3.0
 // ~1.2.3, ~>1.2.3 --> >=1.2.3, <1.3.0
 // ~1.2.0, ~>1.2.0 --> >=1.2.0, <1.3.0
-func constraintTilde(v *Version, c *constraint) (bool, error) {
+//
+// How ~> widens for a version like 1.2 depends on MatchOptions.Pessimistic.
+// Which prereleases a tilde with a prerelease (e.g., ~1.2.3-beta.2) admits
+// depends on MatchOptions.Tilde. By default any prerelease in the range is.
+func constraintTilde(v *Version, c *constraint, o MatchOptions) (bool, checkReason) {
 	// If there is a pre-release on the version but the constraint isn't looking
 	// for them assume that pre-releases are not compatible. See issue 21 for
 	// more details.
-	if v.Prerelease() != "" && c.con.Prerelease() == "" {
-		return false, fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+	if c.skipPrerelease(v, &o) {
+		return false, reasonPrerelease
+	}
+
+	// node-semver only admits prereleases of the release the tilde names.
+	if o.Tilde == TildePrereleaseSameRelease && o.Prerelease == PrereleaseExplicit &&
+		v.Prerelease() != "" && !sameRelease(v, c.con) {
+		return false, reasonDifferentRelease
 	}
 
-	if v.LessThan(c.con) {
-		return false, fmt.Errorf("%s is less than %s", v, c.orig)
+	if o.compare(v, c.con) < 0 {
+		return false, reasonLess
 	}
 
 	// ~0.0.0 is a special case where all constraints are accepted. It's
 	// equivalent to >= 0.0.0.
 	if c.con.Major() == 0 && c.con.Minor() == 0 && c.con.Patch() == 0 &&
 		!c.minorDirty && !c.patchDirty {
-		return true, nil
+		return true, reasonNone
 	}
 
 	if v.Major() != c.con.Major() {
-		return false, fmt.Errorf("%s does not have same major version as %s", v, c.orig)
+		return false, reasonMajor
+	}
+
+	// RubyGems drops the last segment written so ~>1.2 only fixes the major.
+	minorDirty := c.minorDirty
+	if o.Pessimistic == PessimisticRubyGems && c.origfunc == "~>" && c.patchDirty {
+		minorDirty = true
 	}
 
-	if v.Minor() != c.con.Minor() && !c.minorDirty {
-		return false, fmt.Errorf("%s does not have same major and minor version as %s", v, c.orig)
+	if v.Minor() != c.con.Minor() && !minorDirty {
+		return false, reasonMajorMinor
 	}
 
-	return true, nil
+	return true, reasonNone
 }
 
 // When there is a .x (dirty) status it automatically opts in to ~. Otherwise
 // it's a straight =
-func constraintTildeOrEqual(v *Version, c *constraint) (bool, error) {
+func constraintTildeOrEqual(v *Version, c *constraint, o MatchOptions) (bool, checkReason) {
 	// If there is a pre-release on the version but the constraint isn't looking
 	// for them assume that pre-releases are not compatible. See issue 21 for
 	// more details.
-	if v.Prerelease() != "" && c.con.Prerelease() == "" {
-		return false, fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+	if c.skipPrerelease(v, &o) {
+		return false, reasonPrerelease
 	}
 
 	if c.dirty {
-		return constraintTilde(v, c)
+		return constraintTilde(v, c, o)
 	}
 
-	eq := v.Equal(c.con)
+	eq := o.equal(v, c.con)
 	if eq {
-		return true, nil
+		return true, reasonNone
 	}
 
-	return false, fmt.Errorf("%s is not equal to %s", v, c.orig)
+	return false, reasonNotEqual
 }
 
 // ^*      -->  (any)
@@ -528,54 +1000,57 @@ func constraintTildeOrEqual(v *Version, c *constraint) (bool, error) {
 // ^0.0.3  -->  >=0.0.3 <0.0.4
 // ^0.0    -->  >=0.0.0 <0.1.0
 // ^0      -->  >=0.0.0 <1.0.0
-func constraintCaret(v *Version, c *constraint) (bool, error) {
+//
+// The expansion of 0.y.z versions depends on MatchOptions.Caret. The table
+// above is for the default of CaretLeftmostNonZero.
+func constraintCaret(v *Version, c *constraint, o MatchOptions) (bool, checkReason) {
 	// If there is a pre-release on the version but the constraint isn't looking
 	// for them assume that pre-releases are not compatible. See issue 21 for
 	// more details.
-	if v.Prerelease() != "" && c.con.Prerelease() == "" {
-		return false, fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+	if c.skipPrerelease(v, &o) {
+		return false, reasonPrerelease
 	}
 
 	// This less than handles prereleases
-	if v.LessThan(c.con) {
-		return false, fmt.Errorf("%s is less than %s", v, c.orig)
+	if o.compare(v, c.con) < 0 {
+		return false, reasonLess
 	}
 
 	var eq bool
 
 	// ^ when the major > 0 is >=x.y.z < x+1
-	if c.con.Major() > 0 || c.minorDirty {
+	if c.con.Major() > 0 || c.minorDirty || o.Caret == CaretMajor {
 
 		// ^ has to be within a major range for > 0. Everything less than was
 		// filtered out with the LessThan call above. This filters out those
 		// that greater but not within the same major range.
 		eq = v.Major() == c.con.Major()
 		if eq {
-			return true, nil
+			return true, reasonNone
 		}
-		return false, fmt.Errorf("%s does not have same major version as %s", v, c.orig)
+		return false, reasonMajor
 	}
 
 	// ^ when the major is 0 and minor > 0 is >=0.y.z < 0.y+1
 	if c.con.Major() == 0 && v.Major() > 0 {
-		return false, fmt.Errorf("%s does not have same major version as %s", v, c.orig)
+		return false, reasonMajor
 	}
 	// If the con Minor is > 0 it is not dirty
-	if c.con.Minor() > 0 || c.patchDirty {
+	if c.con.Minor() > 0 || c.patchDirty || o.Caret == CaretMinor {
 		eq = v.Minor() == c.con.Minor()
 		if eq {
-			return true, nil
+			return true, reasonNone
 		}
-		return false, fmt.Errorf("%s does not have same minor version as %s. Expected minor versions to match when constraint major version is 0", v, c.orig)
+		return false, reasonMinor
 	}
 
 	// At this point the major is 0 and the minor is 0 and not dirty. The patch
 	// is not dirty so we need to check if they are equal. If they are not equal
-	eq = c.con.Patch() == v.Patch()
+	eq = v.Minor() == 0 && c.con.Patch() == v.Patch()
 	if eq {
-		return true, nil
+		return true, reasonNone
 	}
-	return false, fmt.Errorf("%s does not equal %s. Expect version and constraint to equal when major and minor versions are 0", v, c.orig)
+	return false, reasonPatch
 }
 
 func isX(x string) bool {
@@ -587,16 +1062,124 @@ func isX(x string) bool {
 	}
 }
 
+// rewriteRange rewrites the hyphen ranges in a constraint, such as 1.2 - 1.4,
+// into >= 1.2, <= 1.4. A range is a version, whitespace, a hyphen,
+// whitespace, and a version, so the hyphens of prereleases such as
+// 1.2.0-beta.1 are left alone. The input is scanned once, with each hyphen
+// only looking at the versions on either side of it, so the time taken grows
+// linearly with the input however many hyphens it holds.
 func rewriteRange(i string) string {
-	m := constraintRangeRegex.FindAllStringSubmatch(i, -1)
-	if m == nil {
+	var b strings.Builder
+
+	// last is the end of the input written to b.
+	last := 0
+	for k := 0; k < len(i); k++ {
+		if i[k] != '-' || k == last || k+1 == len(i) || !isSpace(i[k-1]) || !isSpace(i[k+1]) {
+			continue
+		}
+
+		// The lower version ends at the whitespace before the hyphen and the
+		// upper version starts after the whitespace following it.
+		le := k - 1
+		for le > last && isSpace(i[le-1]) {
+			le--
+		}
+		ls := le
+		for ls > last && isRangeChar(i[ls-1]) {
+			ls--
+		}
+		us := k + 1
+		for us < len(i) && isSpace(i[us]) {
+			us++
+		}
+		ue := us
+		for ue < len(i) && isRangeChar(i[ue]) {
+			ue++
+		}
+		if !isRangeVersion(i[ls:le]) || !isRangeVersion(i[us:ue]) {
+			continue
+		}
+
+		// The whitespace around the range is dropped.
+		start := ls
+		for start > last && isSpace(i[start-1]) {
+			start--
+		}
+		end := ue
+		for end < len(i) && isSpace(i[end]) {
+			end++
+		}
+
+		b.WriteString(i[last:start])
+		b.WriteString(">= ")
+		b.WriteString(i[ls:le])
+		b.WriteString(", <= ")
+		b.WriteString(i[us:ue])
+		last = end
+		k = end - 1
+	}
+
+	if last == 0 {
 		return i
 	}
-	o := i
-	for _, v := range m {
-		t := fmt.Sprintf(">= %s, <= %s", v[1], v[11])
-		o = strings.Replace(o, v[0], t, 1)
+	b.WriteString(i[last:])
+	return b.String()
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\f', '\r':
+		return true
 	}
+	return false
+}
+
+// isRangeChar reports if c can be part of a version in a range.
+func isRangeChar(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+		c == '.' || c == '-' || c == '+' || c == '*'
+}
 
-	return o
+// isRangeVersion reports if s is a version as written in a constraint, with
+// up to three segments each of which may be a wildcard, and an optional
+// prerelease and metadata.
+func isRangeVersion(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == 'v' {
+		i++
+	}
+	for k := 0; k < 3; k++ {
+		if k > 0 {
+			if i == len(s) || s[i] != '.' {
+				break
+			}
+			i++
+		}
+		j := i
+		for j < len(s) && (s[j] >= '0' && s[j] <= '9' || isX(s[j:j+1])) {
+			j++
+		}
+		if j == i {
+			return false
+		}
+		i = j
+	}
+
+	if i < len(s) && s[i] == '-' {
+		j := i + 1
+		for j < len(s) && s[j] != '+' {
+			j++
+		}
+		if !validIdentifiers(s[i+1 : j]) {
+			return false
+		}
+		i = j
+	}
+	if i < len(s) && s[i] == '+' {
+		if !validIdentifiers(s[i+1:]) {
+			return false
+		}
+		i = len(s)
+	}
+	return i == len(s)
 }
@@ -2,7 +2,9 @@ package semver
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseConstraint(t *testing.T) {
@@ -169,6 +171,8 @@ func TestConstraintCheck(t *testing.T) {
 		{"^0.2", "0.5.6", false},
 		{"^0.0.3", "0.0.3", true},
 		{"^0.0.3", "0.0.4", false},
+		{"^0.0.3", "0.1.3", false},
+		{"^0.0.3", "1.0.3", false},
 		{"^0.0", "0.0.3", true},
 		{"^0.0", "0.1.4", false},
 		{"^0.0", "1.0.4", false},
@@ -198,7 +202,7 @@ func TestConstraintCheck(t *testing.T) {
 			continue
 		}
 
-		a, _ := c.check(v)
+		a, _ := c.check(v, &MatchOptions{})
 		if a != tc.check {
 			t.Errorf("Constraint %q failing with %q", tc.constraint, tc.version)
 		}
@@ -401,6 +405,41 @@ func TestConstraintsCheck(t *testing.T) {
 	}
 }
 
+func TestConstraintsCheckAllocs(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+	}{
+		{"^1.2.3", "2.0.0"},
+		{"~1.2.3", "1.3.0"},
+		{">=1.2, <1.5 || >2.0", "1.7.0"},
+		{"!=1.2.x", "1.2.4"},
+		{"1.2.3", "1.2.3-beta.1"},
+		{"^0.0.3", "0.0.4"},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Check(v) {
+			t.Errorf("Expected %s not to satisfy %s", tc.version, tc.constraint)
+		}
+		allocs := testing.AllocsPerRun(100, func() {
+			c.Check(v)
+		})
+		if allocs != 0 {
+			t.Errorf("Expected checking %s against %s not to allocate but got %v allocations", tc.version, tc.constraint, allocs)
+		}
+	}
+}
+
 func TestRewriteRange(t *testing.T) {
 	tests := []struct {
 		c  string
@@ -409,6 +448,21 @@ func TestRewriteRange(t *testing.T) {
 		{"2 - 3", ">= 2, <= 3"},
 		{"2 - 3, 2 - 3", ">= 2, <= 3,>= 2, <= 3"},
 		{"2 - 3, 4.0.0 - 5.1", ">= 2, <= 3,>= 4.0.0, <= 5.1"},
+		{"1.2 - 1.4 || 2.x", ">= 1.2, <= 1.4|| 2.x"},
+		{"1.2.3-beta.1 - 2.0.0-rc-1+build-5", ">= 1.2.3-beta.1, <= 2.0.0-rc-1+build-5"},
+		{"1.x - 2.*", ">= 1.x, <= 2.*"},
+		{"\t1\t-\t2\t", ">= 1, <= 2"},
+		{"1 - 2 - 3", ">= 1, <= 2- 3"},
+		{">=1.2 - 1.4", ">=>= 1.2, <= 1.4"},
+		{"1.2.3-beta", "1.2.3-beta"},
+		{"1.2.3-beta-2 -2", "1.2.3-beta-2 -2"},
+		{"1 -- 2", "1 -- 2"},
+		{"- 1", "- 1"},
+		{"1 -", "1 -"},
+		{"1 - ", "1 - "},
+		{"1 - foo", "1 - foo"},
+		{"1.2.3.4 - 2", "1.2.3.4 - 2"},
+		{"1.2.3- - 2", "1.2.3- - 2"},
 	}
 
 	for _, tc := range tests {
@@ -420,6 +474,30 @@ func TestRewriteRange(t *testing.T) {
 	}
 }
 
+func TestRewriteRangeLinear(t *testing.T) {
+	tests := []string{
+		strings.Repeat("- ", 100000),
+		strings.Repeat("1 - ", 100000),
+		strings.Repeat("1-a-b-c ", 100000) + "-",
+		"1.2.3-" + strings.Repeat("-", 200000) + " - 2",
+		strings.Repeat(" ", 200000) + "-" + strings.Repeat(" ", 200000),
+	}
+
+	for _, tc := range tests {
+		start := time.Now()
+		rewriteRange(tc)
+		if d := time.Since(start); d > time.Second {
+			t.Errorf("Expected a %d byte range to be rewritten quickly but it took %s", len(tc), d)
+		}
+	}
+
+	in := strings.Repeat("1.0.0-a-b - 2.0.0-c-d, ", 1000)
+	out := rewriteRange(in)
+	if n := strings.Count(out, ">= 1.0.0-a-b, <= 2.0.0-c-d"); n != 1000 {
+		t.Errorf("Expected 1000 ranges to be rewritten but got %d", n)
+	}
+}
+
 func TestIsX(t *testing.T) {
 	tests := []struct {
 		t string
@@ -459,6 +537,8 @@ func TestConstraintsValidate(t *testing.T) {
 		{"!=4.x", "4.1.0", false},
 		{"!=4.1.x", "4.2.0", true},
 		{"!=4.2.x", "4.2.3", false},
+		{"!=4.2.3", "4.2.4-beta", true},
+		{"!=0.0.0", "0.0.0-0", true},
 		{">1.1", "4.1.0", true},
 		{">1.1", "1.1.0", false},
 		{"<1.1", "0.1.0", true},
@@ -646,6 +726,12 @@ func TestConstraintString(t *testing.T) {
 		{"2.x,   >=1.2.3 || >4.5.6, < 5.7", "2.x >=1.2.3 || >4.5.6 <5.7"},
 		{"2.x,   >=1.2.3 || >4.5.6, < 5.7 || >40.50.60, < 50.70", "2.x >=1.2.3 || >4.5.6 <5.7 || >40.50.60 <50.70"},
 		{"1.2", "1.2"},
+		{"^2 || ^1", "^1 || ^2"},
+		{">4.5.6, < 5.7 || 2.x, >=1.2.3", "2.x >=1.2.3 || >4.5.6 <5.7"},
+		{">2, <1 || ^1", "^1 || >2 <1"},
+		{"=1.2.3 || 1.2.3", "1.2.3 || =1.2.3"},
+		{">=1.2.3 || >=1.2.3-beta", ">=1.2.3-beta || >=1.2.3"},
+		{"<3 || *", "* || <3"},
 	}
 
 	for _, tc := range tests {
@@ -664,3 +750,253 @@ func TestConstraintString(t *testing.T) {
 		}
 	}
 }
+
+func TestDisjuncts(t *testing.T) {
+	tests := []struct {
+		constraint string
+		disjuncts  []string
+	}{
+		{"^1.2", []string{"^1.2"}},
+		{">=1.2, <2 || 3.x || !=4.0.0", []string{">=1.2 <2", "3.x", "!=4.0.0"}},
+		{"*", []string{"*"}},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.constraint, err)
+		}
+
+		ds := Disjuncts(c)
+		if len(ds) != len(tc.disjuncts) {
+			t.Fatalf("expected %q to have %d disjuncts but got %d", tc.constraint, len(tc.disjuncts), len(ds))
+		}
+		for k, d := range ds {
+			if d.String() != tc.disjuncts[k] {
+				t.Errorf("expected disjunct %d of %q to be %q but got %q", k, tc.constraint, tc.disjuncts[k], d)
+			}
+		}
+	}
+
+	o := MatchOptions{Prerelease: PrereleaseInclude}
+	c, err := NewConstraintWithOptions("^1.2 || ^2", o)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, d := range Disjuncts(c) {
+		if d.Options() != o {
+			t.Errorf("expected %q to keep the options %+v but got %+v", d, o, d.Options())
+		}
+	}
+}
+
+func TestNewConstraintWithOptions(t *testing.T) {
+	o := MatchOptions{Prerelease: PrereleaseInclude, Caret: CaretMajor}
+	c, err := NewConstraintWithOptions("^0.2.3", o)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if c.Options() != o {
+		t.Errorf("expected options %+v but got %+v", o, c.Options())
+	}
+
+	v := MustParse("0.5.0-beta.1")
+	if !c.Check(v) {
+		t.Errorf("expected %q to check %q with the options it was created with", c, v)
+	}
+	if ok, msgs := c.Validate(v); !ok {
+		t.Errorf("expected %q to validate %q with the options it was created with: %v", c, v, msgs)
+	}
+	if c.CheckWithOptions(v, MatchOptions{}) {
+		t.Errorf("expected %q not to check %q with the default options", c, v)
+	}
+
+	if _, err := NewConstraintWithOptions("foo", o); err == nil {
+		t.Error("expected an error parsing an invalid constraint")
+	}
+}
+
+func TestDegenerateConstraints(t *testing.T) {
+	tests := []struct {
+		input string
+
+		// lenient is the rendering of the constraints NewConstraint collapses
+		// the input to or empty when it is an error.
+		lenient string
+
+		// strict is the error StrictNewConstraint returns or empty when it
+		// parses the input.
+		strict string
+	}{
+		{"^1 || ^2", "^1 || ^2", ""},
+		{"^1, <1.5", "^1 <1.5", ""},
+		{"||", "", "|| has an empty || branch"},
+		{" || ", "", " ||  has an empty || branch"},
+		{"|| ||", "", "|| || has an empty || branch"},
+		{"^1 ||", "^1", "^1 || has an empty || branch"},
+		{"|| ^1", "^1", "|| ^1 has an empty || branch"},
+		{"^1 || || ^2", "^1 || ^2", "^1 || || ^2 has an empty || branch"},
+		{"^1 ||  ||", "^1", "^1 ||  || has an empty || branch"},
+		{"^1||||^2", "^1 || ^2", "^1||||^2 has an empty || branch"},
+		{",", "", ", has a leading comma"},
+		{",,", "", ",, has a leading comma"},
+		{" , ", "", " ,  has a leading comma"},
+		{"^1,", "^1", "^1, has a trailing comma"},
+		{"^1 ,", "^1", "^1 , has a trailing comma"},
+		{",^1", "^1", ",^1 has a leading comma"},
+		{"^1,,<1.5", "^1 <1.5", "^1,,<1.5 has an empty clause between commas"},
+		{"^1, ,<1.5", "^1 <1.5", "^1, ,<1.5 has an empty clause between commas"},
+		{"^1,,,<1.5", "^1 <1.5", "^1,,,<1.5 has an empty clause between commas"},
+		{"^1, || ^2", "^1 || ^2", "^1, || ^2 has a trailing comma"},
+		{"^1 || ,^2", "^1 || ^2", "^1 || ,^2 has a leading comma"},
+		{"^1 || , || ^2", "^1 || ^2", "^1 || , || ^2 has a leading comma"},
+		{", || ,", "", ", || , has a leading comma"},
+
+		// Blank input and a single | are rejected by the parser in either
+		// mode.
+		{"", "", "improper constraint: "},
+		{"   ", "", "improper constraint:    "},
+		{"^1 | ^2", "", "constraint Parser Error"},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.input)
+		switch {
+		case tc.lenient == "" && err == nil:
+			t.Errorf("expected %q to be an error but got %q", tc.input, c)
+		case tc.lenient != "" && err != nil:
+			t.Errorf("unexpected error for %q: %s", tc.input, err)
+		case err == nil && c.String() != tc.lenient:
+			t.Errorf("expected %q to collapse to %q but got %q", tc.input, tc.lenient, c)
+		}
+
+		c, err = StrictNewConstraint(tc.input)
+		switch {
+		case tc.strict == "" && err != nil:
+			t.Errorf("unexpected strict error for %q: %s", tc.input, err)
+		case tc.strict != "" && err == nil:
+			t.Errorf("expected strict error %q for %q but got %q", tc.strict, tc.input, c)
+		case err != nil && !strings.HasPrefix(err.Error(), tc.strict):
+			t.Errorf("expected strict error %q for %q but got %q", tc.strict, tc.input, err)
+		}
+	}
+}
+
+func TestStrictNewConstraintWithOptions(t *testing.T) {
+	o := MatchOptions{Prerelease: PrereleaseInclude}
+	c, err := StrictNewConstraintWithOptions("^1.2", o)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if c.Options() != o {
+		t.Errorf("expected options %+v but got %+v", o, c.Options())
+	}
+	if _, err := StrictNewConstraintWithOptions("^1.2,", o); err == nil {
+		t.Error("expected an error parsing a trailing comma")
+	}
+}
+
+func TestConstraintsNilVersion(t *testing.T) {
+	c := mustConstraint(t, "*")
+
+	if c.Check(nil) {
+		t.Error("Expected a nil version not to satisfy *")
+	}
+	if c.CheckWithOptions(nil, MatchOptions{Prerelease: PrereleaseInclude}) {
+		t.Error("Expected a nil version not to satisfy * with options")
+	}
+	ok, errs := c.Validate(nil)
+	if ok || len(errs) != 1 || errs[0] != ErrNilVersion {
+		t.Errorf("Expected a nil version to fail validation with ErrNilVersion but got %t %v", ok, errs)
+	}
+	if s := mustConstraint(t, "1.2.3").Status(nil); s != BAD {
+		t.Errorf("Expected a nil version to have a BAD status but got %d", s)
+	}
+}
+
+func TestConstraintsMatches(t *testing.T) {
+	kinds := []string{
+		"1.2.3", "=1.2.3", "!=1.2.3", ">1.2", "<1.2", ">=1.2", "<=1.2",
+		"~1.2", "~>1.2", "^1.2", "1.x", "*", "1.2 - 1.4", ">=1.2, <2 || 3.x",
+	}
+	for _, k := range kinds {
+		c := mustConstraint(t, k)
+
+		ok, err := c.Matches(nil)
+		if ok || err == nil {
+			t.Errorf("Expected %q not to match nil and return an error", k)
+			continue
+		}
+		me, ok := err.(*MatchError)
+		if !ok || me.Err != ErrNilVersion || me.Constraint != c.String() {
+			t.Errorf("Expected a MatchError wrapping ErrNilVersion for %q but got %#v", k, err)
+		}
+		if c.Check(nil) {
+			t.Errorf("Expected %q not to check nil", k)
+		}
+		if ok, errs := c.Validate(nil); ok || len(errs) != 1 || errs[0] != ErrNilVersion {
+			t.Errorf("Expected %q not to validate nil but got %t %v", k, ok, errs)
+		}
+
+		v := MustParse("1.2.3")
+		ok, err = c.Matches(v)
+		if err != nil || ok != c.Check(v) {
+			t.Errorf("Expected %q to match %s the same way as Check but got %t %v", k, v, ok, err)
+		}
+	}
+
+	_, err := mustConstraint(t, "^1.2").Matches(nil)
+	if e := "unable to match ^1.2: Version is nil"; err.Error() != e {
+		t.Errorf("Expected the error %q but got %q", e, err)
+	}
+}
+
+// isNilVersionError reports if err is a *MatchError for a nil version.
+func isNilVersionError(err error) bool {
+	me, ok := err.(*MatchError)
+	return ok && me.Err == ErrNilVersion
+}
+
+func TestNilConstraintsFunctions(t *testing.T) {
+	v := MustParse("1.2.3")
+	tests := map[string]func(c *Constraints) bool{
+		"IsAny":               func(c *Constraints) bool { return !IsAny(c) },
+		"IsNone":              func(c *Constraints) bool { return IsNone(c) },
+		"SpansBreakingChange": func(c *Constraints) bool { return !SpansBreakingChange(c) },
+		"Disjuncts":           func(c *Constraints) bool { return len(Disjuncts(c)) == 0 },
+		"DebugString":         func(c *Constraints) bool { return DebugString(c) != "" },
+		"Describe":            func(c *Constraints) bool { return Describe(c) == "no version" },
+		"Explain":             func(c *Constraints) bool { return !Explain(c, v).Matched },
+		"Fingerprint":         func(c *Constraints) bool { return Fingerprint(c) == Fingerprint(None()) },
+		"Lint":                func(c *Constraints) bool { return len(Lint(c)) == 0 },
+		"MatchesRange":        func(c *Constraints) bool { return !MatchesRange(c, v, v, true) },
+		"Matching":            func(c *Constraints) bool { return !Matching(c)(v) },
+		"MatchPlatform":       func(c *Constraints) bool { return len(MatchPlatform(c, []*Version{v}, Platform{})) == 0 },
+		"NewEvaluator":        func(c *Constraints) bool { return !NewEvaluator(c, 0).Check(v) },
+		"Union":               func(c *Constraints) bool { return !Union(c, nil).Check(v) },
+		"Intersect":           func(c *Constraints) bool { return !Intersect(c, Any()).Check(v) },
+		"And":                 func(c *Constraints) bool { return !c.And(Any()).Check(v) },
+		"Or":                  func(c *Constraints) bool { return c.Or(Any()).Check(v) },
+		"DiffConstraints": func(c *Constraints) bool {
+			added, removed := DiffConstraints(c, nil)
+			return IsNone(added) && IsNone(removed)
+		},
+		"SplitAt": func(c *Constraints) bool {
+			below, above := SplitAt(c, v)
+			return !below.Check(v) && !above.Check(v)
+		},
+	}
+	for name, f := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("%s panicked on nil constraints: %v", name, r)
+				}
+			}()
+			if !f(nil) {
+				t.Errorf("expected %s to treat nil constraints as admitting no versions", name)
+			}
+		}()
+	}
+}
@@ -0,0 +1,72 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// DebugString dumps the internal structure of constraints for use in tests
+// and debugging. Unlike String, which renders the constraints as written, it
+// shows how each clause was parsed and the intervals of releases and
+// prereleases admitted by each clause, each || branch, and the constraints as
+// a whole. Exclusions show as gaps between intervals. Branches and clauses
+// are listed in the order they were written.
+//
+// The output is deterministic so it can be compared against a snapshot, but
+// its format is not stable across releases of this package. Nil constraints
+// are dumped as constraints with no branches.
+func DebugString(c *Constraints) string {
+	c = orNone(c, nil)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "options: %+v\n", c.opts)
+	for k, group := range c.constraints {
+		fmt.Fprintf(&buf, "branch %d:\n", k)
+		for kk, cc := range group {
+			fmt.Fprintf(&buf, "  clause %d: op=%q version=%q orig=%q dirty=%t minorDirty=%t patchDirty=%t omitted=%t\n",
+				kk, cc.origfunc, cc.con.String(), cc.orig, cc.dirty, cc.minorDirty, cc.patchDirty, cc.omitted)
+			if cc.opts != nil {
+				fmt.Fprintf(&buf, "    options: %+v\n", *cc.opts)
+			}
+			writeDebugSet(&buf, "    ", cc.set(&c.opts))
+		}
+		may, _ := andSets(group, &c.opts)
+		writeDebugSet(&buf, "  ", may)
+	}
+	buf.WriteString("set:\n")
+	may, _ := c.sets()
+	writeDebugSet(&buf, "  ", may)
+	return buf.String()
+}
+
+func writeDebugSet(buf *bytes.Buffer, indent string, s versionSet) {
+	fmt.Fprintf(buf, "%sreleases: %s\n", indent, debugIntervals(s.releases))
+	fmt.Fprintf(buf, "%sprereleases: %s\n", indent, debugIntervals(s.prereleases))
+}
+
+// debugIntervals renders intervals in interval notation where a square
+// bracket is an inclusive bound and a parenthesis an exclusive one.
+func debugIntervals(in []Interval) string {
+	if len(in) == 0 {
+		return "none"
+	}
+
+	s := make([]string, len(in))
+	for k, i := range in {
+		min, max := "(-inf", "+inf)"
+		if i.min.v != nil {
+			min = "(" + i.min.v.String()
+			if i.min.inclusive {
+				min = "[" + i.min.v.String()
+			}
+		}
+		if i.max.v != nil {
+			max = i.max.v.String() + ")"
+			if i.max.inclusive {
+				max = i.max.v.String() + "]"
+			}
+		}
+		s[k] = min + ", " + max
+	}
+	return strings.Join(s, " ")
+}
@@ -0,0 +1,40 @@
+package semver
+
+import "testing"
+
+func TestDebugString(t *testing.T) {
+	c, err := NewConstraint(">=1.2, !=1.4.2 || 3.x-beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `options: {Prerelease:0 Metadata:0 Caret:0 Tilde:0 Partial:0 Pessimistic:0 FoldPrereleaseCase:false LegacyPrereleaseOrder:false}
+branch 0:
+  clause 0: op=">=" version="1.2.0" orig="1.2" dirty=true minorDirty=false patchDirty=true omitted=true
+    releases: [1.2.0, +inf)
+    prereleases: none
+  clause 1: op="!=" version="1.4.2" orig="1.4.2" dirty=false minorDirty=false patchDirty=false omitted=false
+    releases: (-inf, 1.4.2) [1.4.3, +inf)
+    prereleases: (-inf, 1.4.2) (1.4.2, +inf)
+  releases: [1.2.0, 1.4.2) [1.4.3, +inf)
+  prereleases: none
+branch 1:
+  clause 0: op="" version="3.0.0-beta" orig="3.x-beta" dirty=true minorDirty=true patchDirty=false omitted=false
+    releases: [3.0.0, 4.0.0)
+    prereleases: [3.0.0-beta, 4.0.0-0)
+  releases: [3.0.0, 4.0.0)
+  prereleases: [3.0.0-beta, 4.0.0-0)
+set:
+  releases: [1.2.0, 1.4.2) [1.4.3, +inf)
+  prereleases: [3.0.0-beta, 4.0.0-0)
+`
+	if got := DebugString(c); got != expected {
+		t.Errorf("expected debug string\n%s\nbut got\n%s", expected, got)
+	}
+
+	// The same constraints give the same output.
+	d, _ := NewConstraint(">=1.2, !=1.4.2 || 3.x-beta")
+	if DebugString(c) != DebugString(d) {
+		t.Error("expected the debug string to be deterministic")
+	}
+}
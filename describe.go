@@ -0,0 +1,86 @@
+package semver
+
+import "strings"
+
+// Describe returns an English description of the versions the constraints
+// admit, such as "any version from 1.2.0 up to but not including 2.0.0, except
+// 1.4.2", for showing to people rather than the constraints themselves. It is
+// built from Ranges and PrereleaseRanges so equivalent constraints have the
+// same description however they are written. Nil constraints admit no
+// version.
+func Describe(c *Constraints) string {
+	c = orNone(c, nil)
+	releases := c.Ranges()
+	pres := c.PrereleaseRanges()
+	if len(releases) == 0 && len(pres) == 0 {
+		return "no version"
+	}
+
+	var parts []string
+	for _, r := range releases {
+		parts = append(parts, describeRange(r, "any version", true))
+	}
+	s := strings.Join(parts, ", or ")
+	if len(releases) == 0 {
+		s = "no release"
+	}
+	if len(pres) == 0 {
+		return s
+	}
+
+	parts = parts[:0]
+	for _, r := range pres {
+		parts = append(parts, describeRange(r, "any prerelease", false))
+	}
+	return s + "; also " + strings.Join(parts, ", or ")
+}
+
+// describeRange describes a range of the versions named by what.
+func describeRange(r *Range, what string, releases bool) string {
+	min, max := r.Min(), r.Max()
+
+	var s string
+	switch {
+	case releases && min != nil && max != nil && max.Equal(nextRelease(min.major, min.minor, min.patch)):
+		// A normalized release range holding a single release.
+		s = "only " + min.String()
+	case min != nil && max != nil && r.IncludesMin() && r.IncludesMax() && min.Equal(max):
+		s = "only " + min.String()
+	case min == nil && max == nil:
+		s = what
+	case max == nil && r.IncludesMin():
+		s = what + " from " + min.String()
+	case max == nil:
+		s = what + " after " + min.String()
+	case min == nil && r.IncludesMax():
+		s = what + " up to and including " + max.String()
+	case min == nil:
+		s = what + " before " + max.String()
+	default:
+		from := " from "
+		if !r.IncludesMin() {
+			from = " after "
+		}
+		to := " up to but not including "
+		if r.IncludesMax() {
+			to = " up to and including "
+		}
+		s = what + from + min.String() + to + max.String()
+	}
+
+	if len(r.excluded) > 0 {
+		s += ", except " + englishList(versionStrings(r.excluded))
+	}
+	return s
+}
+
+// englishList joins the items as a list in a sentence, such as "a, b, and c".
+func englishList(items []string) string {
+	switch len(items) {
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " and " + items[1]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
+}
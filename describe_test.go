@@ -0,0 +1,33 @@
+package semver
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{"^1.2, !=1.4.2", "any version from 1.2.0 up to but not including 2.0.0, except 1.4.2"},
+		{">=1.2.0, <2.0.0, !=1.4.2", "any version from 1.2.0 up to but not including 2.0.0, except 1.4.2"},
+		{"*", "any version"},
+		{">=1.2", "any version from 1.2.0"},
+		{"<2", "any version before 2.0.0"},
+		{"!=1.2.3, !=1.3.0, !=2.0.0", "any version, except 1.2.3, 1.3.0, and 2.0.0; also any prerelease"},
+		{"1.2.3", "only 1.2.3"},
+		{"~1.2 || ~1.4", "any version from 1.2.0 up to but not including 1.3.0, or any version from 1.4.0 up to but not including 1.5.0"},
+		{">2, <1", "no version"},
+		{">=1.2.3-beta", "any version from 1.2.3; also any prerelease from 1.2.3-beta"},
+		{"=1.2.3-beta", "no release; also only 1.2.3-beta"},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.constraint, err)
+		}
+
+		if got := Describe(c); got != tc.expected {
+			t.Errorf("expected %q to be described as %q but got %q", tc.constraint, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package semver
+
+// DiffConstraints compares two constraints, such as a policy before and after
+// a change, returning constraints admitting the versions after admits and
+// before does not, and those before admits and after no longer does. Each
+// side is read with the options it was created with, and the results use the
+// default options. A nil before or after admits no versions.
+//
+// Only releases are compared. The prereleases constraints admit depend on
+// which prereleases the constraints name, which cannot always be written as
+// constraints on their own, so a change only to the prereleases admitted
+// results in empty added and removed constraints. Eq tells such changes
+// apart.
+//
+// With MetadataEqual, MetadataOrdered, or LegacyPrereleaseOrder the releases
+// one side may admit are compared against those the other surely admits. The
+// results then also hold the releases named by clauses relying on them, such
+// as 1.2.3 for `=1.2.3+abc` and `=1.2.3+def` with MetadataEqual, unless both
+// sides are written with the same clauses.
+func DiffConstraints(before, after *Constraints) (added, removed *Constraints) {
+	before, after = orNone(before, nil), orNone(after, nil)
+	bmay, bmust := before.sets()
+	amay, amust := after.sets()
+	if (!exact(bmay, bmust) || !exact(amay, amust)) && before.canonicalClauses() == after.canonicalClauses() {
+		return releaseConstraint(nil), releaseConstraint(nil)
+	}
+	return releaseConstraint(subtractIntervals(amay.releases, bmust.releases)),
+		releaseConstraint(subtractIntervals(bmay.releases, amust.releases))
+}
+
+// subtractIntervals returns the versions in the merged intervals a but not in
+// the merged intervals b.
+func subtractIntervals(a, b []Interval) []Interval {
+	return intersectIntervals(a, complementIntervals(b))
+}
+
+// complementIntervals returns the versions in none of the merged intervals.
+func complementIntervals(in []Interval) []Interval {
+	var out []Interval
+	start := bound{}
+	for _, i := range in {
+		if i.min.v != nil {
+			out = append(out, Interval{min: start, max: bound{v: i.min.v, inclusive: !i.min.inclusive}})
+		}
+		if i.max.v == nil {
+			return mergeIntervals(out)
+		}
+		start = bound{v: i.max.v, inclusive: !i.max.inclusive}
+	}
+	return mergeIntervals(append(out, Interval{min: start}))
+}
+
+// releaseConstraint returns constraints admitting the releases in the merged
+// normalized release intervals.
+func releaseConstraint(in []Interval) *Constraints {
+	var branches []string
+	for _, r := range newRanges(in, true) {
+		min, max := r.interval.min.v, r.interval.max.v
+		s := r.interval.String()
+		if min != nil && max != nil && max.Equal(nextRelease(min.major, min.minor, min.patch)) {
+			s = min.String()
+		}
+		for _, v := range r.excluded {
+			s += " !=" + v.String()
+		}
+		branches = append(branches, s)
+	}
+	if len(branches) == 0 {
+		branches = []string{"<0.0.0-0"}
+	}
+
+	// The branches are parsed one at a time and joined with Union so a diff
+	// with many ranges is not refused by the Limits.
+	var out *Constraints
+	for _, b := range branches {
+		c, _ := newConstraintWithOptions(b, MatchOptions{})
+		out = Union(out, c)
+	}
+	return out
+}
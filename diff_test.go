@@ -0,0 +1,89 @@
+package semver
+
+import "testing"
+
+func TestDiffConstraints(t *testing.T) {
+	tests := []struct {
+		before, after  string
+		added, removed string
+	}{
+		{"^1.2", "^1.2", "<0.0.0-0", "<0.0.0-0"},
+		{"^1.2", ">=1.2.0 <2.0.0", "<0.0.0-0", "<0.0.0-0"},
+		{"^1.2", "^1.4", "<0.0.0-0", ">=1.2.0 <1.4.0"},
+		{"^1.2", "^1.2 || ^2", ">=2.0.0 <3.0.0", "<0.0.0-0"},
+		{"~1.2 || ~1.4", "~1.3", ">=1.3.0 <1.4.0", ">=1.2.0 <1.3.0 || >=1.4.0 <1.5.0"},
+		{"^1.2", "^1.2, !=1.4.2", "<0.0.0-0", "1.4.2"},
+		{">=1.0", "<2.0", "<1.0.0", ">=2.0.0"},
+		{"*", ">=1.2.0 <1.5.0 !=1.3.0", "<0.0.0-0", "<1.2.0 || 1.3.0 || >=1.5.0"},
+		{"1.2.0 || 1.2.2", "1.2.0 - 1.2.2", "1.2.1", "<0.0.0-0"},
+		{"<0.0.0-0", "^0.2", ">=0.2.0 <0.3.0", "<0.0.0-0"},
+	}
+
+	for _, tc := range tests {
+		added, removed := DiffConstraints(mustConstraint(t, tc.before), mustConstraint(t, tc.after))
+		if added.String() != tc.added {
+			t.Errorf("%s to %s: expected %q to be added but got %q", tc.before, tc.after, tc.added, added)
+		}
+		if removed.String() != tc.removed {
+			t.Errorf("%s to %s: expected %q to be removed but got %q", tc.before, tc.after, tc.removed, removed)
+		}
+
+		// The diff applied to before must admit the same releases as after.
+		applied := Union(mustConstraint(t, tc.before), added)
+		for _, s := range []string{"0.1.0", "0.2.5", "1.0.0", "1.2.0", "1.2.1", "1.3.0", "1.3.4", "1.4.2", "1.5.0", "2.0.0", "2.7.1"} {
+			v := MustParse(s)
+			want := mustConstraint(t, tc.after).Check(v)
+			if got := applied.Check(v) && !removed.Check(v); got != want {
+				t.Errorf("%s to %s: expected the diff to admit %s %t but got %t", tc.before, tc.after, s, want, got)
+			}
+		}
+	}
+}
+
+func TestDiffConstraintsNil(t *testing.T) {
+	c := mustConstraint(t, "^1.2")
+	added, removed := DiffConstraints(nil, c)
+	if added.String() != ">=1.2.0 <2.0.0" || removed.String() != "<0.0.0-0" {
+		t.Errorf("expected ^1.2 to be added but got %s and %s", added, removed)
+	}
+	added, removed = DiffConstraints(c, nil)
+	if added.String() != "<0.0.0-0" || removed.String() != ">=1.2.0 <2.0.0" {
+		t.Errorf("expected ^1.2 to be removed but got %s and %s", added, removed)
+	}
+}
+
+func TestDiffConstraintsOptions(t *testing.T) {
+	before, err := NewConstraintWithOptions("^0.2", MatchOptions{Caret: CaretMajor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, removed := DiffConstraints(before, mustConstraint(t, "^0.2"))
+	if added.String() != "<0.0.0-0" || removed.String() != ">=0.3.0 <1.0.0" {
+		t.Errorf("expected each side to use its own options but got %s and %s", added, removed)
+	}
+}
+
+func TestDiffConstraintsPrecedenceOptions(t *testing.T) {
+	opts := MatchOptions{Metadata: MetadataEqual}
+	before, err := NewConstraintWithOptions("=1.2.3+abc || ^2", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := NewConstraintWithOptions("=1.2.3+def || ^2", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, removed := DiffConstraints(before, after)
+	if added.String() != "1.2.3" || removed.String() != "1.2.3" {
+		t.Errorf("expected 1.2.3 to be added and removed but got %s and %s", added, removed)
+	}
+
+	same, err := NewConstraintWithOptions("^2 || =1.2.3+abc", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, removed = DiffConstraints(before, same)
+	if added.String() != "<0.0.0-0" || removed.String() != "<0.0.0-0" {
+		t.Errorf("expected no changes but got %s and %s", added, removed)
+	}
+}
@@ -0,0 +1,98 @@
+package semver
+
+import (
+	"sync"
+	"time"
+)
+
+// Evaluator checks versions against constraints and remembers the results,
+// for checking the same few versions against the same constraints many
+// times, as an admission controller does. Versions equal in their segments,
+// prerelease, and metadata share a result, so v1.2.3 and 1.2.3 are checked
+// once. It is safe for concurrent use.
+type Evaluator struct {
+	c    *Constraints
+	size int
+
+	mu      sync.RWMutex
+	results map[evalKey]bool
+}
+
+// evalKey identifies a version by what checking it depends on.
+type evalKey struct {
+	major, minor, patch uint64
+	pre, metadata       string
+}
+
+// NewEvaluator creates an evaluator for the constraints. It holds up to size
+// results, and is emptied when it is full and a version not in it is
+// checked. A size of 0 or less does not limit it. Nil constraints admit no
+// versions.
+func NewEvaluator(c *Constraints, size int) *Evaluator {
+	return &Evaluator{
+		c:       orNone(c, nil),
+		size:    size,
+		results: map[evalKey]bool{},
+	}
+}
+
+// Constraints returns the constraints the evaluator checks versions against.
+func (e *Evaluator) Constraints() *Constraints {
+	return e.c
+}
+
+// Check tests if a version satisfies the constraints, the same as
+// Constraints.Check. A nil version satisfies none.
+func (e *Evaluator) Check(v *Version) bool {
+	if v == nil {
+		return false
+	}
+	h := currentHooks()
+	if h == nil {
+		ok, _ := e.check(v)
+		return ok
+	}
+	start := time.Now()
+	ok, hit := e.check(v)
+	matchHook(h, e.c, v, ok, start, hit)
+	return ok
+}
+
+// Matches tests if a version satisfies the constraints, the same as
+// Constraints.Matches.
+func (e *Evaluator) Matches(v *Version) (bool, error) {
+	if v == nil {
+		return false, &MatchError{Constraint: e.c.String(), Err: ErrNilVersion}
+	}
+	return e.Check(v), nil
+}
+
+// check returns the result for a version along with whether it was
+// remembered.
+func (e *Evaluator) check(v *Version) (bool, bool) {
+	key := evalKey{v.major, v.minor, v.patch, v.Prerelease(), v.Metadata()}
+
+	e.mu.RLock()
+	ok, hit := e.results[key]
+	e.mu.RUnlock()
+	if hit {
+		return ok, true
+	}
+
+	ok = e.c.checkWithOptions(v, e.c.opts)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.size > 0 && len(e.results) >= e.size {
+		e.results = map[evalKey]bool{}
+	}
+	e.results[key] = ok
+	return ok, false
+}
+
+// Len returns the number of results held.
+func (e *Evaluator) Len() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return len(e.results)
+}
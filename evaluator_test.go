@@ -0,0 +1,100 @@
+package semver
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestEvaluator(t *testing.T) {
+	c := mustConstraint(t, "^1.2, !=1.4.0")
+	e := NewEvaluator(c, 0)
+	if e.Constraints() != c {
+		t.Error("expected the evaluator to keep its constraints")
+	}
+
+	versions := []string{"1.2.0", "v1.2.0", "1.4.0", "1.4.0+build", "1.5.0-rc.1", "2.0.0", "1.9.9"}
+	for round := 0; round < 3; round++ {
+		for _, s := range versions {
+			v := MustParse(s)
+			if got, want := e.Check(v), c.Check(v); got != want {
+				t.Errorf("round %d: expected %s to be %t but got %t", round, s, want, got)
+			}
+		}
+	}
+	if e.Len() != 6 {
+		t.Errorf("expected 6 results as 1.2.0 and v1.2.0 share one but got %d", e.Len())
+	}
+
+	if ok, err := e.Matches(nil); ok || !isNilVersionError(err) {
+		t.Errorf("expected ErrNilVersion but got %t %v", ok, err)
+	}
+	if e.Check(nil) {
+		t.Error("expected a nil version to satisfy nothing")
+	}
+	if ok, err := e.Matches(MustParse("1.3.0")); !ok || err != nil {
+		t.Errorf("expected 1.3.0 to match but got %t %v", ok, err)
+	}
+
+	if NewEvaluator(nil, 0).Check(MustParse("1.0.0")) {
+		t.Error("expected nil constraints to admit nothing")
+	}
+}
+
+func TestEvaluatorOptions(t *testing.T) {
+	c, err := NewConstraintWithOptions("=1.2.3+abc", MatchOptions{Metadata: MetadataEqual})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := NewEvaluator(c, 0)
+	if !e.Check(MustParse("1.2.3+abc")) || e.Check(MustParse("1.2.3+def")) {
+		t.Error("expected versions differing only in metadata to be checked separately")
+	}
+}
+
+func TestEvaluatorSize(t *testing.T) {
+	e := NewEvaluator(mustConstraint(t, "^1"), 2)
+	for _, s := range []string{"1.0.0", "1.1.0", "1.2.0"} {
+		e.Check(MustParse(s))
+	}
+	if e.Len() != 1 {
+		t.Errorf("expected the evaluator to be emptied when full but it holds %d", e.Len())
+	}
+}
+
+func TestEvaluatorHooks(t *testing.T) {
+	r := &recordHooks{}
+	SetHooks(r)
+	defer SetHooks(nil)
+
+	e := NewEvaluator(mustConstraint(t, "^1"), 0)
+	v := MustParse("1.5.0")
+	e.Check(v)
+	e.Check(v)
+
+	if len(r.matches) != 2 || r.matches[0].CacheHit || !r.matches[1].CacheHit {
+		t.Fatalf("expected a miss and then a hit but got %+v", r.matches)
+	}
+	if !r.matches[1].Matched || r.matches[1].Version != v {
+		t.Errorf("unexpected event %+v", r.matches[1])
+	}
+}
+
+func TestEvaluatorConcurrent(t *testing.T) {
+	e := NewEvaluator(mustConstraint(t, "~1.2"), 4)
+	versions := []*Version{MustParse("1.2.0"), MustParse("1.2.5"), MustParse("1.3.0"), MustParse("2.0.0"), MustParse("1.2.9")}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				v := versions[n%len(versions)]
+				if e.Check(v) != (v.Major() == 1 && v.Minor() == 2) {
+					t.Errorf("unexpected result for %s", v)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,196 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Explanation describes how constraints are interpreted. It is returned by
+// Explain and is intended to help debug why a version does or does not
+// satisfy a set of constraints.
+type Explanation struct {
+	// Constraint is the constraints as rendered by Constraints.String.
+	Constraint string `json:"constraint"`
+
+	// Version is the version the clauses were checked against. It is nil when
+	// no version was passed to Explain.
+	Version *Version `json:"version,omitempty"`
+
+	// Matched reports if the version satisfies the constraints.
+	Matched bool `json:"matched"`
+
+	// Branches are the || separated groups of the constraints.
+	Branches []BranchExplanation `json:"branches"`
+}
+
+// BranchExplanation describes one || separated group of AND clauses.
+type BranchExplanation struct {
+	Clauses []ClauseExplanation `json:"clauses"`
+
+	// Releases are the ranges of release versions admitted by the group,
+	// rendered as constraints. Single versions excluded from within a range
+	// are listed in Exclusions rather than splitting the range.
+	Releases []string `json:"releases"`
+
+	// Prereleases are the ranges of prerelease versions admitted by the
+	// group, rendered the same way as Releases.
+	Prereleases []string `json:"prereleases"`
+
+	// Exclusions are the single versions excluded from the ranges.
+	Exclusions []string `json:"exclusions"`
+
+	// Matched reports if the version satisfies every clause in the group.
+	Matched bool `json:"matched"`
+}
+
+// ClauseExplanation describes a single clause of a group.
+type ClauseExplanation struct {
+	// Clause is the clause as written.
+	Clause string `json:"clause"`
+
+	// Interpretation is the operator, version, and wildcard the clause was
+	// parsed into.
+	Interpretation string `json:"interpretation"`
+
+	// Matched reports if the version satisfies the clause.
+	Matched bool `json:"matched"`
+
+	// Reason explains why the version does not satisfy the clause.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Explain describes the normalized ranges of versions each group of the
+// constraints admits. When v is not nil each clause is also checked against
+// it, reporting the reason any clause does not match. The ranges hold every
+// version the constraints may admit, as those of Ranges do. Nil constraints
+// have no branches.
+func Explain(c *Constraints, v *Version) Explanation {
+	c = orNone(c, nil)
+	e := Explanation{
+		Constraint: c.String(),
+		Version:    v,
+		Branches:   make([]BranchExplanation, len(c.constraints)),
+	}
+
+	for k, group := range c.constraints {
+		s, _ := andSets(group, &c.opts)
+		b := BranchExplanation{Clauses: make([]ClauseExplanation, len(group))}
+		var ex []*Version
+		b.Releases, ex = renderIntervals(s.releases, true)
+		b.Exclusions = versionStrings(ex)
+		b.Prereleases, ex = renderIntervals(s.prereleases, false)
+		b.Exclusions = append(b.Exclusions, versionStrings(ex)...)
+
+		for i, cl := range group {
+			b.Clauses[i] = ClauseExplanation{
+				Clause:         cl.string(),
+				Interpretation: cl.describe(),
+			}
+		}
+		if v != nil {
+			b.Matched = explainMatches(v, group, c.opts, b.Clauses)
+			e.Matched = e.Matched || b.Matched
+		}
+		e.Branches[k] = b
+	}
+
+	return e
+}
+
+// explainMatches checks each clause of a group against a version filling in
+// the match and reason, the same way ValidateWithOptions does.
+func explainMatches(v *Version, group []*constraint, opts MatchOptions, out []ClauseExplanation) bool {
+	parts := groupParts(group, &opts, true)
+	joy := true
+	for k, c := range group {
+		p := partOf(parts, c, &opts)
+		gopts, ok := groupOptions(v, p.clauses, *p.opts)
+		var err error
+		if ok {
+			_, err = c.check(v, &gopts)
+		}
+		switch {
+		case !ok:
+			err = fmt.Errorf("%s is a prerelease version and the constraint does not name a prerelease of %d.%d.%d", v, v.major, v.minor, v.patch)
+		case err != nil && c.skipPrerelease(v, &gopts):
+			err = fmt.Errorf("%s is a prerelease version and the constraint is only looking for release versions", v)
+		}
+
+		out[k].Matched = err == nil
+		if err != nil {
+			out[k].Reason = err.Error()
+			joy = false
+		}
+	}
+	return joy
+}
+
+// String renders the explanation over multiple lines.
+func (e Explanation) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "constraint: %s\n", e.Constraint)
+	for k, b := range e.Branches {
+		fmt.Fprintf(&buf, "branch %d:\n", k+1)
+		for _, c := range b.Clauses {
+			fmt.Fprintf(&buf, "  %s: %s", c.Clause, c.Interpretation)
+			switch {
+			case e.Version == nil:
+			case c.Matched:
+				buf.WriteString(" (matches)")
+			default:
+				fmt.Fprintf(&buf, " (%s)", c.Reason)
+			}
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "  releases: %s\n", listString(b.Releases))
+		fmt.Fprintf(&buf, "  prereleases: %s\n", listString(b.Prereleases))
+		if len(b.Exclusions) > 0 {
+			fmt.Fprintf(&buf, "  excluding: %s\n", strings.Join(b.Exclusions, ", "))
+		}
+	}
+
+	if e.Version != nil {
+		if e.Matched {
+			fmt.Fprintf(&buf, "%s satisfies the constraint\n", e.Version)
+		} else {
+			fmt.Fprintf(&buf, "%s does not satisfy the constraint\n", e.Version)
+		}
+	}
+	return buf.String()
+}
+
+func listString(s []string) string {
+	if len(s) == 0 {
+		return "none"
+	}
+	return strings.Join(s, " || ")
+}
+
+// renderIntervals renders merged intervals as constraints. Neighbouring
+// intervals separated by a single version are joined and the version is
+// returned as excluded instead.
+func renderIntervals(in []Interval, releases bool) ([]string, []*Version) {
+	var out []string
+	var ex []*Version
+	for _, r := range newRanges(in, releases) {
+		ex = append(ex, r.excluded...)
+
+		// A normalized release interval holding a single release is
+		// rendered as that release.
+		if releases && r.interval.min.v != nil && r.interval.max.v != nil && r.interval.max.v.Equal(nextRelease(r.interval.min.v.major, r.interval.min.v.minor, r.interval.min.v.patch)) {
+			out = append(out, r.interval.min.v.String())
+			continue
+		}
+		out = append(out, r.interval.String())
+	}
+	return out, ex
+}
+
+func versionStrings(vs []*Version) []string {
+	s := make([]string, len(vs))
+	for k, v := range vs {
+		s[k] = v.String()
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+package semver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExplainRanges(t *testing.T) {
+	tests := []struct {
+		constraint  string
+		releases    []string
+		prereleases []string
+		exclusions  []string
+	}{
+		{"^1.2", []string{">=1.2.0 <2.0.0"}, nil, nil},
+		{">=1.2, <2, !=1.4.2", []string{">=1.2.0 <2.0.0"}, nil, []string{"1.4.2"}},
+		{"!=1.2.3", []string{"*"}, []string{"*"}, []string{"1.2.3"}},
+		{"!=1.2.3-beta", []string{"*"}, []string{"*"}, []string{"1.2.3-beta"}},
+		{">=1.2, !=1.4.x", []string{">=1.2.0 <1.4.0", ">=1.5.0"}, nil, nil},
+		{"^1.2.3-alpha", []string{">=1.2.3 <2.0.0"}, []string{">=1.2.3-alpha <2.0.0-0"}, nil},
+		{"1.2.3", []string{"1.2.3"}, nil, nil},
+		{"1.2.3 - 1.2.5, !=1.2.4", []string{">=1.2.3 <1.2.6"}, nil, []string{"1.2.4"}},
+		{">=1.2.3, <=1.2.5, !=1.2.4", []string{">=1.2.3 <1.2.6"}, nil, []string{"1.2.4"}},
+		{">2, <1", nil, nil, nil},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.constraint, err)
+		}
+
+		b := Explain(c, nil).Branches[0]
+		if !equalStrings(b.Releases, tc.releases) || !equalStrings(b.Prereleases, tc.prereleases) || !equalStrings(b.Exclusions, tc.exclusions) {
+			t.Errorf("unexpected ranges for %q: releases %q prereleases %q exclusions %q", tc.constraint, b.Releases, b.Prereleases, b.Exclusions)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func TestExplainVersion(t *testing.T) {
+	c, err := NewConstraint(">=1.2, !=1.4.2 || 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := Explain(c, MustParse("1.4.2"))
+	if e.Matched || e.Branches[0].Matched || e.Branches[1].Matched {
+		t.Error("expected 1.4.2 not to match")
+	}
+	cl := e.Branches[0].Clauses
+	if !cl[0].Matched || cl[0].Reason != "" {
+		t.Errorf("expected >=1.2 to match but got %+v", cl[0])
+	}
+	if cl[1].Matched || cl[1].Reason != "1.4.2 is equal to 1.4.2" {
+		t.Errorf("unexpected result for !=1.4.2: %+v", cl[1])
+	}
+
+	e = Explain(c, MustParse("3.1.0"))
+	if !e.Matched || !e.Branches[1].Matched {
+		t.Errorf("expected 3.1.0 to match the second branch: %+v", e)
+	}
+
+	e = Explain(c, MustParse("1.5.0-beta"))
+	if e.Matched || e.Branches[0].Clauses[0].Reason != "1.5.0-beta is a prerelease version and the constraint is only looking for release versions" {
+		t.Errorf("unexpected prerelease reason: %+v", e.Branches[0].Clauses[0])
+	}
+}
+
+func TestExplanationString(t *testing.T) {
+	c, err := NewConstraint(">=1.2, !=1.4.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `constraint: >=1.2 !=1.4.2
+branch 1:
+  >=1.2: op=>= version=1.2.0 wildcard=patch (matches)
+  !=1.4.2: op=!= version=1.4.2 (1.4.2 is equal to 1.4.2)
+  releases: >=1.2.0
+  prereleases: none
+  excluding: 1.4.2
+1.4.2 does not satisfy the constraint
+`
+	if a := Explain(c, MustParse("1.4.2")).String(); a != expected {
+		t.Errorf("unexpected explanation:\n%s", a)
+	}
+
+	if a := Explain(c, nil).String(); strings.Contains(a, "matches") || strings.Contains(a, "satisf") {
+		t.Errorf("expected no match report without a version:\n%s", a)
+	}
+}
@@ -0,0 +1,72 @@
+package semver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Fingerprint returns a hash of the versions the constraints admit. It is
+// computed from the normalized intervals of releases and prereleases rather
+// than from how the constraints are written, so `^1.2` and `>=1.2.0, <2` have
+// the same fingerprint. This lets equivalent constraints from many sources be
+// collapsed into one. The options the constraints were created with are taken
+// into account as they change the versions admitted. A nil c has the same
+// fingerprint as None.
+//
+// With MetadataEqual, MetadataOrdered, or LegacyPrereleaseOrder the intervals
+// cannot describe every version admitted, such as 1.2.3+abc but not 1.2.3+def
+// for `=1.2.3+abc` with MetadataEqual. Constraints relying on them are hashed
+// from their clauses and options instead, so they only share a fingerprint
+// with constraints written with the same clauses.
+func Fingerprint(c *Constraints) [32]byte {
+	if c == nil {
+		c = None()
+	}
+	s, must := c.sets()
+	var buf bytes.Buffer
+	if !exact(s, must) {
+		buf.WriteString("clauses:")
+		buf.WriteString(c.canonicalClauses())
+		return sha256.Sum256(buf.Bytes())
+	}
+	buf.WriteString("releases:")
+	writeCanonicalIntervals(&buf, s.releases)
+	buf.WriteString("prereleases:")
+	writeCanonicalIntervals(&buf, s.prereleases)
+	return sha256.Sum256(buf.Bytes())
+}
+
+// writeCanonicalIntervals writes intervals in a form that only depends on the
+// versions they hold. Build metadata does not change the order of versions so
+// it is left out.
+func writeCanonicalIntervals(buf *bytes.Buffer, in []Interval) {
+	for _, i := range in {
+		switch {
+		case i.min.v == nil:
+			buf.WriteString("(")
+		case i.min.inclusive:
+			fmt.Fprintf(buf, "[%s", canonicalVersion(i.min.v))
+		default:
+			fmt.Fprintf(buf, "(%s", canonicalVersion(i.min.v))
+		}
+		buf.WriteString(",")
+		switch {
+		case i.max.v == nil:
+			buf.WriteString(")")
+		case i.max.inclusive:
+			fmt.Fprintf(buf, "%s]", canonicalVersion(i.max.v))
+		default:
+			fmt.Fprintf(buf, "%s)", canonicalVersion(i.max.v))
+		}
+	}
+	buf.WriteString(";")
+}
+
+func canonicalVersion(v *Version) string {
+	s := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if v.Prerelease() != "" {
+		s += "-" + v.Prerelease()
+	}
+	return s
+}
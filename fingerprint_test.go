@@ -0,0 +1,82 @@
+package semver
+
+import "testing"
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"^1.2", ">=1.2.0, <2", true},
+		{"^1.2", ">=1.2.0, <2.0.0-0", true},
+		{"~1.2 || ~1.3", ">=1.2, <1.4", true},
+		{"~1.3 || ~1.2", "~1.2 || ~1.3", true},
+		{"1.2.3+build", "=1.2.3", true},
+		{">1.2.3, <=1.4", ">=1.2.4, <1.5.0", true},
+		{"*", ">=0.0.0", true},
+		{"^1.2", "^1.3", false},
+		{">=1.2", ">=1.2.0-0", false},
+		{"!=1.2.3", "*", false},
+	}
+
+	for _, tc := range tests {
+		a, err := NewConstraint(tc.a)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.a, err)
+		}
+		b, err := NewConstraint(tc.b)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.b, err)
+		}
+
+		if got := Fingerprint(a) == Fingerprint(b); got != tc.equal {
+			t.Errorf("expected the fingerprints of %q and %q to be equal to be %t", tc.a, tc.b, tc.equal)
+		}
+	}
+
+	// Options change the versions admitted.
+	a, _ := NewConstraint("^0.2")
+	b, _ := NewConstraintWithOptions("^0.2", MatchOptions{Caret: CaretMajor})
+	if Fingerprint(a) == Fingerprint(b) {
+		t.Error("expected options changing the versions admitted to change the fingerprint")
+	}
+}
+
+func TestFingerprintPrecedenceOptions(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		opts  MatchOptions
+		equal bool
+	}{
+		{"=1.2.3+abc", "=1.2.3+def", MatchOptions{Metadata: MetadataEqual}, false},
+		{"=1.2.3+abc", "=1.2.3+abc", MatchOptions{Metadata: MetadataEqual}, true},
+		{"=1.2.3+abc, <2 || ^3", "^3 || <2 =1.2.3+abc", MatchOptions{Metadata: MetadataEqual}, true},
+		{">1.2.3+build.9", ">1.2.3+build.10", MatchOptions{Metadata: MetadataOrdered}, false},
+		{">=1.2.3-99999999999999999999", ">=1.2.3-100000000000000000000", MatchOptions{LegacyPrereleaseOrder: true}, false},
+		{"=1.2.3+abc", "=1.2.3+def", MatchOptions{}, true},
+	}
+	for _, tc := range tests {
+		a, err := NewConstraintWithOptions(tc.a, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := NewConstraintWithOptions(tc.b, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Fingerprint(a) == Fingerprint(b); got != tc.equal {
+			t.Errorf("expected the fingerprints of %q and %q with %+v to be equal to be %t", tc.a, tc.b, tc.opts, tc.equal)
+		}
+	}
+
+	// The metadata policy is part of the fingerprint.
+	a, _ := NewConstraint("=1.2.3+abc")
+	b, _ := NewConstraintWithOptions("=1.2.3+abc", MatchOptions{Metadata: MetadataEqual})
+	if Fingerprint(a) == Fingerprint(b) {
+		t.Error("expected the metadata policy to change the fingerprint")
+	}
+
+	if Fingerprint(nil) != Fingerprint(None()) {
+		t.Error("expected nil constraints to have the fingerprint of None")
+	}
+}
@@ -0,0 +1,70 @@
+//go:build go1.18
+// +build go1.18
+
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+	"github.com/jesseduffield/semver/v3/semvertest"
+)
+
+func FuzzNewVersion(f *testing.F) {
+	for _, s := range semvertest.VersionSeeds() {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := semver.NewVersion(s)
+		if err != nil {
+			return
+		}
+		if !semver.LooksLikeVersion(s) {
+			t.Fatalf("expected %q to look like a version as it parses", s)
+		}
+
+		// The canonical form of a version parses back to the same version.
+		p, err := semver.NewVersion(v.String())
+		if err != nil {
+			t.Fatalf("unable to parse %q, the canonical form of %q: %s", v.String(), s, err)
+		}
+		if p.Compare(v) != 0 || p.String() != v.String() {
+			t.Fatalf("expected %q to parse to %s but got %s", v.String(), v, p)
+		}
+	})
+}
+
+func FuzzNewConstraint(f *testing.F) {
+	for _, s := range semvertest.ConstraintSeeds() {
+		f.Add(s, "1.2.3")
+	}
+
+	f.Fuzz(func(t *testing.T, s, vs string) {
+		c, err := semver.NewConstraint(s)
+		if err != nil {
+			return
+		}
+
+		// The constraints render to a string that parses back to the same
+		// constraints.
+		p, err := semver.NewConstraint(c.String())
+		if err != nil {
+			t.Fatalf("unable to parse %q, the rendering of %q: %s", c.String(), s, err)
+		}
+		if p.String() != c.String() {
+			t.Fatalf("expected %q to render as %q but got %q", c.String(), c.String(), p.String())
+		}
+
+		v, err := semver.NewVersion(vs)
+		if err != nil {
+			return
+		}
+		if c.Check(v) != p.Check(v) {
+			t.Fatalf("%q and its rendering %q disagree on %s", s, c.String(), v)
+		}
+		if ok, _ := c.Validate(v); ok != c.Check(v) {
+			t.Fatalf("Validate and Check disagree on %s for %q", v, s)
+		}
+	})
+}
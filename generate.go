@@ -0,0 +1,188 @@
+package semver
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Generate implements the testing/quick Generator interface so *Version can
+// be used in property tests. The receiver is not used and may be nil. The
+// size bounds the generated version: the numeric segments are at most size
+// and larger sizes allow longer prerelease and metadata. A size of 0 always
+// generates 0.0.0, so failures found at small sizes come with small versions.
+func (v *Version) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomVersion(r, size))
+}
+
+// Generate implements the testing/quick Generator interface so *Constraints
+// can be used in property tests. The receiver is not used and may be nil. The
+// size bounds the versions in the clauses the same way it does for
+// Version.Generate, while the number of || groups and clauses in each group
+// grow with the size.
+func (cs *Constraints) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomConstraints(r, size))
+}
+
+// GenOpts controls the versions made by GenerateVersions. The zero value
+// generates 0.0.0 every time, so set the ranges for the segments.
+type GenOpts struct {
+	// MaxMajor, MaxMinor, and MaxPatch are the largest values generated for
+	// each segment. The segments are chosen uniformly from 0 to their max.
+	MaxMajor, MaxMinor, MaxPatch uint64
+
+	// PrereleaseProbability is the chance, from 0 to 1, that a version is a
+	// prerelease.
+	PrereleaseProbability float64
+
+	// PrereleaseTags are the identifiers prereleases start with, such as
+	// alpha, beta, and rc. A number is appended to the tag (e.g., rc.2). When
+	// empty, random identifiers are used instead.
+	PrereleaseTags []string
+
+	// MetadataProbability is the chance, from 0 to 1, that a version has
+	// build metadata.
+	MetadataProbability float64
+
+	// MaxIdentifiers is the largest number of dot separated identifiers in
+	// random prereleases and in metadata. Values less than 1 are treated as 1.
+	MaxIdentifiers int
+}
+
+// GenerateVersions returns n random versions drawn using r. The same source
+// and options always produce the same versions, so corpora for load tests and
+// benchmarks can be reproduced from a seed.
+func GenerateVersions(r *rand.Rand, n int, opts GenOpts) []*Version {
+	vs := make([]*Version, n)
+	for k := range vs {
+		vs[k] = generateVersion(r, &opts)
+	}
+	return vs
+}
+
+func generateVersion(r *rand.Rand, o *GenOpts) *Version {
+	v := &Version{
+		major: randomSegment(r, o.MaxMajor),
+		minor: randomSegment(r, o.MaxMinor),
+		patch: randomSegment(r, o.MaxPatch),
+	}
+
+	parts := o.MaxIdentifiers
+	if parts < 1 {
+		parts = 1
+	}
+	var pre, metadata string
+	if o.PrereleaseProbability > 0 && r.Float64() < o.PrereleaseProbability {
+		if len(o.PrereleaseTags) > 0 {
+			pre = o.PrereleaseTags[r.Intn(len(o.PrereleaseTags))] + "." + strconv.Itoa(r.Intn(10))
+		} else {
+			pre = randomIdentifiers(r, parts)
+		}
+	}
+	if o.MetadataProbability > 0 && r.Float64() < o.MetadataProbability {
+		metadata = randomIdentifiers(r, parts)
+	}
+	v.setCanonical(pre, metadata, "")
+	return v
+}
+
+// randomSegment returns a random number between 0 and max.
+func randomSegment(r *rand.Rand, max uint64) uint64 {
+	if max == 0 {
+		return 0
+	}
+	if max < math.MaxInt64 {
+		return uint64(r.Int63n(int64(max) + 1))
+	}
+
+	// Beyond the range of Int63n at least half of all values are in range
+	// so drawing until one is found ends quickly.
+	for {
+		if n := r.Uint64(); n <= max {
+			return n
+		}
+	}
+}
+
+// randomIdentifiers returns between 1 and n dot separated identifiers valid
+// for both prereleases and metadata.
+func randomIdentifiers(r *rand.Rand, n int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-"
+	parts := make([]string, 1+r.Intn(n))
+	for k := range parts {
+		if r.Intn(2) == 0 {
+			parts[k] = strconv.Itoa(r.Intn(100))
+			continue
+		}
+		b := make([]byte, 1+r.Intn(6))
+		for i := range b {
+			b[i] = letters[r.Intn(len(letters))]
+		}
+		parts[k] = string(b)
+	}
+	return strings.Join(parts, ".")
+}
+
+// randomVersion generates a version bounded by the size passed in by
+// testing/quick.
+func randomVersion(r *rand.Rand, size int) *Version {
+	if size <= 0 {
+		return generateVersion(r, &GenOpts{})
+	}
+
+	// Longer prerelease and metadata only come with larger sizes.
+	max := uint64(size)
+	return generateVersion(r, &GenOpts{
+		MaxMajor:              max,
+		MaxMinor:              max,
+		MaxPatch:              max,
+		PrereleaseProbability: 0.25,
+		MetadataProbability:   0.125,
+		MaxIdentifiers:        1 + size/20,
+	})
+}
+
+// randomOps are the operators used by generated clauses.
+var randomOps = []string{"", "=", "!=", ">", "<", ">=", "<=", "~", "~>", "^"}
+
+// randomClause returns a single clause such as ^1.2 or >=1.x.
+func randomClause(r *rand.Rand, size int) string {
+	v := randomVersion(r, size)
+	v.setCanonical(v.Prerelease(), "", "")
+
+	s := strconv.FormatUint(v.major, 10)
+	switch r.Intn(4) {
+	case 0:
+		// Only the major version.
+	case 1:
+		s += "." + strconv.FormatUint(v.minor, 10) + ".x"
+	default:
+		s = v.String()
+	}
+
+	if r.Intn(8) == 0 {
+		return s + " - " + randomVersion(r, size).String()
+	}
+	return randomOps[r.Intn(len(randomOps))] + s
+}
+
+func randomConstraints(r *rand.Rand, size int) *Constraints {
+	groups := make([]string, 1+r.Intn(1+size/25))
+	for k := range groups {
+		clauses := make([]string, 1+r.Intn(1+size/20))
+		for i := range clauses {
+			clauses[i] = randomClause(r, size)
+		}
+		groups[k] = strings.Join(clauses, ", ")
+	}
+
+	c, err := NewConstraint(strings.Join(groups, " || "))
+	if err != nil {
+		// The generated clauses are always valid so this is a bug in the
+		// generator.
+		panic("semver: generated an invalid constraint: " + err.Error())
+	}
+	return c
+}
@@ -0,0 +1,122 @@
+package semver
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestVersionGenerate(t *testing.T) {
+	f := func(v *Version) bool {
+		p, err := StrictNewVersion(v.String())
+		return err == nil && p.Equal(v) && p.String() == v.String()
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+
+	g := func(a, b *Version) bool {
+		return a.Compare(b) == -b.Compare(a)
+	}
+	if err := quick.Check(g, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVersionGenerateSize(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		v := (*Version)(nil).Generate(r, 0).Interface().(*Version)
+		if v.String() != "0.0.0" {
+			t.Fatalf("expected size 0 to generate 0.0.0 but got %s", v)
+		}
+
+		v = (*Version)(nil).Generate(r, 5).Interface().(*Version)
+		if v.Major() > 5 || v.Minor() > 5 || v.Patch() > 5 {
+			t.Fatalf("expected segments of at most 5 but got %s", v)
+		}
+	}
+}
+
+func TestConstraintsGenerate(t *testing.T) {
+	f := func(c *Constraints, v *Version) bool {
+		p, err := NewConstraint(c.String())
+		if err != nil {
+			return false
+		}
+		return p.String() == c.String() && p.Check(v) == c.Check(v)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGenerateVersions(t *testing.T) {
+	opts := GenOpts{
+		MaxMajor:              3,
+		MaxMinor:              10,
+		MaxPatch:              20,
+		PrereleaseProbability: 0.3,
+		PrereleaseTags:        []string{"alpha", "beta", "rc"},
+		MetadataProbability:   0.1,
+	}
+
+	vs := GenerateVersions(rand.New(rand.NewSource(42)), 1000, opts)
+	if len(vs) != 1000 {
+		t.Fatalf("expected 1000 versions but got %d", len(vs))
+	}
+
+	pre, meta := 0, 0
+	for _, v := range vs {
+		if _, err := StrictNewVersion(v.Original()); err != nil {
+			t.Fatalf("generated an invalid version %q: %s", v.Original(), err)
+		}
+		if v.Major() > 3 || v.Minor() > 10 || v.Patch() > 20 {
+			t.Fatalf("generated %s outside of the segment ranges", v)
+		}
+		if v.Prerelease() != "" {
+			pre++
+			tag := strings.SplitN(v.Prerelease(), ".", 2)[0]
+			if tag != "alpha" && tag != "beta" && tag != "rc" {
+				t.Fatalf("generated %s with an unexpected prerelease tag", v)
+			}
+		}
+		if v.Metadata() != "" {
+			meta++
+		}
+	}
+
+	// The probabilities are loosely respected.
+	if pre < 200 || pre > 400 {
+		t.Errorf("expected about 300 prereleases but got %d", pre)
+	}
+	if meta < 50 || meta > 150 {
+		t.Errorf("expected about 100 versions with metadata but got %d", meta)
+	}
+
+	// The same seed produces the same versions.
+	again := GenerateVersions(rand.New(rand.NewSource(42)), 1000, opts)
+	for k := range vs {
+		if vs[k].Original() != again[k].Original() {
+			t.Fatalf("expected the same versions from the same seed but got %s and %s", vs[k], again[k])
+		}
+	}
+}
+
+func TestGenerateVersionsZeroOptions(t *testing.T) {
+	for _, v := range GenerateVersions(rand.New(rand.NewSource(1)), 10, GenOpts{}) {
+		if v.Original() != "0.0.0" {
+			t.Fatalf("expected the zero options to generate 0.0.0 but got %s", v)
+		}
+	}
+
+	// Segments up to the largest uint64 can be generated.
+	vs := GenerateVersions(rand.New(rand.NewSource(1)), 10, GenOpts{MaxMajor: math.MaxUint64})
+	for _, v := range vs {
+		if _, err := NewVersion(v.Original()); err != nil {
+			t.Fatalf("generated an invalid version %q: %s", v.Original(), err)
+		}
+	}
+}
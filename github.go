@@ -0,0 +1,62 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// GitHubOptions controls which entries FromGitHub keeps.
+type GitHubOptions struct {
+	// IncludeDrafts keeps draft releases.
+	IncludeDrafts bool
+
+	// IncludePrereleases keeps releases GitHub marks as prereleases and
+	// versions with a prerelease, such as 1.2.0-rc.1.
+	IncludePrereleases bool
+
+	// Prefixes are stripped from the tags the same way as by FromTags.
+	Prefixes []string
+}
+
+// gitHubEntry holds the fields read from an entry of a GitHub releases or
+// tags API response.
+type gitHubEntry struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// FromGitHub decodes a response from the GitHub releases API, such as
+// GET /repos/{owner}/{repo}/releases, or tags API, such as
+// GET /repos/{owner}/{repo}/tags, into versions. Releases are read from their
+// tag_name and tags from their name. Drafts and prereleases are skipped unless
+// the options include them, as are tags that are not versions. The versions
+// are returned in the order of the response.
+func FromGitHub(r io.Reader, o GitHubOptions) (Collection, error) {
+	var entries []gitHubEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("invalid GitHub response: %s", err)
+	}
+
+	var out Collection
+	for _, e := range entries {
+		tag := e.TagName
+		if tag == "" {
+			tag = e.Name
+		}
+		if e.Draft && !o.IncludeDrafts {
+			continue
+		}
+		v := fromTag(tag, o.Prefixes)
+		if v == nil {
+			continue
+		}
+		if (e.Prerelease || v.Prerelease() != "") && !o.IncludePrereleases {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
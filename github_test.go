@@ -0,0 +1,49 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFromGitHub(t *testing.T) {
+	releases := `[
+		{"tag_name": "v2.1.0-rc.1", "name": "2.1 RC", "draft": false, "prerelease": true},
+		{"tag_name": "v2.0.1", "name": "Patch", "draft": true, "prerelease": false},
+		{"tag_name": "v2.0.0", "name": "Two", "draft": false, "prerelease": false},
+		{"tag_name": "nightly", "name": "Nightly", "draft": false, "prerelease": true},
+		{"tag_name": "v1.9.0-beta", "name": "Unmarked beta", "draft": false, "prerelease": false},
+		{"tag_name": "v1.8.0", "name": "Eight", "draft": false, "prerelease": false}
+	]`
+	tags := `[{"name": "release-1.1.0", "commit": {"sha": "abc"}}, {"name": "release-1.0.0"}, {"name": "latest"}]`
+
+	tests := []struct {
+		body     string
+		options  GitHubOptions
+		expected []string
+	}{
+		{releases, GitHubOptions{}, []string{"v2.0.0", "v1.8.0"}},
+		{releases, GitHubOptions{IncludeDrafts: true}, []string{"v2.0.1", "v2.0.0", "v1.8.0"}},
+		{releases, GitHubOptions{IncludePrereleases: true}, []string{"v2.1.0-rc.1", "v2.0.0", "v1.9.0-beta", "v1.8.0"}},
+		{tags, GitHubOptions{Prefixes: []string{"release-"}}, []string{"1.1.0", "1.0.0"}},
+		{`[]`, GitHubOptions{}, nil},
+	}
+
+	for _, tc := range tests {
+		vs, err := FromGitHub(strings.NewReader(tc.body), tc.options)
+		if err != nil {
+			t.Errorf("unexpected error with %+v: %s", tc.options, err)
+			continue
+		}
+		var got []string
+		for _, v := range vs {
+			got = append(got, v.Original())
+		}
+		if !equalStrings(got, tc.expected) {
+			t.Errorf("expected the versions %q with %+v but got %q", tc.expected, tc.options, got)
+		}
+	}
+
+	if _, err := FromGitHub(strings.NewReader(`{"message": "Not Found"}`), GitHubOptions{}); err == nil {
+		t.Error("expected an error for a response that is not a list")
+	}
+}
@@ -0,0 +1,99 @@
+package semver
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Requirement is a module required by a go.mod file.
+type Requirement struct {
+	Path    string
+	Version *Version
+
+	// Indirect is true when the requirement is marked // indirect.
+	Indirect bool
+}
+
+// ParseGoModRequires parses the require directives of a go.mod file, both
+// single line and in blocks, into their requirements by module path. Other
+// directives, such as replace and exclude, are skipped. An error naming the
+// line is returned for a requirement that can not be read or whose version
+// is invalid.
+func ParseGoModRequires(data []byte) (map[string]Requirement, error) {
+	reqs := map[string]Requirement{}
+	block := ""
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; s.Scan(); n++ {
+		line, comment := splitGoModComment(s.Text())
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case block != "":
+			if fields[0] == ")" {
+				block = ""
+				continue
+			}
+			if block != "require" {
+				continue
+			}
+		case len(fields) == 2 && fields[1] == "(":
+			block = fields[0]
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		default:
+			continue
+		}
+
+		r, err := parseRequirement(fields, comment)
+		if err != nil {
+			return nil, fmt.Errorf("go.mod line %d: %s", n, err)
+		}
+		reqs[r.Path] = r
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+// parseRequirement parses the path and version of a requirement and its
+// comment.
+func parseRequirement(fields []string, comment string) (Requirement, error) {
+	if len(fields) != 2 {
+		return Requirement{}, fmt.Errorf("%s is not a module path and version", strings.Join(fields, " "))
+	}
+
+	path := fields[0]
+	if strings.HasPrefix(path, `"`) {
+		p, err := strconv.Unquote(path)
+		if err != nil {
+			return Requirement{}, fmt.Errorf("%s is not a valid quoted module path", path)
+		}
+		path = p
+	}
+
+	v, err := NewVersion(fields[1])
+	if err != nil {
+		return Requirement{}, fmt.Errorf("%s has the invalid version %s: %s", path, fields[1], err)
+	}
+
+	comment = strings.TrimSpace(comment)
+	indirect := comment == "indirect" || strings.HasPrefix(comment, "indirect;")
+	return Requirement{Path: path, Version: v, Indirect: indirect}, nil
+}
+
+// splitGoModComment splits a go.mod line into its content and the text of
+// its // comment.
+func splitGoModComment(line string) (string, string) {
+	if i := strings.Index(line, "//"); i >= 0 {
+		return line[:i], line[i+2:]
+	}
+	return line, ""
+}
@@ -0,0 +1,70 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGoModRequires(t *testing.T) {
+	gomod := `module example.com/app
+
+go 1.22
+
+require github.com/jesseduffield/semver/v3 v3.0.3
+
+require (
+	golang.org/x/mod v0.22.0
+	google.golang.org/grpc v1.64.0 // indirect
+	"example.com/quoted" v1.0.0 // indirect; needed by tests
+	github.com/old/lib v2.3.0+incompatible
+	golang.org/x/sys v0.0.0-20240101000000-abcdefabcdef // indirect
+)
+
+replace (
+	golang.org/x/mod => ../mod
+)
+
+exclude golang.org/x/net v0.1.0
+`
+
+	reqs, err := ParseGoModRequires([]byte(gomod))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]struct {
+		version  string
+		indirect bool
+	}{
+		"github.com/jesseduffield/semver/v3": {"3.0.3", false},
+		"golang.org/x/mod":                   {"0.22.0", false},
+		"google.golang.org/grpc":             {"1.64.0", true},
+		"example.com/quoted":                 {"1.0.0", true},
+		"github.com/old/lib":                 {"2.3.0+incompatible", false},
+		"golang.org/x/sys":                   {"0.0.0-20240101000000-abcdefabcdef", true},
+	}
+	if len(reqs) != len(expected) {
+		t.Errorf("expected %d requirements but got %d: %v", len(expected), len(reqs), reqs)
+	}
+	for path, e := range expected {
+		r, ok := reqs[path]
+		if !ok {
+			t.Errorf("expected a requirement on %s", path)
+			continue
+		}
+		if r.Path != path || r.Version.String() != e.version || r.Indirect != e.indirect {
+			t.Errorf("expected %s %s indirect %t but got %s %s indirect %t", path, e.version, e.indirect, r.Path, r.Version, r.Indirect)
+		}
+	}
+
+	for _, bad := range []string{
+		"require (\n\tgolang.org/x/mod\n)",
+		"require golang.org/x/mod latest",
+		"require \"unterminated v1.0.0",
+	} {
+		_, err := ParseGoModRequires([]byte(bad))
+		if err == nil || !strings.HasPrefix(err.Error(), "go.mod line ") {
+			t.Errorf("expected an error naming the line for %q but got %v", bad, err)
+		}
+	}
+}
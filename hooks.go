@@ -0,0 +1,140 @@
+package semver
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Hooks observes parsing and matching, so services embedding the package can
+// feed their own metrics without wrapping every call. They are installed for
+// the whole process with SetHooks and are called synchronously, so they
+// should return quickly. They must be safe for concurrent use.
+type Hooks interface {
+	// OnParse is called after NewVersion, StrictNewVersion, NewConstraint,
+	// NewConstraintWithOptions, StrictNewConstraint,
+	// StrictNewConstraintWithOptions, or ConstraintCache.Get returns.
+	OnParse(ParseEvent)
+
+	// OnMatch is called after Check, CheckWithOptions, Validate, or
+	// ValidateWithOptions returns.
+	OnMatch(MatchEvent)
+}
+
+// ParseKind is what was parsed in a ParseEvent.
+type ParseKind int
+
+const (
+	// ParseVersion is a version parsed by NewVersion.
+	ParseVersion ParseKind = iota
+
+	// ParseStrictVersion is a version parsed by StrictNewVersion.
+	ParseStrictVersion
+
+	// ParseConstraint is constraints parsed by NewConstraint,
+	// NewConstraintWithOptions, StrictNewConstraint,
+	// StrictNewConstraintWithOptions, or ConstraintCache.Get.
+	ParseConstraint
+)
+
+// String returns a short name for the kind of parse.
+func (k ParseKind) String() string {
+	switch k {
+	case ParseVersion:
+		return "version"
+	case ParseStrictVersion:
+		return "strict-version"
+	default:
+		return "constraint"
+	}
+}
+
+// ParseEvent describes a single parse.
+type ParseEvent struct {
+	Kind     ParseKind
+	Input    string
+	Duration time.Duration
+
+	// CacheHit is true when the result came from a cache rather than being
+	// parsed.
+	CacheHit bool
+
+	// Err is the error returned, if any.
+	Err error
+}
+
+// MatchEvent describes a version being checked against constraints.
+type MatchEvent struct {
+	Constraints *Constraints
+	Version     *Version
+	Matched     bool
+	Duration    time.Duration
+
+	// CacheHit is true when the result came from a cache rather than being
+	// computed.
+	CacheHit bool
+}
+
+// HookFuncs adapts functions to Hooks. A nil function is not called.
+type HookFuncs struct {
+	Parse func(ParseEvent)
+	Match func(MatchEvent)
+}
+
+// OnParse calls f.Parse.
+func (f HookFuncs) OnParse(e ParseEvent) {
+	if f.Parse != nil {
+		f.Parse(e)
+	}
+}
+
+// OnMatch calls f.Match.
+func (f HookFuncs) OnMatch(e MatchEvent) {
+	if f.Match != nil {
+		f.Match(e)
+	}
+}
+
+// hooksHolder wraps the installed hooks so an atomic.Value always stores the
+// same type.
+type hooksHolder struct {
+	h Hooks
+}
+
+var installedHooks atomic.Value
+
+// SetHooks installs hooks for the whole process, replacing any installed
+// before. Passing nil removes them. Without hooks, parsing and matching only
+// pay for checking that none are installed.
+func SetHooks(h Hooks) {
+	installedHooks.Store(hooksHolder{h})
+}
+
+// currentHooks returns the installed hooks or nil.
+func currentHooks() Hooks {
+	if h, ok := installedHooks.Load().(hooksHolder); ok {
+		return h.h
+	}
+	return nil
+}
+
+// parseHook reports a parse to the hooks.
+func parseHook(h Hooks, kind ParseKind, input string, start time.Time, cacheHit bool, err error) {
+	h.OnParse(ParseEvent{
+		Kind:     kind,
+		Input:    input,
+		Duration: time.Since(start),
+		CacheHit: cacheHit,
+		Err:      err,
+	})
+}
+
+// matchHook reports a match to the hooks.
+func matchHook(h Hooks, cs *Constraints, v *Version, matched bool, start time.Time, cacheHit bool) {
+	h.OnMatch(MatchEvent{
+		Constraints: cs,
+		Version:     v,
+		Matched:     matched,
+		Duration:    time.Since(start),
+		CacheHit:    cacheHit,
+	})
+}
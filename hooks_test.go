@@ -0,0 +1,123 @@
+package semver
+
+import (
+	"sync"
+	"testing"
+)
+
+// recordHooks collects the events it is called with.
+type recordHooks struct {
+	mu      sync.Mutex
+	parses  []ParseEvent
+	matches []MatchEvent
+}
+
+func (r *recordHooks) OnParse(e ParseEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parses = append(r.parses, e)
+}
+
+func (r *recordHooks) OnMatch(e MatchEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.matches = append(r.matches, e)
+}
+
+func TestHooks(t *testing.T) {
+	r := &recordHooks{}
+	SetHooks(r)
+	defer SetHooks(nil)
+
+	v, _ := NewVersion("1.2.3")
+	_, _ = StrictNewVersion("1.2")
+	c, _ := NewConstraint("^1.2")
+	_, _ = NewConstraintWithOptions("foo", MatchOptions{})
+
+	cache := NewConstraintCache(MatchOptions{}, 0)
+	_, _ = cache.Get(">= 1.2")
+	_, _ = cache.Get(">=1.2")
+
+	c.Check(v)
+	c.Validate(MustParse("2.0.0"))
+
+	parses := []struct {
+		kind     ParseKind
+		input    string
+		cacheHit bool
+		err      bool
+	}{
+		{ParseVersion, "1.2.3", false, false},
+		{ParseStrictVersion, "1.2", false, true},
+		{ParseConstraint, "^1.2", false, false},
+		{ParseConstraint, "foo", false, true},
+		{ParseConstraint, ">= 1.2", false, false},
+		{ParseConstraint, ">=1.2", true, false},
+
+		// MustParse in the call to Validate.
+		{ParseVersion, "2.0.0", false, false},
+	}
+	if len(r.parses) != len(parses) {
+		t.Fatalf("Expected %d parse events but got %d: %v", len(parses), len(r.parses), r.parses)
+	}
+	for k, e := range parses {
+		a := r.parses[k]
+		if a.Kind != e.kind || a.Input != e.input || a.CacheHit != e.cacheHit || (a.Err != nil) != e.err {
+			t.Errorf("Expected parse event %d to be %s %q with a cache hit %t and error %t but got %s %q, %t, %v", k, e.kind, e.input, e.cacheHit, e.err, a.Kind, a.Input, a.CacheHit, a.Err)
+		}
+		if a.Duration < 0 {
+			t.Errorf("Expected a duration for parse event %d but got %s", k, a.Duration)
+		}
+	}
+
+	if len(r.matches) != 2 {
+		t.Fatalf("Expected 2 match events but got %d", len(r.matches))
+	}
+	if m := r.matches[0]; m.Version != v || m.Constraints.String() != "^1.2" || !m.Matched {
+		t.Errorf("Expected 1.2.3 to match ^1.2 but got %+v", m)
+	}
+	if m := r.matches[1]; m.Version.String() != "2.0.0" || m.Matched {
+		t.Errorf("Expected 2.0.0 not to match ^1.2 but got %+v", m)
+	}
+}
+
+func TestHookFuncs(t *testing.T) {
+	var parsed []string
+	SetHooks(HookFuncs{Parse: func(e ParseEvent) {
+		parsed = append(parsed, e.Input)
+	}})
+	defer SetHooks(nil)
+
+	c, _ := NewConstraint("^1")
+	c.Check(MustParse("1.0.0"))
+
+	if len(parsed) != 2 || parsed[0] != "^1" || parsed[1] != "1.0.0" {
+		t.Errorf("Expected ^1 and 1.0.0 to be parsed but got %q", parsed)
+	}
+
+	SetHooks(nil)
+	_, _ = NewVersion("2.0.0")
+	if len(parsed) != 2 {
+		t.Error("Expected no events once the hooks are removed")
+	}
+}
+
+func TestHooksNotInstalledAllocs(t *testing.T) {
+	SetHooks(nil)
+	c := mustConstraint(t, "^1.2")
+	v := MustParse("1.5.0")
+	allocs := testing.AllocsPerRun(100, func() {
+		c.Check(v)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected checking without hooks not to allocate but got %v allocations", allocs)
+	}
+}
+
+func TestParseKindString(t *testing.T) {
+	for k, e := range map[ParseKind]string{ParseVersion: "version", ParseStrictVersion: "strict-version", ParseConstraint: "constraint"} {
+		if k.String() != e {
+			t.Errorf("Expected %q but got %q", e, k.String())
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package semver
+
+import "sync"
+
+// Interner shares the strings of the versions it parses. Registry corpora
+// hold the same version strings, such as 1.0.0-beta.1, many times over.
+// Versions parsed by the same Interner from equal strings share one copy of
+// the string, and so of the prerelease and metadata kept within it. The
+// copy is made on first sight, so a version parsed from a substring of a
+// large buffer, such as a line of a file, does not keep the buffer alive.
+//
+// An Interner only grows. Drop it once a bulk parse is done to release the
+// strings no version refers to. It is safe for concurrent use.
+type Interner struct {
+	mu      sync.Mutex
+	strings map[string]string
+}
+
+// NewInterner creates an empty Interner.
+func NewInterner() *Interner {
+	return &Interner{strings: map[string]string{}}
+}
+
+// Intern returns the shared copy of s, making one when s has not been seen.
+func (in *Interner) Intern(s string) string {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if shared, ok := in.strings[s]; ok {
+		return shared
+	}
+	shared := string([]byte(s))
+	in.strings[shared] = shared
+	return shared
+}
+
+// Len returns the number of distinct strings held.
+func (in *Interner) Len() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return len(in.strings)
+}
+
+// NewVersion parses a version the same way as NewVersion, sharing its
+// string with the versions parsed before it.
+func (in *Interner) NewVersion(s string) (*Version, error) {
+	v, err := NewVersion(s)
+	if err != nil {
+		return nil, err
+	}
+	in.intern(v)
+	return v, nil
+}
+
+// StrictNewVersion parses a version the same way as StrictNewVersion,
+// sharing its string with the versions parsed before it.
+func (in *Interner) StrictNewVersion(s string) (*Version, error) {
+	v, err := StrictNewVersion(s)
+	if err != nil {
+		return nil, err
+	}
+	in.intern(v)
+	return v, nil
+}
+
+// intern replaces the text of a version with its shared copy. The offsets
+// into the text are unchanged as the copy is equal.
+func (in *Interner) intern(v *Version) {
+	v.text = in.Intern(v.text)
+}
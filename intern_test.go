@@ -0,0 +1,85 @@
+package semver
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+// stringData returns the address of the bytes of a string.
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestInterner(t *testing.T) {
+	in := NewInterner()
+
+	buf := "1.0.0-beta.1+build.5 1.0.0-beta.1+build.5 2.0.0"
+	fields := strings.Fields(buf)
+
+	v1, err := in.NewVersion(fields[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, err := in.StrictNewVersion(fields[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	v3, err := in.NewVersion(fields[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stringData(v1.Original()) != stringData(v2.Original()) {
+		t.Error("Expected equal versions to share their original")
+	}
+	if stringData(v1.Prerelease()) != stringData(v2.Prerelease()) {
+		t.Error("Expected equal versions to share their prerelease")
+	}
+	if stringData(v1.Original()) == stringData(buf) {
+		t.Error("Expected the interned original to be a copy of the input")
+	}
+
+	if v1.Prerelease() != "beta.1" || v1.Metadata() != "build.5" || v1.Original() != fields[0] {
+		t.Errorf("Expected the parts of %s to be unchanged but got %q, %q, %q", fields[0], v1.Prerelease(), v1.Metadata(), v1.Original())
+	}
+	if v3.String() != "2.0.0" {
+		t.Errorf("Expected 2.0.0 but got %s", v3)
+	}
+	if l := in.Len(); l != 2 {
+		t.Errorf("Expected 2 interned strings but got %d", l)
+	}
+
+	if _, err := in.NewVersion("bad"); err == nil {
+		t.Error("Expected an error parsing bad")
+	}
+	if _, err := in.StrictNewVersion("1.2"); err == nil {
+		t.Error("Expected an error strictly parsing 1.2")
+	}
+	if l := in.Len(); l != 2 {
+		t.Errorf("Expected invalid versions not to be interned but got %d strings", l)
+	}
+}
+
+func TestInternerConcurrent(t *testing.T) {
+	in := NewInterner()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, s := range []string{"1.0.0-rc.1", "1.0.0-rc.2", "1.0.0"} {
+				if _, err := in.NewVersion(s); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if l := in.Len(); l != 3 {
+		t.Errorf("Expected 3 interned strings but got %d", l)
+	}
+}
@@ -0,0 +1,783 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// bound is one end of an interval of versions. A nil version means the
+// interval is unbounded on that side.
+type bound struct {
+	v         *Version
+	inclusive bool
+}
+
+// Interval is a contiguous range of versions ordered by Version.Compare. It
+// holds every version between its bounds, releases and prereleases alike, so
+// unlike constraints it does not skip prereleases that were not asked for. The
+// zero value holds every version.
+type Interval struct {
+	min, max bound
+}
+
+// NewInterval creates an interval from min to max. A nil min or max leaves the
+// interval unbounded on that side. includeMin and includeMax control whether
+// the bounds themselves are within the interval and are ignored for nil
+// bounds.
+func NewInterval(min *Version, includeMin bool, max *Version, includeMax bool) Interval {
+	i := Interval{}
+	if min != nil {
+		i.min = bound{v: min, inclusive: includeMin}
+	}
+	if max != nil {
+		i.max = bound{v: max, inclusive: includeMax}
+	}
+	return i
+}
+
+// Min returns the lower bound of the interval or nil when it is unbounded
+// below.
+func (i Interval) Min() *Version {
+	return i.min.v
+}
+
+// Max returns the upper bound of the interval or nil when it is unbounded
+// above.
+func (i Interval) Max() *Version {
+	return i.max.v
+}
+
+// IncludesMin reports if Min is itself within the interval.
+func (i Interval) IncludesMin() bool {
+	return i.min.v != nil && i.min.inclusive
+}
+
+// IncludesMax reports if Max is itself within the interval.
+func (i Interval) IncludesMax() bool {
+	return i.max.v != nil && i.max.inclusive
+}
+
+// versionSet describes the versions admitted by a constraint. Releases and
+// prereleases are tracked separately because constraints only admit
+// prereleases when they ask for them. The release intervals are normalized
+// so they always have an inclusive min and an exclusive max made up of
+// release versions. This makes equal sets of releases compare as equal.
+type versionSet struct {
+	releases    []Interval
+	prereleases []Interval
+}
+
+// anyInterval is the interval containing every version.
+var anyInterval = Interval{}
+
+// universe returns the set containing every version.
+func universe() versionSet {
+	return versionSet{
+		releases:    []Interval{anyInterval},
+		prereleases: []Interval{anyInterval},
+	}
+}
+
+// newCoreVersion creates a release version from its numeric segments.
+func newCoreVersion(major, minor, patch uint64) *Version {
+	v := &Version{major: major, minor: minor, patch: patch}
+	v.setCanonical("", "", "")
+	return v
+}
+
+// newLowestVersion creates the lowest version sharing the numeric segments.
+// The prerelease 0 comes before every other prerelease of the same release.
+func newLowestVersion(major, minor, patch uint64) *Version {
+	v := &Version{major: major, minor: minor, patch: patch}
+	v.setCanonical("0", "", "")
+	return v
+}
+
+// nextRelease returns the release following major.minor.patch, or nil when
+// it is the largest possible. A segment at its largest carries into the one
+// before rather than wrapping around to 0, which would put the release below
+// the one it follows.
+func nextRelease(major, minor, patch uint64) *Version {
+	switch {
+	case patch < math.MaxUint64:
+		return newCoreVersion(major, minor, patch+1)
+	case minor < math.MaxUint64:
+		return newCoreVersion(major, minor+1, 0)
+	case major < math.MaxUint64:
+		return newCoreVersion(major+1, 0, 0)
+	}
+	return nil
+}
+
+// nextMajorBound returns the exclusive upper bound below the prereleases of
+// the major version after major. There is none after the largest major, so
+// the bound is then unbounded rather than wrapping around to 0.
+func nextMajorBound(major uint64) bound {
+	if major == math.MaxUint64 {
+		return bound{}
+	}
+	return bound{v: newLowestVersion(major+1, 0, 0)}
+}
+
+// nextMinorBound returns the exclusive upper bound below the prereleases of
+// the minor version after major.minor, carrying into the major at the
+// largest minor.
+func nextMinorBound(major, minor uint64) bound {
+	if minor == math.MaxUint64 {
+		return nextMajorBound(major)
+	}
+	return bound{v: newLowestVersion(major, minor+1, 0)}
+}
+
+// nextPatchBound returns the exclusive upper bound below the prereleases of
+// the release after major.minor.patch, carrying into the minor at the
+// largest patch.
+func nextPatchBound(major, minor, patch uint64) bound {
+	if patch == math.MaxUint64 {
+		return nextMinorBound(major, minor)
+	}
+	return bound{v: newLowestVersion(major, minor, patch+1)}
+}
+
+// from returns the interval of the versions at or above an upper bound
+// computed by nextMajorBound or the like, or none when it is unbounded as
+// there are no versions above it.
+func from(b bound) []Interval {
+	if b.v == nil {
+		return nil
+	}
+	return []Interval{{min: bound{v: b.v, inclusive: true}}}
+}
+
+// compareMin compares two lower bounds. An unbounded min is the lowest and an
+// inclusive min is lower than an exclusive one on the same version.
+func compareMin(a, b bound) int {
+	switch {
+	case a.v == nil && b.v == nil:
+		return 0
+	case a.v == nil:
+		return -1
+	case b.v == nil:
+		return 1
+	}
+	if d := a.v.Compare(b.v); d != 0 {
+		return d
+	}
+	if a.inclusive == b.inclusive {
+		return 0
+	}
+	if a.inclusive {
+		return -1
+	}
+	return 1
+}
+
+// compareMax compares two upper bounds. An unbounded max is the highest and an
+// exclusive max is lower than an inclusive one on the same version.
+func compareMax(a, b bound) int {
+	switch {
+	case a.v == nil && b.v == nil:
+		return 0
+	case a.v == nil:
+		return 1
+	case b.v == nil:
+		return -1
+	}
+	if d := a.v.Compare(b.v); d != 0 {
+		return d
+	}
+	if a.inclusive == b.inclusive {
+		return 0
+	}
+	if a.inclusive {
+		return 1
+	}
+	return -1
+}
+
+// Empty reports if no version can fall within the interval.
+func (i Interval) Empty() bool {
+	if i.min.v == nil || i.max.v == nil {
+		return false
+	}
+	d := i.min.v.Compare(i.max.v)
+	if d != 0 {
+		return d > 0
+	}
+	return !i.min.inclusive || !i.max.inclusive
+}
+
+// Contains reports if a version falls within the interval. A nil version
+// falls within none.
+func (i Interval) Contains(v *Version) bool {
+	if v == nil {
+		return false
+	}
+	if i.min.v != nil {
+		d := v.Compare(i.min.v)
+		if d < 0 || (d == 0 && !i.min.inclusive) {
+			return false
+		}
+	}
+	if i.max.v != nil {
+		d := v.Compare(i.max.v)
+		if d > 0 || (d == 0 && !i.max.inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// covers reports if every version in o is also within i.
+func (i Interval) covers(o Interval) bool {
+	return compareMin(i.min, o.min) <= 0 && compareMax(o.max, i.max) <= 0
+}
+
+// Intersect returns the interval of versions within both intervals. The result
+// is empty when the intervals do not overlap.
+func (i Interval) Intersect(o Interval) Interval {
+	r := i
+	if compareMin(o.min, r.min) > 0 {
+		r.min = o.min
+	}
+	if compareMax(o.max, r.max) < 0 {
+		r.max = o.max
+	}
+	return r
+}
+
+// Union returns the versions within either interval as the fewest intervals,
+// in ascending order. Intervals that overlap or are adjacent are merged into
+// one while others are returned separately. Empty intervals are dropped.
+func (i Interval) Union(o Interval) []Interval {
+	return mergeIntervals([]Interval{i, o})
+}
+
+// Adjacent reports if the intervals meet without overlapping, so one ends
+// where the other begins with the version they meet on in exactly one of them.
+// For example, [1.0.0, 2.0.0) and [2.0.0, 3.0.0) are adjacent.
+func (i Interval) Adjacent(o Interval) bool {
+	if i.Empty() || o.Empty() {
+		return false
+	}
+	if compareMin(o.min, i.min) < 0 {
+		i, o = o, i
+	}
+	return i.max.v != nil && o.min.v != nil && i.max.v.Equal(o.min.v) &&
+		i.max.inclusive != o.min.inclusive
+}
+
+// String renders the interval as constraints, such as `>=1.2.0 <2.0.0`. An
+// interval holding a single version is rendered as that version.
+func (i Interval) String() string {
+	if i.min.v != nil && i.max.v != nil && i.min.inclusive && i.max.inclusive && i.min.v.Equal(i.max.v) {
+		return i.min.v.String()
+	}
+
+	var s []string
+	switch {
+	case i.min.v == nil:
+	case i.min.inclusive:
+		s = append(s, ">="+i.min.v.String())
+	default:
+		s = append(s, ">"+i.min.v.String())
+	}
+	switch {
+	case i.max.v == nil:
+	case i.max.inclusive:
+		s = append(s, "<="+i.max.v.String())
+	default:
+		s = append(s, "<"+i.max.v.String())
+	}
+	if len(s) == 0 {
+		return "*"
+	}
+	return strings.Join(s, " ")
+}
+
+// touches reports if the interval i, which starts no later than o, overlaps
+// or is directly adjacent to o so the two can be merged into one.
+func (i Interval) touches(o Interval) bool {
+	if i.max.v == nil || o.min.v == nil {
+		return true
+	}
+	d := i.max.v.Compare(o.min.v)
+	if d != 0 {
+		return d > 0
+	}
+	return i.max.inclusive || o.min.inclusive
+}
+
+// mergeIntervals sorts the intervals, drops empty ones, and merges those that
+// overlap or touch.
+func mergeIntervals(in []Interval) []Interval {
+	s := make([]Interval, 0, len(in))
+	for _, i := range in {
+		if !i.Empty() {
+			s = append(s, i)
+		}
+	}
+	sort.Slice(s, func(a, b int) bool {
+		return compareMin(s[a].min, s[b].min) < 0
+	})
+
+	out := s[:0]
+	for _, i := range s {
+		if len(out) > 0 && out[len(out)-1].touches(i) {
+			last := &out[len(out)-1]
+			if compareMax(i.max, last.max) > 0 {
+				last.max = i.max
+			}
+			continue
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// releaseInterval rewrites an interval into the normalized form used for
+// releases, an inclusive release min and an exclusive release max.
+func releaseInterval(i Interval) Interval {
+	r := Interval{}
+	if i.min.v != nil {
+		v := i.min.v
+		switch {
+		case v.Prerelease() != "":
+			// A release above a prerelease is at least its release.
+			r.min = bound{v: newCoreVersion(v.major, v.minor, v.patch), inclusive: true}
+		case i.min.inclusive:
+			r.min = bound{v: newCoreVersion(v.major, v.minor, v.patch), inclusive: true}
+		default:
+			next := nextRelease(v.major, v.minor, v.patch)
+			if next == nil {
+				// There is no release above the largest possible.
+				core := newCoreVersion(v.major, v.minor, v.patch)
+				return Interval{min: bound{v: core, inclusive: true}, max: bound{v: core}}
+			}
+			r.min = bound{v: next, inclusive: true}
+		}
+
+		// 0.0.0 is the lowest release so the set is unbounded below.
+		if r.min.v.major == 0 && r.min.v.minor == 0 && r.min.v.patch == 0 {
+			r.min = bound{}
+		}
+	}
+	if i.max.v != nil {
+		v := i.max.v
+		switch {
+		case v.Prerelease() != "":
+			// A release below a prerelease is below its release.
+			r.max = bound{v: newCoreVersion(v.major, v.minor, v.patch)}
+		case i.max.inclusive:
+			// There is no release above the largest possible, leaving the
+			// interval unbounded above.
+			r.max = bound{v: nextRelease(v.major, v.minor, v.patch)}
+		default:
+			r.max = bound{v: newCoreVersion(v.major, v.minor, v.patch)}
+		}
+
+		// No release is below 0.0.0 so the set is empty. The min is set so
+		// the interval reports itself as empty.
+		if r.min.v == nil && r.max.v != nil && r.max.v.major == 0 && r.max.v.minor == 0 && r.max.v.patch == 0 {
+			r.min = bound{v: r.max.v, inclusive: true}
+		}
+	}
+	return r
+}
+
+// newVersionSet creates a set from the intervals a constraint covers. When
+// pre is false no prereleases are admitted.
+func newVersionSet(in []Interval, pre bool) versionSet {
+	r := make([]Interval, len(in))
+	for k, i := range in {
+		r[k] = releaseInterval(i)
+	}
+	s := versionSet{releases: mergeIntervals(r)}
+	if pre {
+		s.prereleases = mergeIntervals(in)
+	}
+	return s
+}
+
+func intersectIntervals(a, b []Interval) []Interval {
+	var out []Interval
+	for _, i := range a {
+		for _, o := range b {
+			if r := i.Intersect(o); !r.Empty() {
+				out = append(out, r)
+			}
+		}
+	}
+	return mergeIntervals(out)
+}
+
+func coveredBy(a, b []Interval) bool {
+	for _, i := range a {
+		found := false
+		for _, o := range b {
+			if o.covers(i) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// intersect returns the versions admitted by both sets.
+func (s versionSet) intersect(o versionSet) versionSet {
+	return versionSet{
+		releases:    intersectIntervals(s.releases, o.releases),
+		prereleases: intersectIntervals(s.prereleases, o.prereleases),
+	}
+}
+
+// subtract returns the versions admitted by s and not by o.
+func (s versionSet) subtract(o versionSet) versionSet {
+	return versionSet{
+		releases:    subtractIntervals(s.releases, o.releases),
+		prereleases: subtractIntervals(s.prereleases, o.prereleases),
+	}
+}
+
+// union returns the versions admitted by either set.
+func (s versionSet) union(o versionSet) versionSet {
+	return versionSet{
+		releases:    mergeIntervals(append(append([]Interval{}, s.releases...), o.releases...)),
+		prereleases: mergeIntervals(append(append([]Interval{}, s.prereleases...), o.prereleases...)),
+	}
+}
+
+// subsetOf reports if every version admitted by s is admitted by o.
+func (s versionSet) subsetOf(o versionSet) bool {
+	return coveredBy(s.releases, o.releases) && coveredBy(s.prereleases, o.prereleases)
+}
+
+// empty reports if the set admits no versions.
+func (s versionSet) empty() bool {
+	return len(s.releases) == 0 && len(s.prereleases) == 0
+}
+
+// allReleases reports if the set admits every release version.
+func (s versionSet) allReleases() bool {
+	return coveredBy([]Interval{anyInterval}, s.releases)
+}
+
+// contains reports if a version is admitted by the set.
+func (s versionSet) contains(v *Version) bool {
+	in := s.releases
+	if v.Prerelease() != "" {
+		in = s.prereleases
+	}
+	for _, i := range in {
+		if i.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// intervals returns the ranges of versions the constraint covers before any
+// prerelease filtering is applied.
+func (c *constraint) intervals(o *MatchOptions) []Interval {
+	c = c.resolve(o)
+	con := c.con
+	at := bound{v: con, inclusive: true}
+	after := bound{v: con}
+
+	// nextMajor and nextMinor are the exclusive upper bounds used by the
+	// wildcard and range operators. They sit below any prerelease of the next
+	// series as those are outside of the range as well.
+	nextMajor := nextMajorBound(con.major)
+	nextMinor := nextMinorBound(con.major, con.minor)
+
+	switch c.origfunc {
+	case "", "=":
+		if c.dirty {
+			return c.tildeIntervals(o)
+		}
+		return []Interval{{min: at, max: at}}
+	case "!=":
+		if !c.dirty {
+			return []Interval{{max: after}, {min: after}}
+		}
+		// A wildcard excludes the whole major or minor series.
+		switch {
+		case c.minorDirty:
+			below := Interval{max: bound{v: newLowestVersion(con.major, 0, 0)}}
+			return append([]Interval{below}, from(nextMajor)...)
+		case c.patchDirty:
+			below := Interval{max: bound{v: newLowestVersion(con.major, con.minor, 0)}}
+			return append([]Interval{below}, from(nextMinor)...)
+		}
+		return []Interval{{max: after}, {min: after}}
+	case ">":
+		switch {
+		case c.minorDirty:
+			return from(nextMajor)
+		case c.patchDirty:
+			return from(nextMinor)
+		}
+		return []Interval{{min: after}}
+	case "<":
+		return []Interval{{max: after}}
+	case ">=", "=>":
+		return []Interval{{min: at}}
+	case "<=", "=<":
+		if !c.dirty {
+			return []Interval{{max: at}}
+		}
+		if c.minorDirty {
+			return []Interval{{max: nextMajor}}
+		}
+		return []Interval{{max: nextMinor}}
+	case "~", "~>":
+		return c.tildeIntervals(o)
+	case "^":
+		switch {
+		case con.major > 0 || c.minorDirty || o.Caret == CaretMajor:
+			return []Interval{{min: at, max: nextMajor}}
+		case con.minor > 0 || c.patchDirty || o.Caret == CaretMinor:
+			return []Interval{{min: at, max: nextMinor}}
+		}
+		return []Interval{{min: at, max: nextPatchBound(0, 0, con.patch)}}
+	}
+
+	return nil
+}
+
+func (c *constraint) tildeIntervals(o *MatchOptions) []Interval {
+	con := c.con
+	at := bound{v: con, inclusive: true}
+
+	// ~0.0.0 is a special case where all versions are accepted.
+	if con.major == 0 && con.minor == 0 && con.patch == 0 && !c.minorDirty && !c.patchDirty {
+		return []Interval{{min: at}}
+	}
+	if c.minorDirty || (o.Pessimistic == PessimisticRubyGems && c.origfunc == "~>" && c.patchDirty) {
+		return []Interval{{min: at, max: nextMajorBound(con.major)}}
+	}
+	return []Interval{{min: at, max: nextMinorBound(con.major, con.minor)}}
+}
+
+// prereleasesOf returns the interval holding the prereleases of the release v
+// is, or is a prerelease of.
+func prereleasesOf(v *Version) Interval {
+	return Interval{
+		min: bound{v: newLowestVersion(v.major, v.minor, v.patch), inclusive: true},
+		max: bound{v: newCoreVersion(v.major, v.minor, v.patch)},
+	}
+}
+
+// set returns the versions admitted by the constraint on its own, read with
+// its own options when it has them and otherwise with o. When the prerelease
+// policy is PrereleaseScoped the prereleases are further limited by partSets.
+// Versions are placed in the set by precedence, see region.
+func (c *constraint) set(o *MatchOptions) versionSet {
+	o = clauseOptions(c, o)
+	c = c.resolve(o)
+	in := c.intervals(o)
+	switch {
+	case c.origfunc == "!=" && c.patchDirty && c.con.Prerelease() == "" && o.Prerelease != PrereleaseExplicit:
+		// A prerelease is never equal to a 1.2.x style wildcard so it is not
+		// excluded by one.
+		s := newVersionSet(in, false)
+		s.prereleases = []Interval{anyInterval}
+		return s
+	case o.Prerelease != PrereleaseExplicit:
+		return newVersionSet(in, true)
+	case c.origfunc == "!=" && !c.dirty:
+		// An exact not equal never filters prereleases out.
+		return newVersionSet(in, true)
+	case c.con.Prerelease() == "":
+		return newVersionSet(in, false)
+	}
+
+	s := newVersionSet(in, true)
+	if o.Tilde == TildePrereleaseSameRelease && (c.origfunc == "~" || c.origfunc == "~>") {
+		s.prereleases = intersectIntervals(s.prereleases, []Interval{prereleasesOf(c.con)})
+	}
+	return s
+}
+
+// region returns the versions the constraint may treat differently than its
+// set says. Sets order versions by precedence alone, so they cannot tell
+// apart the versions sharing a precedence with the version a clause compares
+// against when MetadataEqual or MetadataOrdered looks at their metadata. Nor
+// can they order prereleases the way LegacyPrereleaseOrder does. The region
+// is empty otherwise. With FoldPrereleaseCase the set holds the versions with
+// their prereleases folded to lower case, the same way they are checked.
+func (c *constraint) region(o *MatchOptions) versionSet {
+	o = clauseOptions(c, o)
+	r := c.resolve(o)
+	v := r.con
+	equality := !r.dirty && (r.origfunc == "" || r.origfunc == "=" || r.origfunc == "!=")
+
+	// A version without metadata is the lowest of those sharing its
+	// precedence when metadata is ordered, so >= and < admit the same
+	// versions as they do by precedence.
+	var meta bool
+	switch o.Metadata {
+	case MetadataEqual:
+		meta = equality
+	case MetadataOrdered:
+		meta = v.Metadata() != "" || equality || r.origfunc == ">" ||
+			!r.dirty && (r.origfunc == "<=" || r.origfunc == "=<")
+	}
+	pre := o.LegacyPrereleaseOrder && v.Prerelease() != ""
+	if !meta && !pre {
+		return versionSet{}
+	}
+
+	// The versions sharing a precedence with a release are releases, while
+	// the region of a prerelease is kept to the prereleases of its release.
+	core := newCoreVersion(v.major, v.minor, v.patch)
+	if v.Prerelease() == "" {
+		return versionSet{releases: []Interval{releaseInterval(Interval{
+			min: bound{v: core, inclusive: true},
+			max: bound{v: core, inclusive: true},
+		})}}
+	}
+	return versionSet{prereleases: []Interval{{
+		min: bound{v: newLowestVersion(v.major, v.minor, v.patch), inclusive: true},
+		max: bound{v: core},
+	}}}
+}
+
+// sets returns the versions the constraint may admit and those it surely
+// admits. Both are its set unless its region is not empty.
+func (c *constraint) sets(o *MatchOptions) (may, must versionSet) {
+	s := c.set(o)
+	r := c.region(o)
+	if r.empty() {
+		return s, s
+	}
+	return s.union(r), s.subtract(r)
+}
+
+// andSets returns the versions every constraint in an AND group may admit
+// and those they surely admit, each part of the group read with its own
+// options.
+func andSets(group []*constraint, o *MatchOptions) (may, must versionSet) {
+	if !mixedGroup(group) {
+		return partSets(group, o)
+	}
+	may, must = universe(), universe()
+	for _, p := range groupParts(group, o, true) {
+		pmay, pmust := partSets(p.clauses, p.opts)
+		may, must = may.intersect(pmay), must.intersect(pmust)
+	}
+	return may, must
+}
+
+// partSets returns the versions every constraint in an AND group read with
+// the options o may admit and those they surely admit.
+func partSets(group []*constraint, o *MatchOptions) (may, must versionSet) {
+	may, must = universe(), universe()
+	for _, c := range group {
+		cmay, cmust := c.sets(o)
+		may, must = may.intersect(cmay), must.intersect(cmust)
+	}
+
+	// Scoped prereleases must share a release with a prerelease the group
+	// names.
+	if o.Prerelease == PrereleaseScoped {
+		var scope []Interval
+		for _, c := range group {
+			if c.con.Prerelease() != "" {
+				scope = append(scope, prereleasesOf(c.con))
+			}
+		}
+		may.prereleases = intersectIntervals(may.prereleases, scope)
+		must.prereleases = intersectIntervals(must.prereleases, scope)
+	}
+	return may, must
+}
+
+// sets returns the versions the constraints may admit and those they surely
+// admit using the options they were created with. The two are the same
+// unless a clause has a region.
+func (cs Constraints) sets() (may, must versionSet) {
+	for _, group := range cs.constraints {
+		gmay, gmust := andSets(group, &cs.opts)
+		may, must = may.union(gmay), must.union(gmust)
+	}
+	return may, must
+}
+
+// exact reports if the versions the constraints may admit are those they
+// surely admit, so their sets describe them exactly.
+func exact(may, must versionSet) bool {
+	return may.subsetOf(must)
+}
+
+// canonicalClauses renders the clauses of the constraints along with the
+// options each is read with, in an order that does not depend on the order
+// they were written in.
+func (cs Constraints) canonicalClauses() string {
+	groups := make([]string, len(cs.constraints))
+	for k, group := range cs.constraints {
+		clauses := make([]string, len(group))
+		for i, c := range group {
+			clauses[i] = fmt.Sprintf("%s%+v", c.string(), *clauseOptions(c, &cs.opts))
+		}
+		sort.Strings(clauses)
+		groups[k] = strings.Join(clauses, " ")
+	}
+	sort.Strings(groups)
+	return strings.Join(groups, " || ")
+}
+
+// Eq reports if the constraints admit exactly the same versions as o. The
+// versions admitted are compared rather than how the constraints are written,
+// so `>=1.2.0 <2.0.0` and `^1.2.0` are equal. Each side uses the options it was
+// created with. A nil o admits no versions.
+//
+// With MetadataEqual, MetadataOrdered, or LegacyPrereleaseOrder the versions
+// sharing a precedence with those the constraints name cannot always be
+// compared. Constraints relying on them are only equal when they are written
+// with the same clauses, in any order, so `=1.2.3+abc` with MetadataEqual is
+// not equal to `=1.2.3+def`.
+func (cs Constraints) Eq(o *Constraints) bool {
+	if o == nil {
+		o = &Constraints{}
+	}
+	amay, amust := cs.sets()
+	bmay, bmust := o.sets()
+	if amay.subsetOf(bmust) && bmay.subsetOf(amust) {
+		return true
+	}
+	if exact(amay, amust) && exact(bmay, bmust) {
+		return false
+	}
+	return cs.canonicalClauses() == o.canonicalClauses()
+}
+
+// MatchesRange reports if the constraints admit every version between lo and
+// hi. When inclusive is true lo and hi are part of the range, otherwise only
+// the versions between them are. A nil lo or hi leaves the range unbounded on
+// that side, while nil constraints admit no versions.
+//
+// Following the way constraints treat prereleases, the prereleases within the
+// range are only required to be admitted when lo or hi is a prerelease.
+// Otherwise only the releases within the range are considered. With
+// MetadataEqual, MetadataOrdered, or LegacyPrereleaseOrder the versions
+// sharing a precedence with those the constraints name are not counted as
+// admitted, so `!=1.2.3+abc` with MetadataEqual does not match 1.2.0 to 1.3.0.
+func MatchesRange(c *Constraints, lo, hi *Version, inclusive bool) bool {
+	if c == nil {
+		c = &Constraints{}
+	}
+	i := Interval{
+		min: bound{v: lo, inclusive: inclusive},
+		max: bound{v: hi, inclusive: inclusive},
+	}
+	pre := (lo != nil && lo.Prerelease() != "") || (hi != nil && hi.Prerelease() != "")
+	_, must := c.sets()
+	return newVersionSet([]Interval{i}, pre).subsetOf(must)
+}
@@ -0,0 +1,570 @@
+package semver
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestConstraintSetMatchesCheck(t *testing.T) {
+	constraints := []string{
+		"*", "1.2", "1.2.3", "=1.2.3-beta.1", "!=1.2.3", "!=1.x", "!=1.2.x",
+		">1.2.3", ">1", ">1.2", "<1.2.3", "<1.x", ">=1.2", ">=1.2.3-beta",
+		"<=1.2.3", "<=1.x", "<=1.2", "~1.2.3", "~1", "~1.2", "~0.0.0", "~*", "~>1.2", "~1.2.3-beta",
+		"^1.2.3", "^0.2.3", "^0.0.3", "^0", "^0.0", "^1.x", "^1.2.3-alpha",
+		">=1.1, <2, !=1.2.3 || > 3", "1.2 - 1.4.5", "<1.0.0 || >=2.0.0-0",
+		">=1.2.3-beta, <2",
+	}
+	versions := []string{
+		"0.0.0", "0.0.1", "0.0.3", "0.0.4", "0.1.0", "0.2.3", "0.2.9", "0.3.0",
+		"1.0.0", "1.1.9", "1.2.0", "1.2.2", "1.2.3", "1.2.4", "1.3.0", "1.4.5",
+		"1.4.6", "2.0.0", "2.0.1", "3.0.0", "3.0.1", "10.0.0", "1.2.3-alpha",
+		"1.2.3-beta", "1.2.3-beta.1", "1.2.4-alpha", "2.0.0-0", "2.0.0-rc.1",
+		"0.0.0-0",
+	}
+
+	options := []MatchOptions{
+		{},
+		{Prerelease: PrereleaseScoped},
+		{Prerelease: PrereleaseInclude},
+		{Caret: CaretMinor},
+		{Caret: CaretMajor},
+		{Tilde: TildePrereleaseSameRelease},
+		{Partial: PartialZero},
+		{Pessimistic: PessimisticRubyGems},
+	}
+
+	for _, o := range options {
+		for _, cs := range constraints {
+			c, err := NewConstraintWithOptions(cs, o)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %s", cs, err)
+			}
+			s, _ := c.sets()
+			for _, vs := range versions {
+				v := MustParse(vs)
+				if c.Check(v) != s.contains(v) {
+					t.Errorf("set for %q with %+v disagrees with Check on %s: expected %t", cs, o, vs, c.Check(v))
+				}
+			}
+		}
+	}
+}
+
+func TestConstraintSetOverflow(t *testing.T) {
+	// Bounds past the largest segments carry or become unbounded rather than
+	// wrapping around to 0.
+	m := "18446744073709551615"
+	r := strings.NewReplacer("M", m)
+	constraints := []string{
+		"^M", "^M.M", "^M.M.M", "^0.M", "^0.0.M", "^1.M.M", "~M", "~M.M", "~1.M",
+		"~0.0.M", "~>M.M", "M.x", "1.M.x", "M.M.x", "!=M.x", "!=1.M.x", "!=M.M.x",
+		">M", ">M.M", ">1.M", ">M.M.M", ">=M.M.M", "<=M.x", "<=1.M", "<=M.M",
+		"<=M.M.M", "<M.M.M", "M.M.M", "1.M.M - M.M.M",
+	}
+	versions := []string{
+		"0.0.0", "0.0.1", "0.1.0", "1.0.0", "2.0.0", "0.0.M", "0.M.0", "0.M.M",
+		"1.M.0", "1.M.M", "M.0.0", "M.5.3", "M.M.0", "M.M.M", "M.M.M-0",
+		"M.M.M-beta", "1.M.M-0",
+	}
+
+	options := []MatchOptions{
+		{},
+		{Prerelease: PrereleaseInclude},
+		{Caret: CaretMajor},
+		{Caret: CaretMinor},
+		{Pessimistic: PessimisticRubyGems},
+	}
+
+	for _, o := range options {
+		for _, cs := range constraints {
+			cs = r.Replace(cs)
+			c, err := NewConstraintWithOptions(cs, o)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %s", cs, err)
+			}
+			s, _ := c.sets()
+			for _, vs := range versions {
+				v := MustParse(r.Replace(vs))
+				if c.Check(v) != s.contains(v) {
+					t.Errorf("set for %q with %+v disagrees with Check on %s: expected %t", cs, o, v, c.Check(v))
+				}
+			}
+		}
+	}
+
+	for cs, expected := range map[string]string{
+		"^M":      ">=M.0.0",
+		"~M.M":    ">=M.M.0",
+		"^0.0.M":  ">=0.0.M <0.1.0",
+		"<=M.M.M": "*",
+		">M.M.M":  "",
+		">M":      "",
+	} {
+		c := mustConstraint(t, r.Replace(cs))
+		var out []string
+		for _, rg := range c.Ranges() {
+			out = append(out, rg.String())
+		}
+		if got := strings.Join(out, " || "); got != r.Replace(expected) {
+			t.Errorf("expected %s to cover %s but got %s", cs, expected, got)
+		}
+	}
+}
+
+func TestVersionSetSubset(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		subset bool
+	}{
+		{">=1.2", ">=1.0", true},
+		{">=1.0", ">=1.2", false},
+		{"^1.2.3", ">=1.2.3, <2.0.0", true},
+		{">=1.2.3, <2.0.0", "^1.2.3", true},
+		{"<=1.2.3", "<1.2.4", true},
+		{"<1.2.4", "<=1.2.3", true},
+		{">=1.2.0-0", ">=1.2.0", false},
+		{">=1.2.0", ">=1.2.0-0", true},
+		{"~1.2 || ~1.3", ">=1.2, <1.4", true},
+		{"1.2.3", "!=1.2.3", false},
+	}
+
+	for _, tc := range tests {
+		a, err := NewConstraint(tc.a)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.a, err)
+		}
+		b, err := NewConstraint(tc.b)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.b, err)
+		}
+
+		as, _ := a.sets()
+		bs, _ := b.sets()
+		if got := as.subsetOf(bs); got != tc.subset {
+			t.Errorf("expected %q subset of %q to be %t but got %t", tc.a, tc.b, tc.subset, got)
+		}
+	}
+}
+
+func TestVersionSetEmpty(t *testing.T) {
+	tests := []struct {
+		constraint string
+		empty      bool
+	}{
+		{">2, <1", true},
+		{">=1.2.3, <=1.2.3", false},
+		{">1.2.3, <1.2.4", true},
+		{">1.2.3-0, <1.2.4-0", false},
+		{"=1.2.3-beta, =1.2.3", true},
+		{"^1.2, <1.2", true},
+		{"^1.2, <1.2 || 3", false},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.constraint, err)
+		}
+
+		if s, _ := c.sets(); s.empty() != tc.empty {
+			t.Errorf("expected %q empty to be %t", tc.constraint, tc.empty)
+		}
+	}
+}
+
+func TestConstraintsEq(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{">=1.2.0 <2.0.0", "^1.2.0", true},
+		{"~1.2.3", ">=1.2.3, <1.3", true},
+		{"1.2 - 1.4", ">=1.2, <1.5", true},
+		{"~1.2 || ~1.3", ">=1.2, <1.4", true},
+		{">2, <1", "<0.0.0", true},
+		{"^1.2", ">=1.2.0, <=2.0.0", false},
+		{">=1.2, !=1.4.2", ">=1.2", false},
+		{"^1.2.3-beta", "^1.2.3", false},
+	}
+
+	for _, tc := range tests {
+		a, err := NewConstraint(tc.a)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.a, err)
+		}
+		b, err := NewConstraint(tc.b)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.b, err)
+		}
+
+		if got := a.Eq(b); got != tc.equal {
+			t.Errorf("expected %q eq %q to be %t", tc.a, tc.b, tc.equal)
+		}
+		if got := b.Eq(a); got != tc.equal {
+			t.Errorf("expected %q eq %q to be %t", tc.b, tc.a, tc.equal)
+		}
+	}
+}
+
+func TestMatchesRange(t *testing.T) {
+	tests := []struct {
+		constraint string
+		lo, hi     string
+		inclusive  bool
+		expected   bool
+	}{
+		{"^1.2", "1.2.0", "1.9.9", true, true},
+		{"^1.2", "1.2.0", "2.0.0", true, false},
+		{"^1.2", "1.2.0", "2.0.0", false, true},
+		{"^1.2", "1.1.9", "2.0.0", false, false},
+		{"^1.2", "1.1.0", "1.5.0", true, false},
+		{">=1.2, !=1.4.2", "1.3.0", "1.5.0", true, false},
+		{">=1.2, !=1.4.2", "1.4.2", "1.5.0", false, true},
+		{"~1.2 || ~1.3", "1.2.0", "1.3.9", true, true},
+		{"~1.2 || ~1.4", "1.2.0", "1.4.0", true, false},
+		{">=1.2", "1.2.0", "", true, true},
+		{"<2", "", "1.9.9", true, true},
+		{"^1.2", "", "1.9.9", true, false},
+		{"^1.2", "1.2.0-0", "1.5.0", true, false},
+		{"^1.2.0-0", "1.2.0-0", "1.5.0", true, true},
+		{"^1.2", "1.5.0", "1.2.0", true, true},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.constraint, err)
+		}
+
+		var lo, hi *Version
+		if tc.lo != "" {
+			lo = MustParse(tc.lo)
+		}
+		if tc.hi != "" {
+			hi = MustParse(tc.hi)
+		}
+
+		if a := MatchesRange(c, lo, hi, tc.inclusive); a != tc.expected {
+			t.Errorf("expected %q to match range %q to %q (inclusive %t) to be %t", tc.constraint, tc.lo, tc.hi, tc.inclusive, tc.expected)
+		}
+	}
+
+	// 1.2.3+abc is not admitted so the range from 1.2.0 to 1.3.0 is not.
+	c, err := NewConstraintWithOptions("!=1.2.3+abc", MatchOptions{Metadata: MetadataEqual})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if MatchesRange(c, MustParse("1.2.0"), MustParse("1.3.0"), true) || !MatchesRange(c, MustParse("1.2.4"), MustParse("1.3.0"), true) {
+		t.Errorf("expected %s to only match the range above 1.2.3", c)
+	}
+
+	// With PartialZero !=1.2 is !=1.2.0, which does not filter prereleases.
+	c, err = NewConstraintWithOptions("!=1.2", MatchOptions{Partial: PartialZero})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !MatchesRange(c, MustParse("1.0.0-alpha"), MustParse("1.0.0-beta"), true) {
+		t.Errorf("expected %s to match the prereleases of 1.0.0", c)
+	}
+}
+
+func TestNilRanges(t *testing.T) {
+	lo, hi := MustParse("1.2.0"), MustParse("1.4.0")
+	if MatchesRange(nil, lo, hi, true) || MatchesRange(nil, nil, nil, true) {
+		t.Error("expected nil constraints not to match a range")
+	}
+	if !MatchesRange(mustConstraint(t, "<2"), nil, hi, true) {
+		t.Error("expected <2 to match the range up to 1.4.0 with a nil lower bound")
+	}
+	if mustConstraint(t, "^1").Eq(nil) {
+		t.Error("expected ^1 not to equal nil constraints")
+	}
+	if !mustConstraint(t, ">2, <1").Eq(nil) {
+		t.Error("expected constraints admitting no versions to equal nil constraints")
+	}
+	if !Union(nil, nil).Eq(nil) {
+		t.Error("expected the union of nils to equal nil constraints")
+	}
+}
+
+func TestInterval(t *testing.T) {
+	i := NewInterval(MustParse("1.2.0"), true, MustParse("2.0.0"), false)
+
+	if i.Min().String() != "1.2.0" || !i.IncludesMin() || i.Max().String() != "2.0.0" || i.IncludesMax() {
+		t.Errorf("unexpected bounds for %s", i)
+	}
+	if i.String() != ">=1.2.0 <2.0.0" {
+		t.Errorf("expected >=1.2.0 <2.0.0 but got %s", i)
+	}
+	if i.Empty() {
+		t.Errorf("expected %s not to be empty", i)
+	}
+
+	for v, expected := range map[string]bool{
+		"1.2.0": true, "1.9.9": true, "2.0.0-beta": true, "2.0.0": false, "1.1.9": false,
+	} {
+		if got := i.Contains(MustParse(v)); got != expected {
+			t.Errorf("expected %s contains %s to be %t", i, v, expected)
+		}
+	}
+
+	u := NewInterval(nil, true, nil, true)
+	if u.Min() != nil || u.Max() != nil || u.IncludesMin() || u.IncludesMax() || u.String() != "*" {
+		t.Errorf("expected an unbounded interval but got %s", u)
+	}
+	if !u.Contains(MustParse("0.0.0-0")) {
+		t.Error("expected an unbounded interval to contain every version")
+	}
+	if u.Contains(nil) {
+		t.Error("expected an unbounded interval not to contain nil")
+	}
+
+	single := NewInterval(MustParse("1.2.3"), true, MustParse("1.2.3"), true)
+	if single.String() != "1.2.3" || single.Empty() {
+		t.Errorf("expected the single version 1.2.3 but got %s", single)
+	}
+	if e := NewInterval(MustParse("1.2.3"), true, MustParse("1.2.3"), false); !e.Empty() {
+		t.Errorf("expected %s to be empty", e)
+	}
+}
+
+func TestIntervalAlgebra(t *testing.T) {
+	v := MustParse
+	a := NewInterval(v("1.0.0"), true, v("2.0.0"), false)
+	b := NewInterval(v("1.5.0"), true, v("3.0.0"), false)
+	c := NewInterval(v("2.0.0"), true, v("3.0.0"), false)
+	d := NewInterval(v("4.0.0"), true, nil, false)
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"a intersect b", a.Intersect(b).String(), ">=1.5.0 <2.0.0"},
+		{"b intersect a", b.Intersect(a).String(), ">=1.5.0 <2.0.0"},
+		{"a union b", intervalStrings(a.Union(b)), ">=1.0.0 <3.0.0"},
+		{"a union c", intervalStrings(a.Union(c)), ">=1.0.0 <3.0.0"},
+		{"a union d", intervalStrings(a.Union(d)), ">=1.0.0 <2.0.0, >=4.0.0"},
+		{"d union a", intervalStrings(d.Union(a)), ">=1.0.0 <2.0.0, >=4.0.0"},
+	}
+	for _, tc := range tests {
+		if tc.got != tc.expected {
+			t.Errorf("expected %s to be %q but got %q", tc.name, tc.expected, tc.got)
+		}
+	}
+
+	if !a.Intersect(d).Empty() {
+		t.Errorf("expected %s and %s not to intersect", a, d)
+	}
+
+	adjacent := []struct {
+		a, b     Interval
+		expected bool
+	}{
+		{a, c, true},
+		{c, a, true},
+		{a, b, false},
+		{a, d, false},
+		{NewInterval(nil, false, v("2.0.0"), true), c, false},
+		{NewInterval(nil, false, v("2.0.0"), true), NewInterval(v("2.0.0"), false, nil, false), true},
+	}
+	for _, tc := range adjacent {
+		if got := tc.a.Adjacent(tc.b); got != tc.expected {
+			t.Errorf("expected %s adjacent to %s to be %t", tc.a, tc.b, tc.expected)
+		}
+	}
+}
+
+func intervalStrings(in []Interval) string {
+	s := make([]string, len(in))
+	for k, i := range in {
+		s[k] = i.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+func TestConstraintSetsBracketCheck(t *testing.T) {
+	// The sets cannot describe every version these options admit, but the
+	// versions surely admitted must pass Check and those that pass Check must
+	// be ones the constraints may admit.
+	constraints := []string{
+		"=1.2.3+abc", "!=1.2.3+def", "1.2.3", "=1.2", "!=1.2.x", ">1.2.3", ">1.2.3+build.9",
+		"<1.2.3+build.11", "<=1.2.3", ">=1.2.3", "<1.2.3", "^1.2.3", "~1.2.3+build.2",
+		">1.2.3-100000000000000000000, <1.2.3-99999999999999999999", "<1.2.3-beta.2",
+		"=1.2.3+abc, !=1.2.3+def", ">=1.2.3-RC.1",
+	}
+	versions := []string{
+		"1.2.0", "1.2.0+x", "1.2.2", "1.2.3", "1.2.3+abc", "1.2.3+def", "1.2.3+build.2",
+		"1.2.3+build.9", "1.2.3+build.10", "1.2.3+build.11", "1.2.4", "2.0.0", "2.0.0+x",
+		"1.2.3-beta.1", "1.2.3-beta.3", "1.2.3-5aaa", "1.2.3-rc.1", "1.2.3-RC.2",
+	}
+	options := []MatchOptions{
+		{Metadata: MetadataEqual},
+		{Metadata: MetadataEqual, Partial: PartialZero},
+		{Metadata: MetadataOrdered},
+		{LegacyPrereleaseOrder: true},
+		{LegacyPrereleaseOrder: true, Prerelease: PrereleaseInclude},
+		{FoldPrereleaseCase: true, Prerelease: PrereleaseInclude},
+	}
+
+	for _, o := range options {
+		for _, cs := range constraints {
+			c, err := NewConstraintWithOptions(cs, o)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %s", cs, err)
+			}
+			may, must := c.sets()
+			for _, vs := range versions {
+				v := MustParse(vs)
+				in := v
+				if o.FoldPrereleaseCase {
+					in = foldPrerelease(v)
+				}
+				if must.contains(in) && !c.Check(v) {
+					t.Errorf("%q with %+v surely admits %s but Check rejects it", cs, o, vs)
+				}
+				if c.Check(v) && !may.contains(in) {
+					t.Errorf("%q with %+v admits %s but cannot according to its sets", cs, o, vs)
+				}
+			}
+		}
+	}
+}
+
+func TestConstraintsEqPrecedenceOptions(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		opts  MatchOptions
+		equal bool
+	}{
+		{"=1.2.3+abc", "=1.2.3+def", MatchOptions{Metadata: MetadataEqual}, false},
+		{"=1.2.3+abc", "=1.2.3+abc", MatchOptions{Metadata: MetadataEqual}, true},
+		{"=1.2.3+abc || ^2", "^2 || =1.2.3+abc", MatchOptions{Metadata: MetadataEqual}, true},
+		{"=1.2.3+abc", "=1.2.3", MatchOptions{Metadata: MetadataEqual}, false},
+		{"^1.2", ">=1.2.0, <2", MatchOptions{Metadata: MetadataEqual}, true},
+		{">1.2.3+build.9", ">1.2.3+build.10", MatchOptions{Metadata: MetadataOrdered}, false},
+		{">=1.2.3", "^1.2.3 || >=2", MatchOptions{Metadata: MetadataOrdered}, true},
+		{">=1.2.3-99999999999999999999", ">=1.2.3-100000000000000000000", MatchOptions{LegacyPrereleaseOrder: true}, false},
+		{"=1.2.3+abc", "=1.2.3+def", MatchOptions{}, true},
+	}
+	for _, tc := range tests {
+		a, err := NewConstraintWithOptions(tc.a, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := NewConstraintWithOptions(tc.b, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Eq(b) != tc.equal || b.Eq(a) != tc.equal {
+			t.Errorf("expected %q eq %q with %+v to be %t", tc.a, tc.b, tc.opts, tc.equal)
+		}
+	}
+}
+
+func TestConstraintSetsBracketCheckRandom(t *testing.T) {
+	// Random constraints written with small versions often name the versions
+	// checked, so the sets are compared with Check where the policies matter.
+	r := rand.New(rand.NewSource(1))
+	for _, o := range allMatchOptions() {
+		for n := 0; n < 10; n++ {
+			cs := randomSmallConstraint(r)
+			c, err := NewConstraintWithOptions(cs, o)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %s", cs, err)
+			}
+			may, must := c.sets()
+			for k := 0; k < 20; k++ {
+				v := randomSmallVersion(r)
+				in := v
+				if o.FoldPrereleaseCase {
+					in = foldPrerelease(v)
+				}
+				if must.contains(in) && !c.Check(v) {
+					t.Errorf("%q with %+v surely admits %s but Check rejects it", cs, o, v)
+				}
+				if c.Check(v) && !may.contains(in) {
+					t.Errorf("%q with %+v admits %s but cannot according to its sets", cs, o, v)
+				}
+			}
+		}
+	}
+}
+
+// allMatchOptions returns every combination of the policies in MatchOptions.
+func allMatchOptions() []MatchOptions {
+	var out []MatchOptions
+	for k := 0; k < 3*3*3*2*2*2*2*2; k++ {
+		n := k
+		next := func(base int) int {
+			d := n % base
+			n /= base
+			return d
+		}
+		out = append(out, MatchOptions{
+			Prerelease:            PrereleasePolicy(next(3)),
+			Metadata:              MetadataPolicy(next(3)),
+			Caret:                 CaretPolicy(next(3)),
+			Tilde:                 TildePolicy(next(2)),
+			Partial:               PartialPolicy(next(2)),
+			Pessimistic:           PessimisticPolicy(next(2)),
+			FoldPrereleaseCase:    next(2) == 1,
+			LegacyPrereleaseOrder: next(2) == 1,
+		})
+	}
+	return out
+}
+
+var (
+	smallPrereleases = []string{"alpha", "beta.1", "beta.2", "beta.10", "RC.1", "rc.1", "0", "10", "5aaa"}
+	smallMetadata    = []string{"abc", "def", "build.9", "build.10"}
+	smallOps         = []string{"", "=", "!=", ">", ">=", "<", "<=", "~", "~>", "^"}
+)
+
+// randomSmallVersion returns a version with small segments that may have a
+// prerelease and metadata.
+func randomSmallVersion(r *rand.Rand) *Version {
+	s := fmt.Sprintf("%d.%d.%d", r.Intn(3), r.Intn(3), r.Intn(4))
+	if r.Intn(3) == 0 {
+		s += "-" + smallPrereleases[r.Intn(len(smallPrereleases))]
+	}
+	if r.Intn(4) == 0 {
+		s += "+" + smallMetadata[r.Intn(len(smallMetadata))]
+	}
+	return MustParse(s)
+}
+
+// randomSmallConstraint returns constraints of up to two groups of up to two
+// clauses, with the versions written in full or in part.
+func randomSmallConstraint(r *rand.Rand) string {
+	groups := make([]string, 1+r.Intn(2))
+	for k := range groups {
+		clauses := make([]string, 1+r.Intn(2))
+		for i := range clauses {
+			clauses[i] = randomSmallClause(r)
+		}
+		groups[k] = strings.Join(clauses, ", ")
+	}
+	return strings.Join(groups, " || ")
+}
+
+func randomSmallClause(r *rand.Rand) string {
+	v := randomSmallVersion(r)
+	var s string
+	switch r.Intn(5) {
+	case 0:
+		s = fmt.Sprint(v.Major())
+	case 1:
+		s = fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+	case 2:
+		s = fmt.Sprintf("%d.%d.x", v.Major(), v.Minor())
+	default:
+		s = v.Original()
+	}
+	if r.Intn(10) == 0 {
+		return s + " - " + randomSmallVersion(r).Original()
+	}
+	return smallOps[r.Intn(len(smallOps))] + s
+}
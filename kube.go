@@ -0,0 +1,54 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// kubePrerelease matches the prereleases Kubernetes publishes, optionally
+// followed by the commit count of a git describe version.
+var kubePrerelease = newLazyRegexp(`^(alpha|beta|rc)\.[0-9]+(\.[0-9]+)?$`)
+
+// ParseKubeVersion parses a Kubernetes GitVersion, such as v1.27.3 or the
+// vendor builds v1.27.3-gke.100, v1.27.3-eks-2d98532, and v1.27.3+k3s1.
+// Kubernetes only publishes alpha, beta, and rc prereleases, so any other
+// suffix is a vendor build and is kept as build metadata rather than as a
+// prerelease. That way v1.27.3-gke.100 is parsed as 1.27.3+gke.100 and is
+// admitted by constraints such as >=1.27.
+func ParseKubeVersion(s string) (*Version, error) {
+	var metadata []string
+	rest := s
+	if i := strings.Index(rest, "+"); i >= 0 {
+		metadata = append(metadata, rest[i+1:])
+		rest = rest[:i]
+	}
+	if i := strings.Index(rest, "-"); i >= 0 && !kubePrerelease.MatchString(rest[i+1:]) {
+		metadata = append([]string{rest[i+1:]}, metadata...)
+		rest = rest[:i]
+	}
+
+	v, err := NewVersion(rest)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a Kubernetes version: %s", s, err)
+	}
+	m := strings.Join(metadata, ".")
+	if err := validateMetadata(m); m != "" && err != nil {
+		return nil, fmt.Errorf("%s is not a Kubernetes version: %s", s, err)
+	}
+	v.set(v.Prerelease(), m, s)
+	return v, nil
+}
+
+// NewKubeGate parses a Kubernetes style version gate, such as 1.27+ for
+// 1.27.0 and later, into constraints. The version may have a leading v and
+// may give the patch version, as in v1.27.3+.
+func NewKubeGate(gate string) (*Constraints, error) {
+	if !strings.HasSuffix(gate, "+") {
+		return nil, fmt.Errorf("%s is not a version gate ending in +", gate)
+	}
+	v, err := NewVersion(strings.TrimSuffix(gate, "+"))
+	if err != nil || v.Prerelease() != "" || v.Metadata() != "" {
+		return nil, fmt.Errorf("%s is not a version gate ending in +", gate)
+	}
+	return NewConstraint(">=" + v.Original())
+}
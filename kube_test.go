@@ -0,0 +1,69 @@
+package semver
+
+import "testing"
+
+func TestParseKubeVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		err      bool
+	}{
+		{"v1.27.3", "1.27.3", false},
+		{"v1.27.3-gke.100", "1.27.3+gke.100", false},
+		{"v1.27.3-eks-2d98532", "1.27.3+eks-2d98532", false},
+		{"v1.27.3+k3s1", "1.27.3+k3s1", false},
+		{"v1.27.3-gke.100+abc", "1.27.3+gke.100.abc", false},
+		{"v1.28.0-alpha.1", "1.28.0-alpha.1", false},
+		{"v1.28.0-rc.0.45+5e3b4b9f1c7a2d", "1.28.0-rc.0.45+5e3b4b9f1c7a2d", false},
+		{"v1.27.3-gke_100", "", true},
+		{"gke-1.27", "", true},
+	}
+
+	for _, tc := range tests {
+		v, err := ParseKubeVersion(tc.input)
+		if tc.err {
+			if err == nil {
+				t.Errorf("expected an error for %s but got %s", tc.input, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", tc.input, err)
+			continue
+		}
+		if v.String() != tc.expected || v.Original() != tc.input {
+			t.Errorf("expected %s to parse as %s but got %s", tc.input, tc.expected, v)
+		}
+	}
+}
+
+func TestNewKubeGate(t *testing.T) {
+	c, err := NewKubeGate("1.27+")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for v, expected := range map[string]bool{
+		"v1.27.0":         true,
+		"v1.27.3-gke.100": true,
+		"v1.30.1+k3s1":    true,
+		"v1.26.9-gke.1":   false,
+		"v1.27.0-rc.1":    false,
+	} {
+		kv, err := ParseKubeVersion(v)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", v, err)
+		}
+		if got := c.Check(kv); got != expected {
+			t.Errorf("expected 1.27+ admitting %s to be %t but got %t", v, expected, got)
+		}
+	}
+
+	if c, err := NewKubeGate("v1.27.3+"); err != nil || c.String() != ">=v1.27.3" {
+		t.Errorf("expected the gate v1.27.3+ to be >=v1.27.3 but got %v (%v)", c, err)
+	}
+	for _, gate := range []string{"1.27", "1.27-rc.1+", "+", "one+"} {
+		if _, err := NewKubeGate(gate); err == nil {
+			t.Errorf("expected an error for the gate %q", gate)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package semver
+
+import (
+	"regexp"
+	"sync"
+)
+
+// lazyRegexp is a regular expression compiled the first time it is used
+// rather than when the package is initialized, so programs that never parse
+// versions or constraints do not pay to compile it at startup. It is safe for
+// concurrent use.
+type lazyRegexp struct {
+	once sync.Once
+	expr func() string
+	re   *regexp.Regexp
+}
+
+// newLazyRegexp creates a regular expression compiled from expr on first use.
+func newLazyRegexp(expr string) *lazyRegexp {
+	return &lazyRegexp{expr: func() string { return expr }}
+}
+
+// newLazyRegexpFunc creates a regular expression compiled on first use from
+// the expression built by expr.
+func newLazyRegexpFunc(expr func() string) *lazyRegexp {
+	return &lazyRegexp{expr: expr}
+}
+
+func (l *lazyRegexp) get() *regexp.Regexp {
+	l.once.Do(func() {
+		l.re = regexp.MustCompile(l.expr())
+	})
+	return l.re
+}
+
+func (l *lazyRegexp) MatchString(s string) bool {
+	return l.get().MatchString(s)
+}
+
+func (l *lazyRegexp) FindStringSubmatch(s string) []string {
+	return l.get().FindStringSubmatch(s)
+}
+
+func (l *lazyRegexp) FindAllString(s string, n int) []string {
+	return l.get().FindAllString(s, n)
+}
+
+func (l *lazyRegexp) FindAllStringSubmatch(s string, n int) [][]string {
+	return l.get().FindAllStringSubmatch(s, n)
+}
+
+// LazyConstraint is a constraint string parsed the first time it is used,
+// for programs that load many constraints and only check a few of them. An
+// error parsing it is returned by the methods using it rather than when it is
+// created. It is safe for concurrent use.
+type LazyConstraint struct {
+	source string
+	opts   MatchOptions
+
+	once sync.Once
+	c    *Constraints
+	err  error
+}
+
+// NewLazyConstraint returns a constraint parsed with NewConstraint when it is
+// first used.
+func NewLazyConstraint(c string) *LazyConstraint {
+	return &LazyConstraint{source: c}
+}
+
+// NewLazyConstraintWithOptions returns a constraint parsed with
+// NewConstraintWithOptions when it is first used.
+func NewLazyConstraintWithOptions(c string, opts MatchOptions) *LazyConstraint {
+	return &LazyConstraint{source: c, opts: opts}
+}
+
+// Constraints parses the constraint if it has not been parsed yet and
+// returns the result.
+func (l *LazyConstraint) Constraints() (*Constraints, error) {
+	l.once.Do(func() {
+		l.c, l.err = NewConstraintWithOptions(l.source, l.opts)
+	})
+	return l.c, l.err
+}
+
+// String returns the constraint as given, without parsing it.
+func (l *LazyConstraint) String() string {
+	return l.source
+}
+
+// Matches tests if a version satisfies the constraint. When the constraint
+// cannot be parsed a *MatchError is returned with the parse error as its Err.
+func (l *LazyConstraint) Matches(v *Version) (bool, error) {
+	c, err := l.Constraints()
+	if err != nil {
+		return false, &MatchError{Constraint: l.source, Err: err}
+	}
+	return c.Matches(v)
+}
+
+// Check tests if a version satisfies the constraint. A constraint that cannot
+// be parsed admits no versions.
+func (l *LazyConstraint) Check(v *Version) bool {
+	ok, _ := l.Matches(v)
+	return ok
+}
+
+// Intersect returns constraints admitting the versions both the constraint
+// and o admit, as Intersect does, or the error parsing the constraint.
+func (l *LazyConstraint) Intersect(o *Constraints) (*Constraints, error) {
+	c, err := l.Constraints()
+	if err != nil {
+		return nil, err
+	}
+	return Intersect(c, o), nil
+}
+
+// Union returns constraints admitting the versions either the constraint or
+// o admits, as Union does, or the error parsing the constraint.
+func (l *LazyConstraint) Union(o *Constraints) (*Constraints, error) {
+	c, err := l.Constraints()
+	if err != nil {
+		return nil, err
+	}
+	return Union(c, o), nil
+}
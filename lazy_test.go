@@ -0,0 +1,137 @@
+package semver
+
+import (
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestLazyRegexp(t *testing.T) {
+	r := newLazyRegexp(`^a+$`)
+	if r.re != nil {
+		t.Fatal("expected the regular expression not to be compiled before use")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !r.MatchString("aaa") || r.MatchString("ab") {
+				t.Error("unexpected match result")
+			}
+		}()
+	}
+	wg.Wait()
+
+	re := r.get()
+	if r.get() != re {
+		t.Error("expected the regular expression to be compiled once")
+	}
+}
+
+func TestInitDoesNotCompileRegexps(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the test binary again")
+	}
+
+	// The test binary is run again without running any tests so its init
+	// can be traced. Compiling the regular expressions allocates over 100KB,
+	// so the package initializing in under 16KB shows none were compiled.
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	cmd.Env = append(os.Environ(), "GODEBUG=inittrace=1")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("unable to run the test binary: %s: %s", err, out)
+	}
+
+	// The trace is matched with a regular expression compiled here, rather
+	// than in a package variable, so compiling it is not part of init.
+	initTrace := regexp.MustCompile(`init github.com/jesseduffield/semver/v3 @.* ([0-9]+) bytes, ([0-9]+) allocs`)
+	m := initTrace.FindSubmatch(out)
+	if m == nil {
+		t.Skipf("no init trace for the package in %q", out)
+	}
+	if bytes, _ := strconv.Atoi(string(m[1])); bytes > 16<<10 {
+		t.Errorf("expected initializing the package to allocate under 16KB but it allocated %d bytes in %s allocations", bytes, m[2])
+	}
+}
+
+func TestLazyConstraint(t *testing.T) {
+	r := &recordHooks{}
+	SetHooks(r)
+	defer SetHooks(nil)
+
+	l := NewLazyConstraint("^1.2")
+	if l.String() != "^1.2" || len(r.parses) != 0 {
+		t.Fatalf("expected ^1.2 not to be parsed until used but got %d parses", len(r.parses))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !l.Check(MustParse("1.4.0")) {
+				t.Error("expected ^1.2 to admit 1.4.0")
+			}
+		}()
+	}
+	wg.Wait()
+	if ok, err := l.Matches(MustParse("2.0.0")); ok || err != nil {
+		t.Errorf("expected ^1.2 not to admit 2.0.0 but got %t %v", ok, err)
+	}
+	n := 0
+	for _, e := range r.parses {
+		if e.Kind == ParseConstraint {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("expected ^1.2 to be parsed once but got %d parses", n)
+	}
+
+	c, err := l.Intersect(mustConstraint(t, "<1.5"))
+	if err != nil || c.String() != "^1.2 <1.5" {
+		t.Errorf("expected ^1.2 <1.5 but got %v %v", c, err)
+	}
+	c, err = l.Union(mustConstraint(t, "^2"))
+	if err != nil || c.String() != "^1.2 || ^2" {
+		t.Errorf("expected ^1.2 || ^2 but got %v %v", c, err)
+	}
+}
+
+func TestLazyConstraintErrors(t *testing.T) {
+	l := NewLazyConstraint("<<1.2")
+
+	ok, err := l.Matches(MustParse("1.0.0"))
+	me, isMatch := err.(*MatchError)
+	if ok || !isMatch || me.Constraint != "<<1.2" {
+		t.Fatalf("expected a MatchError for <<1.2 but got %t %v", ok, err)
+	}
+	if _, perr := l.Constraints(); me.Err != perr {
+		t.Errorf("expected the MatchError to hold the parse error %v but got %v", perr, me.Err)
+	}
+	if l.Check(MustParse("1.0.0")) {
+		t.Error("expected a constraint that cannot be parsed to admit no versions")
+	}
+	if _, err := l.Intersect(mustConstraint(t, "*")); err == nil {
+		t.Error("expected Intersect to return the parse error")
+	}
+	if _, err := l.Union(mustConstraint(t, "*")); err == nil {
+		t.Error("expected Union to return the parse error")
+	}
+}
+
+func TestLazyConstraintOptions(t *testing.T) {
+	l := NewLazyConstraintWithOptions("^0.2", MatchOptions{Caret: CaretMajor})
+	if !l.Check(MustParse("0.9.0")) {
+		t.Error("expected ^0.2 to admit 0.9.0 with CaretMajor")
+	}
+	if ok, err := l.Matches(nil); ok || !isNilVersionError(err) {
+		t.Errorf("expected ErrNilVersion but got %t %v", ok, err)
+	}
+}
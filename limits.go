@@ -0,0 +1,108 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrInputTooLarge is matched by errors.Is for every *InputTooLargeError.
+var ErrInputTooLarge = errors.New("input too large")
+
+// InputTooLargeError is returned when a version or constraint exceeds one of
+// the Limits. It matches ErrInputTooLarge with errors.Is.
+type InputTooLargeError struct {
+	// Limit names what was exceeded, such as "version length".
+	Limit string
+
+	// Size is the size of the input, and Max the limit it exceeded.
+	Size, Max int
+}
+
+// Error describes the limit exceeded.
+func (e *InputTooLargeError) Error() string {
+	return fmt.Sprintf("%s of %d is over the limit of %d", e.Limit, e.Size, e.Max)
+}
+
+// Is reports if target is ErrInputTooLarge.
+func (e *InputTooLargeError) Is(target error) bool {
+	return target == ErrInputTooLarge
+}
+
+// Limits bounds the size of the inputs parsed, so a hostile input, such as a
+// constraint of many megabytes, cannot tie up a CPU or fill a
+// ConstraintCache. A limit of 0 or less is no limit. There are no limits
+// until SetLimits is called.
+//
+// The limits apply to the strings parsed and not to the constraints built
+// from them, so the String of the constraints returned by Union, And, or Or
+// may be over them and fail to parse while they are set.
+type Limits struct {
+	// MaxVersionLength is the longest version, in bytes, NewVersion,
+	// StrictNewVersion, and ParseBulk parse.
+	MaxVersionLength int
+
+	// MaxConstraintLength is the longest constraint string, in bytes,
+	// NewConstraint and the other constraint parsers parse.
+	MaxConstraintLength int
+
+	// MaxConstraintBranches is the most || separated branches a constraint
+	// string may have.
+	MaxConstraintBranches int
+}
+
+// RecommendedLimits are limits for programs parsing untrusted input, set
+// with SetLimits(RecommendedLimits). They are well beyond the versions and
+// constraints written by people or found in package registries.
+var RecommendedLimits = Limits{
+	MaxVersionLength:      256,
+	MaxConstraintLength:   16384,
+	MaxConstraintBranches: 256,
+}
+
+// limitsHolder wraps the limits so an atomic.Value always stores the same
+// type.
+type limitsHolder struct {
+	l Limits
+}
+
+var installedLimits atomic.Value
+
+// SetLimits sets the limits for the whole process, replacing any set before.
+// Passing Limits{} removes the limits.
+func SetLimits(l Limits) {
+	installedLimits.Store(limitsHolder{l})
+}
+
+// currentLimits returns the limits in place.
+func currentLimits() Limits {
+	if h, ok := installedLimits.Load().(limitsHolder); ok {
+		return h.l
+	}
+	return Limits{}
+}
+
+// checkVersionLimits returns an *InputTooLargeError when v is longer than
+// the limit.
+func checkVersionLimits(v string) error {
+	if max := currentLimits().MaxVersionLength; max > 0 && len(v) > max {
+		return &InputTooLargeError{Limit: "version length", Size: len(v), Max: max}
+	}
+	return nil
+}
+
+// checkConstraintLimits returns an *InputTooLargeError when c is longer or
+// has more || separated branches than the limits.
+func checkConstraintLimits(c string) error {
+	l := currentLimits()
+	if l.MaxConstraintLength > 0 && len(c) > l.MaxConstraintLength {
+		return &InputTooLargeError{Limit: "constraint length", Size: len(c), Max: l.MaxConstraintLength}
+	}
+	if l.MaxConstraintBranches > 0 {
+		if n := strings.Count(c, "||") + 1; n > l.MaxConstraintBranches {
+			return &InputTooLargeError{Limit: "constraint branches", Size: n, Max: l.MaxConstraintBranches}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNoLimitsByDefault(t *testing.T) {
+	if _, err := NewVersion("1.2.3-" + strings.Repeat("a", 1000)); err != nil {
+		t.Errorf("unexpected error for a long version: %s", err)
+	}
+
+	u := None()
+	for i := 0; i < 300; i++ {
+		u = Union(u, mustConstraint(t, fmt.Sprintf("%d.2.3", i*2)))
+	}
+	c, err := NewConstraint(u.String())
+	if err != nil {
+		t.Fatalf("unexpected error parsing the union of many constraints: %s", err)
+	}
+	if !c.Eq(u) {
+		t.Errorf("expected %s to round trip", u)
+	}
+}
+
+func TestRecommendedLimits(t *testing.T) {
+	defer SetLimits(Limits{})
+	SetLimits(RecommendedLimits)
+
+	long := "1.2.3-" + strings.Repeat("a", RecommendedLimits.MaxVersionLength)
+	if _, err := NewVersion(long); !isInputTooLarge(err) {
+		t.Errorf("expected a version over the recommended limit to be too large but got %v", err)
+	}
+	fits := "1.2.3-" + strings.Repeat("a", RecommendedLimits.MaxVersionLength-6)
+	if _, err := NewVersion(fits); err != nil {
+		t.Errorf("unexpected error for a version at the recommended limit: %s", err)
+	}
+
+	branches := strings.Repeat("1.2.3 || ", RecommendedLimits.MaxConstraintBranches) + "1.2.3"
+	if _, err := NewConstraint(branches); !isInputTooLarge(err) {
+		t.Errorf("expected a constraint over the recommended branches to be too large but got %v", err)
+	}
+}
+
+func TestSetLimits(t *testing.T) {
+	defer SetLimits(Limits{})
+	SetLimits(Limits{MaxVersionLength: 8, MaxConstraintLength: 16, MaxConstraintBranches: 2})
+
+	tests := []struct {
+		name  string
+		parse func(string) error
+		input string
+		err   string
+	}{
+		{"NewVersion", parseErr(NewVersion), "1.2.3-abc", "version length of 9 is over the limit of 8"},
+		{"NewVersion", parseErr(NewVersion), "1.2.3-ab", ""},
+		{"StrictNewVersion", parseErr(StrictNewVersion), "1.2.3-abc", "version length of 9 is over the limit of 8"},
+		{"StrictNewVersion", parseErr(StrictNewVersion), "1.2.3-ab", ""},
+		{"ParseBulk", func(s string) error {
+			_, err := ParseBulk([]string{"1.0.0", s})
+			return err
+		}, "1.2.3-abc", "1.2.3-abc at index 1: version length of 9 is over the limit of 8"},
+		{"NewConstraint", parseErr(NewConstraint), ">=1.2.3, <2.0.0-0", "constraint length of 17 is over the limit of 16"},
+		{"NewConstraint", parseErr(NewConstraint), "^1 || ^2 || ^3", "constraint branches of 3 is over the limit of 2"},
+		{"NewConstraint", parseErr(NewConstraint), "^1 || ^2", ""},
+		{"NewConstraint", parseErr(NewConstraint), "1.2.3-abcdefgh", "version length of 14 is over the limit of 8"},
+		{"StrictNewConstraint", parseErr(StrictNewConstraint), "^1 || ^2 || ^3", "constraint branches of 3 is over the limit of 2"},
+		{"NewConstraintWithTrace", func(s string) error {
+			_, _, err := NewConstraintWithTrace(s)
+			return err
+		}, ">=1.2.3, <2.0.0-0", "constraint length of 17 is over the limit of 16"},
+	}
+
+	for _, tc := range tests {
+		err := tc.parse(tc.input)
+		switch {
+		case tc.err == "" && err != nil:
+			t.Errorf("%s: unexpected error for %q: %s", tc.name, tc.input, err)
+		case tc.err != "" && (err == nil || err.Error() != tc.err):
+			t.Errorf("%s: expected the error %q for %q but got %v", tc.name, tc.err, tc.input, err)
+		case tc.err != "" && !isInputTooLarge(err):
+			t.Errorf("%s: expected the error for %q to be ErrInputTooLarge", tc.name, tc.input)
+		}
+	}
+
+	_, err := NewVersion("10.20.30-a")
+	if e, ok := err.(*InputTooLargeError); !ok || e.Limit != "version length" || e.Size != 10 || e.Max != 8 {
+		t.Errorf("unexpected error %#v", err)
+	}
+
+	SetLimits(Limits{})
+	if _, err := NewVersion("1.2.3-" + strings.Repeat("a", 1000)); err != nil {
+		t.Errorf("unexpected error without limits: %s", err)
+	}
+}
+
+func TestConstraintCacheLimits(t *testing.T) {
+	defer SetLimits(Limits{})
+	SetLimits(Limits{MaxConstraintLength: 16})
+
+	cc := NewConstraintCache(MatchOptions{}, 0)
+	if _, err := cc.Get(">=1.2.3, <2.0.0-0"); !isInputTooLarge(err) {
+		t.Errorf("expected the constraint to be too large but got %v", err)
+	}
+	if cc.Len() != 0 {
+		t.Errorf("expected a constraint over the limit not to be cached but the cache has %d entries", cc.Len())
+	}
+}
+
+// parseErr adapts a parser to return only its error.
+func parseErr(parse interface{}) func(string) error {
+	switch p := parse.(type) {
+	case func(string) (*Version, error):
+		return func(s string) error {
+			_, err := p(s)
+			return err
+		}
+	case func(string) (*Constraints, error):
+		return func(s string) error {
+			_, err := p(s)
+			return err
+		}
+	}
+	panic("unsupported parser")
+}
+
+// isInputTooLarge reports if err is, or wraps, an *InputTooLargeError. The
+// errors are unwrapped by hand as errors.Is needs Go 1.13.
+func isInputTooLarge(err error) bool {
+	for err != nil {
+		if _, ok := err.(*InputTooLargeError); ok {
+			return true
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = u.Unwrap()
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FindingKind is the type of issue a Finding reports.
+type FindingKind int
+
+const (
+	// FindingRedundant is a clause that does not change the versions
+	// admitted. For example, the >=1.0 in `>=1.2, >=1.0`.
+	FindingRedundant FindingKind = iota
+
+	// FindingImpossible is a set of AND clauses that no version can satisfy.
+	// For example, `>2, <1`.
+	FindingImpossible
+
+	// FindingAlwaysTrue is a clause that admits every release version. For
+	// example, `*` or `>=0.0.0`.
+	FindingAlwaysTrue
+
+	// FindingExclusionOutsideRange is a != clause that excludes versions the
+	// rest of the clauses already do not admit. For example, the !=3.0.0 in
+	// `^1.2, !=3.0.0`.
+	FindingExclusionOutsideRange
+
+	// FindingDowngrade is a version published after a greater version of the
+	// same minor series. For example, 1.2.3 after 1.2.4.
+	FindingDowngrade
+
+	// FindingSkipped is a version skipping over the versions that should
+	// come before it. For example, 1.4.0 after 1.2.4.
+	FindingSkipped
+
+	// FindingDuplicate is a version published again, differing at most in
+	// metadata. For example, 1.2.3+build.2 after 1.2.3+build.1.
+	FindingDuplicate
+
+	// FindingPrereleaseAfterRelease is a prerelease published after the
+	// release it precedes. For example, 1.2.3-rc.2 after 1.2.3.
+	FindingPrereleaseAfterRelease
+)
+
+// String returns a short name for the kind of finding.
+func (k FindingKind) String() string {
+	switch k {
+	case FindingRedundant:
+		return "redundant"
+	case FindingImpossible:
+		return "impossible"
+	case FindingAlwaysTrue:
+		return "always-true"
+	case FindingExclusionOutsideRange:
+		return "exclusion-outside-range"
+	case FindingDowngrade:
+		return "downgrade"
+	case FindingSkipped:
+		return "skipped"
+	case FindingDuplicate:
+		return "duplicate"
+	case FindingPrereleaseAfterRelease:
+		return "prerelease-after-release"
+	default:
+		return "unknown"
+	}
+}
+
+// Finding is an issue found in constraints by Lint or in a sequence of
+// versions by AuditSequence.
+type Finding struct {
+	Kind FindingKind
+
+	// Branch is the index of the || separated group the finding is in or, for
+	// AuditSequence, the index of the offending version.
+	Branch int
+
+	// Clause is the offending clause or, for findings on a whole group, the
+	// clauses of the group. For AuditSequence it is the offending version.
+	Clause string
+
+	// Message is a human readable description of the issue.
+	Message string
+}
+
+// String returns the finding in a form suitable for logs and CI output.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Kind, f.Message)
+}
+
+// Lint checks constraints for clauses that are redundant, impossible to
+// satisfy, always true, or exclude versions outside of the range. It returns
+// the issues found. Constraints without issues, and nil constraints, return no
+// findings.
+//
+// Redundancy is decided by the versions the clauses admit rather than by the
+// way they are written. For example, `^1.2.3, <2` reports the <2 as redundant.
+// With MetadataEqual, MetadataOrdered, or LegacyPrereleaseOrder, which tell
+// apart versions sharing a precedence, findings are only reported when they
+// hold however the versions the clauses name are told apart. For example,
+// `=1.2.3+abc, !=1.2.3+def` with MetadataEqual is not reported as impossible.
+func Lint(c *Constraints) []Finding {
+	c = orNone(c, nil)
+	var f []Finding
+
+	may := make([]versionSet, len(c.constraints))
+	must := make([]versionSet, len(c.constraints))
+	for k, group := range c.constraints {
+		may[k], must[k] = lintGroup(k, group, &c.opts, &f)
+	}
+
+	// An entire || branch is redundant when the other branches already admit
+	// every version it does. Later branches are checked first so that, for
+	// duplicates, the one written last is reported.
+	start := len(f)
+	dropped := make([]bool, len(may))
+	for k := len(may) - 1; k >= 0; k-- {
+		if may[k].empty() {
+			dropped[k] = true
+			continue
+		}
+		rest := versionSet{}
+		for o, s := range must {
+			if o != k && !dropped[o] {
+				rest = rest.union(s)
+			}
+		}
+		if may[k].subsetOf(rest) {
+			dropped[k] = true
+			g := groupString(c.constraints[k])
+			f = append(f, Finding{
+				Kind:    FindingRedundant,
+				Branch:  k,
+				Clause:  g,
+				Message: fmt.Sprintf("%s is already admitted by the other || branches", g),
+			})
+		}
+	}
+	reverseFindings(f[start:])
+
+	return f
+}
+
+// lintGroup appends the findings for a single AND group and returns the
+// versions the group may admit and those it surely admits.
+func lintGroup(branch int, group []*constraint, o *MatchOptions, f *[]Finding) (may, must versionSet) {
+	may, must = andSets(group, o)
+	if may.empty() {
+		g := groupString(group)
+		*f = append(*f, Finding{
+			Kind:    FindingImpossible,
+			Branch:  branch,
+			Clause:  g,
+			Message: fmt.Sprintf("no version can satisfy %s", g),
+		})
+		return may, must
+	}
+
+	// Clauses are checked from last to first so that, for duplicates, the
+	// one written last is reported.
+	start := len(*f)
+	defer func() { reverseFindings((*f)[start:]) }()
+	dropped := make([]bool, len(group))
+	rest := make([]*constraint, 0, len(group))
+	for k := len(group) - 1; k >= 0; k-- {
+		c := group[k]
+		_, cmust := c.sets(o)
+		if c.origfunc != "!=" && cmust.allReleases() {
+			*f = append(*f, Finding{
+				Kind:    FindingAlwaysTrue,
+				Branch:  branch,
+				Clause:  c.string(),
+				Message: fmt.Sprintf("%s admits every release version", c.string()),
+			})
+		}
+
+		// A clause is redundant when the group admits the same versions
+		// without it, so when the other clauses imply it. A clause naming a
+		// scoped prerelease also widens the prereleases the others admit,
+		// so for those the whole group is compared instead.
+		rest = rest[:0]
+		for j, oc := range group {
+			if j != k && !dropped[j] {
+				rest = append(rest, oc)
+			}
+		}
+		implied := cmust
+		if clauseOptions(c, o).Prerelease == PrereleaseScoped && c.con.Prerelease() != "" {
+			implied = must
+		}
+		if rmay, _ := andSets(rest, o); !rmay.subsetOf(implied) {
+			continue
+		}
+		dropped[k] = true
+
+		switch {
+		case c.origfunc != "!=" && cmust.allReleases():
+			// Already reported as always true.
+		case c.origfunc == "!=":
+			*f = append(*f, Finding{
+				Kind:    FindingExclusionOutsideRange,
+				Branch:  branch,
+				Clause:  c.string(),
+				Message: fmt.Sprintf("%s excludes versions the other clauses do not admit", c.string()),
+			})
+		default:
+			*f = append(*f, Finding{
+				Kind:    FindingRedundant,
+				Branch:  branch,
+				Clause:  c.string(),
+				Message: fmt.Sprintf("%s is implied by the other clauses", c.string()),
+			})
+		}
+	}
+
+	return may, must
+}
+
+// groupString renders an AND group the same way Constraints.String does.
+func groupString(group []*constraint) string {
+	return strings.Join(clauseStrings(group), " ")
+}
+
+func reverseFindings(f []Finding) {
+	for i, j := 0, len(f)-1; i < j; i, j = i+1, j-1 {
+		f[i], f[j] = f[j], f[i]
+	}
+}
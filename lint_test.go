@@ -0,0 +1,168 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		kinds      []FindingKind
+		clauses    []string
+	}{
+		{"^1.2.3", nil, nil},
+		{">=1.2, <2 || >=3", nil, nil},
+		{">=1.2, >=1.0", []FindingKind{FindingRedundant}, []string{">=1.0"}},
+		{">=1.0, >=1.2", []FindingKind{FindingRedundant}, []string{">=1.0"}},
+		{"^1.2.3, <2", []FindingKind{FindingRedundant}, []string{"<2"}},
+		{">=1.2, >=1.2", []FindingKind{FindingRedundant}, []string{">=1.2"}},
+		{">2, <1", []FindingKind{FindingImpossible}, []string{">2 <1"}},
+		{"^1.2, <1.2 || 3", []FindingKind{FindingImpossible}, []string{"^1.2 <1.2"}},
+		{"*", []FindingKind{FindingAlwaysTrue}, []string{"*"}},
+		{">=0.0.0, <2", []FindingKind{FindingAlwaysTrue}, []string{">=0.0.0"}},
+		{"^1.2, !=3.0.0", []FindingKind{FindingExclusionOutsideRange}, []string{"!=3.0.0"}},
+		{"^1.2, !=1.4.0", nil, nil},
+		{"^1.2 || ^1.4", []FindingKind{FindingRedundant}, []string{"^1.4"}},
+		{">=1.2.0-0, >=1.2.0", []FindingKind{FindingRedundant}, []string{">=1.2.0-0"}},
+		{">=1.2.0, >=1.2.0-0", []FindingKind{FindingRedundant}, []string{">=1.2.0-0"}},
+		{
+			">=1.0, >=1.2, <2, !=5.0.0 || ~1.3",
+			[]FindingKind{FindingRedundant, FindingExclusionOutsideRange, FindingRedundant},
+			[]string{">=1.0", "!=5.0.0", "~1.3"},
+		},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.constraint, err)
+		}
+
+		var kinds []FindingKind
+		var clauses []string
+		for _, f := range Lint(c) {
+			kinds = append(kinds, f.Kind)
+			clauses = append(clauses, f.Clause)
+		}
+		if !reflect.DeepEqual(kinds, tc.kinds) || !reflect.DeepEqual(clauses, tc.clauses) {
+			t.Errorf("expected findings %v on %v for %q but got %v on %v", tc.kinds, tc.clauses, tc.constraint, kinds, clauses)
+		}
+	}
+}
+
+func TestFindingString(t *testing.T) {
+	c, err := NewConstraint(">2, <1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	f := Lint(c)
+	if len(f) != 1 {
+		t.Fatalf("expected 1 finding but got %d", len(f))
+	}
+	if f[0].String() != "impossible: no version can satisfy >2 <1" {
+		t.Errorf("unexpected finding string %q", f[0].String())
+	}
+}
+
+func TestLintWithOptions(t *testing.T) {
+	c, err := NewConstraintWithOptions("^0.2.3, <1", MatchOptions{Caret: CaretMajor})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	f := Lint(c)
+	if len(f) != 1 || f[0].Kind != FindingRedundant || f[0].Clause != "<1" {
+		t.Errorf("expected <1 to be redundant with the caret policy but got %v", f)
+	}
+
+	c, err = NewConstraint("^0.2.3, <1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	f = Lint(c)
+	if len(f) != 1 || f[0].Clause != "<1" {
+		t.Errorf("expected <1 to be redundant with the default options but got %v", f)
+	}
+
+	c, err = NewConstraintWithOptions("^0.2.3, <0.3", MatchOptions{Caret: CaretMajor})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if f = Lint(c); len(f) != 0 {
+		t.Errorf("expected no findings with the caret policy but got %v", f)
+	}
+}
+
+func TestLintFoldPrereleaseCase(t *testing.T) {
+	c, err := NewConstraintWithOptions("=1.0.0-RC.1, =1.0.0-rc.1", MatchOptions{FoldPrereleaseCase: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	f := Lint(c)
+	if len(f) != 1 || f[0].Kind != FindingRedundant {
+		t.Errorf("expected a redundant clause when folding case but got %v", f)
+	}
+
+	c, err = NewConstraint("=1.0.0-RC.1, =1.0.0-rc.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	f = Lint(c)
+	if len(f) != 1 || f[0].Kind != FindingImpossible {
+		t.Errorf("expected an impossible group without folding case but got %v", f)
+	}
+}
+
+func TestLintPrecedenceOptions(t *testing.T) {
+	tests := []struct {
+		constraint string
+		opts       MatchOptions
+		admits     string
+	}{
+		{"=1.2.3+abc, !=1.2.3+def", MatchOptions{Metadata: MetadataEqual}, "1.2.3+abc"},
+		{">1.2.3+build.9, <1.2.3+build.11", MatchOptions{Metadata: MetadataOrdered}, "1.2.3+build.10"},
+		{">1.2.3-100000000000000000000, <1.2.3-99999999999999999999", MatchOptions{LegacyPrereleaseOrder: true}, "1.2.3-5aaa"},
+	}
+	for _, tc := range tests {
+		c, err := NewConstraintWithOptions(tc.constraint, tc.opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !c.Check(MustParse(tc.admits)) {
+			t.Fatalf("expected %s to admit %s", tc.constraint, tc.admits)
+		}
+		for _, f := range Lint(c) {
+			if f.Kind == FindingImpossible {
+				t.Errorf("expected %s with %+v not to be reported impossible", tc.constraint, tc.opts)
+			}
+		}
+	}
+
+	// Clauses relying on the options are still reported when the other
+	// clauses rule out every version they may admit.
+	c, err := NewConstraintWithOptions("=1.2.3+abc, >2", MatchOptions{Metadata: MetadataEqual})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if f := Lint(c); len(f) != 1 || f[0].Kind != FindingImpossible {
+		t.Errorf("expected the group to be impossible but got %v", f)
+	}
+
+	// A clause is only redundant when it is for every version sharing a
+	// precedence with the one it names.
+	c, err = NewConstraintWithOptions("=1.2.3+abc, =1.2.3+def || =1.2.3+abc", MatchOptions{Metadata: MetadataEqual})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if f := Lint(c); len(f) != 0 {
+		t.Errorf("expected no findings but got %v", f)
+	}
+	c, err = NewConstraintWithOptions("<=1.2.3, <2", MatchOptions{Metadata: MetadataOrdered})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if f := Lint(c); len(f) != 1 || f[0].Clause != "<2" {
+		t.Errorf("expected <2 to be redundant but got %v", f)
+	}
+}
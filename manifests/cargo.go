@@ -0,0 +1,209 @@
+package manifests
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// CargoOptions returns the match options following Cargo. A prerelease only
+// satisfies a requirement naming a prerelease of the same release.
+func CargoOptions() semver.MatchOptions {
+	return semver.MatchOptions{Prerelease: semver.PrereleaseScoped}
+}
+
+// cargoVersionKey matches the version key of an inline table.
+var cargoVersionKey = regexp.MustCompile(`(?:^|[{,\s])version\s*=\s*("(?:[^"\\]|\\.)*"|'[^']*')`)
+
+// ParseCargo reads the dependency tables of a Cargo.toml file, including
+// [dev-dependencies], [build-dependencies], [workspace.dependencies], and
+// the target specific tables such as [target.'cfg(unix)'.dependencies].
+// Dependencies are read from both the name = "1.2" and
+// name = { version = "1.2" } forms and from [dependencies.name] tables. Each
+// version requirement is converted with CargoConstraint. Dependencies without
+// a version, such as those with only a path or git source or inherited from
+// the workspace, and requirements that can not be converted are returned as
+// leftovers. When a name is a dependency more than once the first
+// requirement is kept.
+//
+// Only the subset of TOML used for dependency tables is understood. Other
+// tables are skipped.
+func ParseCargo(data []byte) (map[string]*semver.Constraints, []string) {
+	out := map[string]*semver.Constraints{}
+	var leftovers []string
+
+	add := func(name, entry, req string, ok bool) {
+		if !ok {
+			leftovers = append(leftovers, entry)
+			return
+		}
+		c, err := CargoConstraint(req)
+		if err != nil {
+			leftovers = append(leftovers, entry)
+			return
+		}
+		if _, seen := out[name]; !seen {
+			out[name] = c
+		}
+	}
+
+	// table is the name of a [dependencies.name] table being read, with
+	// header its header and tableVersion its version once found.
+	inDeps := false
+	table, header, tableVersion, tableHasVersion := "", "", "", false
+	flush := func() {
+		if table != "" {
+			add(table, header, tableVersion, tableHasVersion)
+		}
+		table, header, tableVersion, tableHasVersion = "", "", "", false
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(stripTOMLComment(s.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			flush()
+			name := strings.Trim(line, "[] ")
+			parts := splitTOMLKey(name)
+			inDeps = false
+			if n := len(parts); n > 0 && isDependencyTable(parts[n-1]) {
+				inDeps = true
+			} else if n > 1 && isDependencyTable(parts[n-2]) {
+				table, header = parts[n-1], line
+			}
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			continue
+		}
+		key := unquoteTOML(strings.TrimSpace(line[:eq]))
+		value := strings.TrimSpace(line[eq+1:])
+
+		switch {
+		case table != "":
+			if key == "version" {
+				tableVersion, tableHasVersion = unquoteTOMLString(value)
+			}
+		case inDeps:
+			if strings.HasPrefix(value, "{") {
+				m := cargoVersionKey.FindStringSubmatch(value)
+				if m == nil {
+					add(key, line, "", false)
+					continue
+				}
+				req, ok := unquoteTOMLString(m[1])
+				add(key, line, req, ok)
+				continue
+			}
+			if strings.Contains(key, ".") {
+				// A dotted key such as name.workspace = true.
+				add(key, line, "", false)
+				continue
+			}
+			req, ok := unquoteTOMLString(value)
+			add(key, line, req, ok)
+		}
+	}
+	flush()
+	return out, leftovers
+}
+
+// CargoConstraint converts a Cargo version requirement, such as
+// ">=1.2, <1.5" or "0.4", into constraints using CargoOptions. A requirement
+// without an operator is a caret requirement, so 0.4 admits 0.4.x the same
+// way as ^0.4.
+func CargoConstraint(req string) (*semver.Constraints, error) {
+	var parts []string
+	for _, p := range strings.Split(req, ",") {
+		p = strings.TrimSpace(p)
+		if p != "" && p[0] >= '0' && p[0] <= '9' && !strings.ContainsAny(p, "*xX") {
+			p = "^" + p
+		}
+		parts = append(parts, p)
+	}
+	return semver.NewConstraintWithOptions(strings.Join(parts, ", "), CargoOptions())
+}
+
+// isDependencyTable reports if a table name holds dependencies.
+func isDependencyTable(name string) bool {
+	switch name {
+	case "dependencies", "dev-dependencies", "build-dependencies", "dev_dependencies", "build_dependencies":
+		return true
+	}
+	return false
+}
+
+// splitTOMLKey splits a dotted TOML key, keeping quoted parts such as
+// 'cfg(unix)' whole and unquoted.
+func splitTOMLKey(key string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			parts = append(parts, unquoteTOML(strings.TrimSpace(key[start:i])))
+			start = i + 1
+		}
+	}
+	return append(parts, unquoteTOML(strings.TrimSpace(key[start:])))
+}
+
+// unquoteTOML removes the quotes from a quoted key, returning other keys as
+// they are.
+func unquoteTOML(key string) string {
+	if s, ok := unquoteTOMLString(key); ok {
+		return s
+	}
+	return key
+}
+
+// unquoteTOMLString unquotes a basic or literal TOML string. The boolean is
+// false when the value is not a string.
+func unquoteTOMLString(value string) (string, bool) {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1], true
+	}
+	if len(value) >= 2 && value[0] == '"' {
+		s, err := strconv.Unquote(value)
+		return s, err == nil
+	}
+	return "", false
+}
+
+// stripTOMLComment removes a # comment from a line, ignoring # within
+// strings.
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,106 @@
+package manifests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func TestCargoConstraint(t *testing.T) {
+	tests := []struct {
+		req     string
+		admits  []string
+		rejects []string
+	}{
+		{"1.2.3", []string{"1.2.3", "1.9.0"}, []string{"2.0.0", "1.2.2"}},
+		{"0.4", []string{"0.4.0", "0.4.9"}, []string{"0.5.0"}},
+		{"0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"=1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		{"~1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{">=1.2, <1.5", []string{"1.4.9"}, []string{"1.5.0"}},
+		{"1.*", []string{"1.8.0"}, []string{"2.0.0"}},
+		{"*", []string{"0.1.0"}, nil},
+		{"1.0.0-alpha.2", []string{"1.0.0-beta", "1.0.0", "1.2.0"}, []string{"1.2.0-beta", "1.0.0-alpha.1"}},
+	}
+
+	for _, tc := range tests {
+		c, err := CargoConstraint(tc.req)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", tc.req, err)
+			continue
+		}
+		for _, v := range tc.admits {
+			if !c.Check(semver.MustParse(v)) {
+				t.Errorf("expected %q (%s) to admit %s", tc.req, c, v)
+			}
+		}
+		for _, v := range tc.rejects {
+			if c.Check(semver.MustParse(v)) {
+				t.Errorf("expected %q (%s) not to admit %s", tc.req, c, v)
+			}
+		}
+	}
+}
+
+func TestParseCargo(t *testing.T) {
+	data := []byte(`[package]
+name = "app"
+version = "0.1.0"
+
+[dependencies]
+serde = "1.0" # serialization
+tokio = { version = "1.28", features = ["full"] }
+"quoted-name" = '0.3'
+local = { path = "../local" }
+shared.workspace = true
+bad = "not a version"
+
+[dependencies.regex]
+version = "1.9.1"
+default-features = false
+
+[dependencies.git-only]
+git = "https://example.com/repo.git"
+
+[dev-dependencies]
+serde = "0.9"
+proptest = "~1.2"
+
+[target.'cfg(unix)'.dependencies]
+libc = ">=0.2.100, <0.3"
+
+[profile.release]
+lto = "1.0"
+`)
+
+	deps, leftovers := ParseCargo(data)
+	expected := map[string]string{
+		"serde":       "^1.0",
+		"tokio":       "^1.28",
+		"quoted-name": "^0.3",
+		"regex":       "^1.9.1",
+		"proptest":    "~1.2",
+		"libc":        ">=0.2.100 <0.3",
+	}
+	got := map[string]string{}
+	for name, c := range deps {
+		got[name] = c.String()
+		if c.Options() != CargoOptions() {
+			t.Errorf("expected %s to use CargoOptions", name)
+		}
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the dependencies %v but got %v", expected, got)
+	}
+
+	expectedLeftovers := []string{
+		`local = { path = "../local" }`,
+		`shared.workspace = true`,
+		`bad = "not a version"`,
+		`[dependencies.git-only]`,
+	}
+	if !reflect.DeepEqual(leftovers, expectedLeftovers) {
+		t.Errorf("expected the leftovers %q but got %q", expectedLeftovers, leftovers)
+	}
+}
@@ -0,0 +1,192 @@
+// Package manifests reads the dependency requirements of package manifests
+// from other ecosystems into semver constraints, so one set of policy checks
+// can run across them. Each reader returns the constraints by dependency name
+// along with the entries that could not be converted, such as dependencies on
+// a URL or requirements using syntax that has no semver equivalent.
+package manifests
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// requirementLine matches a requirements.txt requirement, capturing the
+// project name and its specifiers after any extras.
+var requirementLine = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)
+
+// pep440Version matches the PEP 440 versions that have a semver equivalent: up
+// to three release segments, an optional trailing .* wildcard, and an
+// optional alpha, beta, or release candidate prerelease.
+var pep440Version = regexp.MustCompile(`^v?([0-9]+(?:\.[0-9]+){0,2})(\.\*)?(?:[-_.]?(a|alpha|b|beta|c|rc|pre|preview)[-_.]?([0-9]*))?$`)
+
+// ParseRequirements reads a pip requirements.txt file. Each requirement, such
+// as requests[socks]>=2.8.1,<3 ; python_version >= "3.8", is converted with
+// PEP440Constraint, and a requirement without specifiers admits any version.
+// Names are returned as written. Comments, blank lines, and environment
+// markers are skipped. Options such as -r and -e, URL and path requirements,
+// and requirements that can not be converted are returned as leftovers. When
+// a name is required more than once the first requirement is kept.
+func ParseRequirements(data []byte) (map[string]*semver.Constraints, []string) {
+	out := map[string]*semver.Constraints{}
+	var leftovers []string
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if i := strings.Index(line, ";"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		m := requirementLine.FindStringSubmatch(line)
+		if m == nil || strings.HasPrefix(m[2], "@") {
+			leftovers = append(leftovers, line)
+			continue
+		}
+		c, err := PEP440Constraint(m[2])
+		if err != nil {
+			leftovers = append(leftovers, line)
+			continue
+		}
+		if _, ok := out[m[1]]; !ok {
+			out[m[1]] = c
+		}
+	}
+	return out, leftovers
+}
+
+// PEP440Constraint converts PEP 440 version specifiers, such as
+// ">=1.4.2, !=1.5.*, <2", into constraints. The ==, !=, <, <=, >, >=, ~=, and
+// === operators are supported, with == and != taking a trailing .* wildcard.
+// Versions are compared padded with zeros, so ==1.2 only admits 1.2.0, and
+// the alpha, beta, and release candidate prereleases 1.2a1, 1.2b1, and
+// 1.2rc1 become 1.2.0-a.1, 1.2.0-b.1, and 1.2.0-rc.1. An empty specifier
+// admits any version. An error is returned for versions with no semver
+// equivalent, such as those with an epoch, post or dev release, local label,
+// or more than three release segments.
+func PEP440Constraint(spec string) (*semver.Constraints, error) {
+	// Each specifier is a set of alternatives, of which only != with a
+	// wildcard has more than one. The constraints are every combination of
+	// one alternative from each specifier.
+	branches := []string{""}
+	for _, sp := range strings.Split(spec, ",") {
+		sp = strings.TrimSpace(sp)
+		if sp == "" {
+			continue
+		}
+		alts, err := pep440Specifier(sp)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []string
+		for _, b := range branches {
+			for _, a := range alts {
+				next = append(next, strings.TrimPrefix(b+", "+a, ", "))
+			}
+		}
+		branches = next
+	}
+
+	c := strings.Join(branches, " || ")
+	if c == "" {
+		c = "*"
+	}
+	return semver.NewConstraint(c)
+}
+
+// pep440Specifier converts a single specifier into the alternatives it
+// admits.
+func pep440Specifier(sp string) ([]string, error) {
+	var op string
+	for _, o := range []string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(sp, o) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("%s does not start with a PEP 440 operator", sp)
+	}
+
+	raw := strings.TrimSpace(strings.TrimPrefix(sp, op))
+	m := pep440Version.FindStringSubmatch(strings.ToLower(raw))
+	if m == nil {
+		return nil, fmt.Errorf("%s has no semver equivalent", raw)
+	}
+	release := strings.Split(m[1], ".")
+	wildcard := m[2] != ""
+	if wildcard && (m[3] != "" || (op != "==" && op != "!=")) {
+		return nil, fmt.Errorf("%s can only use a .* wildcard with == or !=", sp)
+	}
+
+	v := pep440Semver(release, m[3], m[4])
+	switch {
+	case wildcard:
+		lower := pep440Semver(release, "", "")
+		upper := pep440Semver(bump(release, len(release)-1), "", "")
+		if op == "==" {
+			return []string{">=" + lower + ", <" + upper}, nil
+		}
+		return []string{"<" + lower, ">=" + upper}, nil
+	case op == "~=":
+		if len(release) < 2 {
+			return nil, fmt.Errorf("%s needs at least two release segments", sp)
+		}
+		upper := pep440Semver(bump(release, len(release)-2), "", "")
+		return []string{">=" + v + ", <" + upper}, nil
+	case op == "==" || op == "===":
+		return []string{"=" + v}, nil
+	default:
+		return []string{op + v}, nil
+	}
+}
+
+// pep440Semver renders a PEP 440 release and prerelease as a semver version,
+// padding the release with zeros.
+func pep440Semver(release []string, pre, n string) string {
+	segments := append([]string{}, release...)
+	for len(segments) < 3 {
+		segments = append(segments, "0")
+	}
+	v := strings.Join(segments, ".")
+
+	if pre == "" {
+		return v
+	}
+	switch pre {
+	case "alpha":
+		pre = "a"
+	case "beta":
+		pre = "b"
+	case "c", "pre", "preview":
+		pre = "rc"
+	}
+	if n == "" {
+		n = "0"
+	}
+	return v + "-" + pre + "." + n
+}
+
+// bump drops the release segments after index i and increments segment i.
+func bump(release []string, i int) []string {
+	out := append([]string{}, release[:i+1]...)
+	var n uint64
+	fmt.Sscan(out[i], &n)
+	out[i] = fmt.Sprint(n + 1)
+	return out
+}
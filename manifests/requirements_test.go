@@ -0,0 +1,90 @@
+package manifests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func TestPEP440Constraint(t *testing.T) {
+	tests := []struct {
+		spec    string
+		admits  []string
+		rejects []string
+	}{
+		{"", []string{"0.0.1", "9.0.0"}, nil},
+		{"==1.2", []string{"1.2.0"}, []string{"1.2.1"}},
+		{"==1.2.*", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"}},
+		{"!=1.2.*, >=1.0", []string{"1.1.5", "1.3.0"}, []string{"1.2.4", "0.9.0"}},
+		{"~=1.4.5", []string{"1.4.5", "1.4.9"}, []string{"1.5.0", "1.4.4"}},
+		{"~=2.2", []string{"2.2.0", "2.9.1"}, []string{"3.0.0"}},
+		{">=2.8.1, <3", []string{"2.8.1", "2.99.0"}, []string{"3.0.0", "2.8.0"}},
+		{">=1.0rc1", []string{"1.0.0-rc.2", "1.0.0"}, []string{"1.0.0-b.3"}},
+		{"===1.0", []string{"1.0.0"}, []string{"1.0.1"}},
+		{"<1.2a1", []string{"1.1.0"}, []string{"1.2.0-a.1"}},
+	}
+
+	for _, tc := range tests {
+		c, err := PEP440Constraint(tc.spec)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", tc.spec, err)
+			continue
+		}
+		for _, v := range tc.admits {
+			if !c.Check(semver.MustParse(v)) {
+				t.Errorf("expected %q (%s) to admit %s", tc.spec, c, v)
+			}
+		}
+		for _, v := range tc.rejects {
+			if c.Check(semver.MustParse(v)) {
+				t.Errorf("expected %q (%s) not to admit %s", tc.spec, c, v)
+			}
+		}
+	}
+
+	for _, spec := range []string{"1.2", "==1!2.0", ">=1.0.post1", "==1.0.dev0", "==1.0+local", "==1.2.3.4", "~=1", ">=1.*", "==1.2rc1.*"} {
+		if c, err := PEP440Constraint(spec); err == nil {
+			t.Errorf("expected an error for %q but got %s", spec, c)
+		}
+	}
+}
+
+func TestParseRequirements(t *testing.T) {
+	data := []byte(`# Production dependencies
+requests[socks]>=2.8.1,<3 ; python_version >= "3.8"
+Django ~= 4.2  # LTS
+numpy
+flask==2.*
+-r dev.txt
+-e git+https://github.com/user/repo.git#egg=repo
+pkg @ https://example.com/pkg-1.0.tar.gz
+odd>=1.0.post1
+requests==1.0
+`)
+
+	reqs, leftovers := ParseRequirements(data)
+	expected := map[string]string{
+		"requests": ">=2.8.1 <3.0.0",
+		"Django":   ">=4.2.0 <5.0.0",
+		"numpy":    "*",
+		"flask":    ">=2.0.0 <3.0.0",
+	}
+	got := map[string]string{}
+	for name, c := range reqs {
+		got[name] = c.String()
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the requirements %v but got %v", expected, got)
+	}
+
+	expectedLeftovers := []string{
+		"-r dev.txt",
+		"-e git+https://github.com/user/repo.git#egg=repo",
+		"pkg @ https://example.com/pkg-1.0.tar.gz",
+		"odd>=1.0.post1",
+	}
+	if !reflect.DeepEqual(leftovers, expectedLeftovers) {
+		t.Errorf("expected the leftovers %q but got %q", expectedLeftovers, leftovers)
+	}
+}
@@ -0,0 +1,55 @@
+package semver
+
+import "fmt"
+
+// MaskLevel is how much of a version Mask keeps.
+type MaskLevel int
+
+const (
+	// MaskMajor keeps only the major version, so 1.2.3-beta.1 is 1.0.0.
+	MaskMajor MaskLevel = iota
+
+	// MaskMinor keeps the major and minor versions, so 1.2.3-beta.1 is
+	// 1.2.0.
+	MaskMinor
+
+	// MaskPatch keeps the major, minor, and patch versions, dropping only
+	// the prerelease and metadata, which can identify a single build. So
+	// 1.2.3-beta.1+sha.5114f85 is 1.2.3.
+	MaskPatch
+)
+
+// Mask returns the version with the segments below the level set to 0 and
+// any prerelease and metadata removed, for reporting versions without
+// revealing more than the level, as privacy conscious telemetry does. The
+// version returned has no v prefix. A nil version is returned as nil.
+func Mask(v *Version, level MaskLevel) *Version {
+	if v == nil {
+		return nil
+	}
+	switch level {
+	case MaskMajor:
+		return newCoreVersion(v.major, 0, 0)
+	case MaskMinor:
+		return newCoreVersion(v.major, v.minor, 0)
+	}
+	return newCoreVersion(v.major, v.minor, v.patch)
+}
+
+// MaskString parses a version with NewVersion and returns it masked to the
+// level with wildcards in place of the segments removed, such as 1.x.x for
+// MaskMajor and 1.2.x for MaskMinor. The wildcards keep a masked version from
+// being mistaken for a real release.
+func MaskString(v string, level MaskLevel) (string, error) {
+	sv, err := NewVersion(v)
+	if err != nil {
+		return "", err
+	}
+	switch level {
+	case MaskMajor:
+		return fmt.Sprintf("%d.x.x", sv.major), nil
+	case MaskMinor:
+		return fmt.Sprintf("%d.%d.x", sv.major, sv.minor), nil
+	}
+	return fmt.Sprintf("%d.%d.%d", sv.major, sv.minor, sv.patch), nil
+}
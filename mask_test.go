@@ -0,0 +1,40 @@
+package semver
+
+import "testing"
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		version string
+		level   MaskLevel
+		mask    string
+		str     string
+	}{
+		{"1.2.3", MaskMajor, "1.0.0", "1.x.x"},
+		{"1.2.3", MaskMinor, "1.2.0", "1.2.x"},
+		{"1.2.3", MaskPatch, "1.2.3", "1.2.3"},
+		{"v1.2.3-beta.1+sha.5114f85", MaskMajor, "1.0.0", "1.x.x"},
+		{"v1.2.3-beta.1+sha.5114f85", MaskMinor, "1.2.0", "1.2.x"},
+		{"v1.2.3-beta.1+sha.5114f85", MaskPatch, "1.2.3", "1.2.3"},
+		{"0.0.1-alpha", MaskMinor, "0.0.0", "0.0.x"},
+		{"10.20", MaskMajor, "10.0.0", "10.x.x"},
+	}
+
+	for _, tc := range tests {
+		m := Mask(MustParse(tc.version), tc.level)
+		if m.String() != tc.mask || m.Original() != tc.mask {
+			t.Errorf("expected %s masked to level %d to be %s but got %s (%s)", tc.version, tc.level, tc.mask, m, m.Original())
+		}
+
+		s, err := MaskString(tc.version, tc.level)
+		if err != nil || s != tc.str {
+			t.Errorf("expected the string %q for %s at level %d but got %q %v", tc.str, tc.version, tc.level, s, err)
+		}
+	}
+
+	if Mask(nil, MaskMajor) != nil {
+		t.Error("expected a nil version to be masked to nil")
+	}
+	if _, err := MaskString("banana", MaskMajor); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
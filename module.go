@@ -0,0 +1,147 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ModuleError is returned when a Go module path and version do not go
+// together under the Go module rules.
+type ModuleError struct {
+	Path    string
+	Version string
+
+	// Reason describes the rule the pair breaks.
+	Reason string
+}
+
+// Error returns the error in the path@version form used by the go command.
+func (e *ModuleError) Error() string {
+	return fmt.Sprintf("%s@%s: %s", e.Path, e.Version, e.Reason)
+}
+
+// ParseModuleVersion parses a Go module path@version pair, such as
+// example.com/mod/v2@v2.1.0, and checks it with CheckModuleVersion. It returns
+// the path and the parsed version.
+func ParseModuleVersion(s string) (string, *Version, error) {
+	i := strings.LastIndex(s, "@")
+	if i < 0 {
+		return "", nil, fmt.Errorf("%s is not a module path@version pair", s)
+	}
+	path, version := s[:i], s[i+1:]
+	if err := CheckModuleVersion(path, version); err != nil {
+		return "", nil, err
+	}
+	v, _ := NewVersion(version)
+	return path, v, nil
+}
+
+// CheckModuleVersion checks that a Go module version is valid for a module
+// path, following the rules of the go command. The version must be canonical
+// with a leading v, as in v1.2.3, and its major version must match the major
+// version suffix of the path:
+//
+//   - a path without a suffix takes v0 and v1 versions, or a later major
+//     version marked +incompatible
+//   - a path ending in /vN, with N of 2 or more, takes vN versions
+//   - a gopkg.in path ending in .vN takes vN versions, with .v1 also taking
+//     v0.0.0 pseudo-versions
+//
+// Only the major version suffix of the path is checked, not the rest of it.
+// A *ModuleError is returned when the pair breaks a rule.
+func CheckModuleVersion(path, version string) error {
+	fail := func(format string, args ...interface{}) error {
+		return &ModuleError{Path: path, Version: version, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	if !strings.HasPrefix(version, "v") {
+		return fail("version must start with v")
+	}
+	v, err := StrictNewVersion(version[1:])
+	if err != nil || v.String() != version[1:] {
+		return fail("version is not a canonical semantic version")
+	}
+	incompatible := v.Metadata() == "incompatible"
+	if v.Metadata() != "" && !incompatible {
+		return fail("version must not have build metadata other than +incompatible")
+	}
+
+	_, pathMajor, ok := SplitPathMajor(path)
+	if !ok {
+		return fail("path has an invalid major version suffix")
+	}
+	major := fmt.Sprintf("v%d", v.major)
+
+	switch {
+	case incompatible && pathMajor != "":
+		return fail("+incompatible is not allowed with the major version suffix %s", pathMajor)
+	case incompatible && v.major < 2:
+		return fail("+incompatible is only allowed on major versions of 2 or more")
+	case incompatible:
+		return nil
+	case pathMajor == "":
+		if v.major > 1 {
+			return fail("major version %s requires the path suffix /%s", major, major)
+		}
+		return nil
+	}
+
+	pathMajor = strings.TrimSuffix(pathMajor, "-unstable")
+	if pathMajor == ".v1" && v.major == 0 && v.minor == 0 && v.patch == 0 && v.Prerelease() != "" {
+		return nil
+	}
+	if pathMajor[1:] != major {
+		return fail("major version %s does not match the path suffix %s", major, pathMajor)
+	}
+	return nil
+}
+
+// SplitPathMajor splits a Go module path into its prefix and its major
+// version suffix, such as /v2 or, for gopkg.in paths, .v2. A path without a
+// suffix returns an empty major. ok is false when the path ends in an
+// invalid suffix such as /v1, /v02, or /v2.1, or is a gopkg.in path without
+// one.
+func SplitPathMajor(path string) (prefix, major string, ok bool) {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		return splitGopkgIn(path)
+	}
+
+	i := len(path)
+	dot := false
+	for i > 0 && (('0' <= path[i-1] && path[i-1] <= '9') || path[i-1] == '.') {
+		if path[i-1] == '.' {
+			dot = true
+		}
+		i--
+	}
+	if i <= 1 || i == len(path) || path[i-1] != 'v' || path[i-2] != '/' {
+		return path, "", true
+	}
+
+	prefix, major = path[:i-2], path[i-2:]
+	if dot || len(major) <= 2 || major[2] == '0' || major == "/v1" {
+		return path, "", false
+	}
+	return prefix, major, true
+}
+
+// splitGopkgIn splits a gopkg.in path, which always ends in a .vN suffix,
+// optionally followed by -unstable.
+func splitGopkgIn(path string) (prefix, major string, ok bool) {
+	end := len(path)
+	if strings.HasSuffix(path, "-unstable") {
+		end -= len("-unstable")
+	}
+
+	i := end
+	for i > 0 && '0' <= path[i-1] && path[i-1] <= '9' {
+		i--
+	}
+	if i <= 1 || i == end || path[i-1] != 'v' || path[i-2] != '.' {
+		return path, "", false
+	}
+	if end-i > 1 && path[i] == '0' {
+		return path, "", false
+	}
+	return path[:i-2], path[i-2:], true
+}
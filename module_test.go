@@ -0,0 +1,91 @@
+package semver
+
+import "testing"
+
+func TestCheckModuleVersion(t *testing.T) {
+	tests := []struct {
+		path, version string
+		reason        string
+	}{
+		{"example.com/mod", "v1.2.3", ""},
+		{"example.com/mod", "v0.1.0-beta.1", ""},
+		{"example.com/mod", "v0.0.0-20240101000000-abcdefabcdef", ""},
+		{"example.com/mod/v2", "v2.0.0", ""},
+		{"example.com/mod/v10", "v10.1.0", ""},
+		{"example.com/mod", "v3.0.0+incompatible", ""},
+		{"gopkg.in/yaml.v2", "v2.4.0", ""},
+		{"gopkg.in/yaml.v1", "v0.0.0-20150101000000-abcdefabcdef", ""},
+		{"gopkg.in/check.v1-unstable", "v1.0.0", ""},
+		{"example.com/mod", "1.2.3", "version must start with v"},
+		{"example.com/mod", "v1.2", "version is not a canonical semantic version"},
+		{"example.com/mod", "v1.2.3+build", "version must not have build metadata other than +incompatible"},
+		{"example.com/mod", "v2.0.0", "major version v2 requires the path suffix /v2"},
+		{"example.com/mod/v2", "v1.0.0", "major version v1 does not match the path suffix /v2"},
+		{"example.com/mod/v2", "v3.0.0", "major version v3 does not match the path suffix /v2"},
+		{"example.com/mod/v1", "v1.0.0", "path has an invalid major version suffix"},
+		{"example.com/mod/v02", "v2.0.0", "path has an invalid major version suffix"},
+		{"example.com/mod/v2", "v2.0.0+incompatible", "+incompatible is not allowed with the major version suffix /v2"},
+		{"example.com/mod", "v1.0.0+incompatible", "+incompatible is only allowed on major versions of 2 or more"},
+		{"gopkg.in/yaml", "v2.0.0", "path has an invalid major version suffix"},
+		{"gopkg.in/yaml.v2", "v3.0.0", "major version v3 does not match the path suffix .v2"},
+	}
+
+	for _, tc := range tests {
+		err := CheckModuleVersion(tc.path, tc.version)
+		if tc.reason == "" {
+			if err != nil {
+				t.Errorf("unexpected error for %s@%s: %s", tc.path, tc.version, err)
+			}
+			continue
+		}
+		merr, ok := err.(*ModuleError)
+		if !ok {
+			t.Errorf("expected a ModuleError for %s@%s but got %v", tc.path, tc.version, err)
+			continue
+		}
+		if merr.Path != tc.path || merr.Version != tc.version || merr.Reason != tc.reason {
+			t.Errorf("expected %s@%s to fail with %q but got %q", tc.path, tc.version, tc.reason, err)
+		}
+	}
+}
+
+func TestParseModuleVersion(t *testing.T) {
+	path, v, err := ParseModuleVersion("example.com/mod/v2@v2.1.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if path != "example.com/mod/v2" || v.Original() != "v2.1.0" || v.Major() != 2 {
+		t.Errorf("expected example.com/mod/v2 and v2.1.0 but got %s and %s", path, v)
+	}
+
+	if _, _, err := ParseModuleVersion("example.com/mod"); err == nil {
+		t.Error("expected an error for a path without a version")
+	}
+	_, _, err = ParseModuleVersion("example.com/mod@v2.0.0")
+	if err == nil || err.Error() != "example.com/mod@v2.0.0: major version v2 requires the path suffix /v2" {
+		t.Errorf("expected the error to name the pair and reason but got %v", err)
+	}
+}
+
+func TestSplitPathMajor(t *testing.T) {
+	tests := []struct {
+		path, prefix, major string
+		ok                  bool
+	}{
+		{"example.com/mod", "example.com/mod", "", true},
+		{"example.com/mod/v2", "example.com/mod", "/v2", true},
+		{"example.com/mod/v1", "example.com/mod/v1", "", false},
+		{"example.com/mod/v2.1", "example.com/mod/v2.1", "", false},
+		{"example.com/v2mod", "example.com/v2mod", "", true},
+		{"gopkg.in/yaml.v3", "gopkg.in/yaml", ".v3", true},
+		{"gopkg.in/check.v1-unstable", "gopkg.in/check", ".v1-unstable", true},
+		{"gopkg.in/yaml", "gopkg.in/yaml", "", false},
+	}
+
+	for _, tc := range tests {
+		prefix, major, ok := SplitPathMajor(tc.path)
+		if prefix != tc.prefix || major != tc.major || ok != tc.ok {
+			t.Errorf("expected %s to split into %q, %q, %t but got %q, %q, %t", tc.path, tc.prefix, tc.major, tc.ok, prefix, major, ok)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// NpmOptions returns the match options following npm's node-semver. A
+// prerelease only satisfies a range naming a prerelease of the same release,
+// and ~ naming a prerelease only admits prereleases of that release.
+func NpmOptions() MatchOptions {
+	return MatchOptions{
+		Prerelease: PrereleaseScoped,
+		Tilde:      TildePrereleaseSameRelease,
+	}
+}
+
+// NpmSpecKind is the kind of a package.json dependency specifier.
+type NpmSpecKind int
+
+const (
+	// NpmRange is a semver range, such as ^1.2.3. The empty string and *
+	// admit any version.
+	NpmRange NpmSpecKind = iota
+
+	// NpmTag is a dist-tag, such as latest or next.
+	NpmTag
+
+	// NpmWorkspace is a package of the same workspace, such as workspace:*
+	// or workspace:^1.2.
+	NpmWorkspace
+
+	// NpmAlias installs another package under the name, such as
+	// npm:other@^1.2.
+	NpmAlias
+
+	// NpmGit is a git repository, such as git+https://host/repo.git,
+	// github:user/repo, or the user/repo shorthand.
+	NpmGit
+
+	// NpmURL is a tarball URL.
+	NpmURL
+
+	// NpmPath is a local directory or tarball, such as file:../lib.
+	NpmPath
+
+	// NpmInvalid is a specifier that is none of the others.
+	NpmInvalid
+)
+
+// String returns a short name for the kind of specifier.
+func (k NpmSpecKind) String() string {
+	switch k {
+	case NpmRange:
+		return "range"
+	case NpmTag:
+		return "tag"
+	case NpmWorkspace:
+		return "workspace"
+	case NpmAlias:
+		return "alias"
+	case NpmGit:
+		return "git"
+	case NpmURL:
+		return "url"
+	case NpmPath:
+		return "path"
+	default:
+		return "invalid"
+	}
+}
+
+// NpmDependency is a dependency of a package.json file.
+type NpmDependency struct {
+	Name string
+	Spec string
+	Kind NpmSpecKind
+
+	// Constraint is the range of an NpmRange specifier, or of an NpmWorkspace
+	// or NpmAlias specifier naming one, parsed with NpmOptions. It is nil
+	// for other specifiers.
+	Constraint *Constraints
+
+	// Dev is true for a dependency from devDependencies.
+	Dev bool
+}
+
+// npmTag matches the names npm accepts as dist-tags.
+var npmTag = newLazyRegexp(`^[A-Za-z][A-Za-z0-9._-]*$`)
+
+// npmGitShorthand matches the user/repo shorthand for GitHub repositories,
+// optionally followed by a #committish.
+var npmGitShorthand = newLazyRegexp(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+(#.*)?$`)
+
+// NpmDependencies classifies the specifiers of package.json dependencies and
+// devDependencies maps by name. A package in both is reported from
+// dependencies. Specifiers that are not semver ranges, such as git URLs and
+// dist-tags, are classified by their kind rather than reported as errors.
+func NpmDependencies(dependencies, devDependencies map[string]string) map[string]NpmDependency {
+	out := make(map[string]NpmDependency, len(dependencies)+len(devDependencies))
+	for name, spec := range devDependencies {
+		d := parseNpmSpec(spec)
+		d.Name, d.Dev = name, true
+		out[name] = d
+	}
+	for name, spec := range dependencies {
+		d := parseNpmSpec(spec)
+		d.Name = name
+		out[name] = d
+	}
+	return out
+}
+
+// ParsePackageJSON reads the dependencies and devDependencies of a
+// package.json file the same way as NpmDependencies.
+func ParsePackageJSON(data []byte) (map[string]NpmDependency, error) {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("invalid package.json: %s", err)
+	}
+	return NpmDependencies(pkg.Dependencies, pkg.DevDependencies), nil
+}
+
+// parseNpmSpec classifies a dependency specifier and parses its range.
+func parseNpmSpec(spec string) NpmDependency {
+	d := NpmDependency{Spec: spec}
+	s := strings.TrimSpace(spec)
+
+	switch {
+	case strings.HasPrefix(s, "workspace:"):
+		d.Kind = NpmWorkspace
+		d.Constraint = npmRange(strings.TrimPrefix(s, "workspace:"))
+	case strings.HasPrefix(s, "npm:"):
+		d.Kind = NpmAlias
+		if i := strings.LastIndex(s, "@"); i > len("npm:") {
+			d.Constraint = npmRange(s[i+1:])
+		} else {
+			d.Constraint = npmRange("")
+		}
+	case hasAnyPrefix(s, "git+", "git:", "github:", "gitlab:", "bitbucket:", "gist:"):
+		d.Kind = NpmGit
+	case hasAnyPrefix(s, "http://", "https://"):
+		d.Kind = NpmURL
+	case hasAnyPrefix(s, "file:", "link:", "./", "../", "/", "~/"):
+		d.Kind = NpmPath
+	default:
+		if c := npmRange(s); c != nil {
+			d.Kind, d.Constraint = NpmRange, c
+		} else if npmTag.MatchString(s) {
+			d.Kind = NpmTag
+		} else if npmGitShorthand.MatchString(s) {
+			d.Kind = NpmGit
+		} else {
+			d.Kind = NpmInvalid
+		}
+	}
+	return d
+}
+
+// npmRange parses an npm range, treating the empty string as *. It returns
+// nil when the range is not valid.
+func npmRange(s string) *Constraints {
+	if strings.TrimSpace(s) == "" {
+		s = "*"
+	}
+	c, err := NewConstraintWithOptions(s, NpmOptions())
+	if err != nil {
+		return nil
+	}
+	return c
+}
+
+func hasAnyPrefix(s string, prefixes ...string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
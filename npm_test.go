@@ -0,0 +1,94 @@
+package semver
+
+import "testing"
+
+func TestNpmDependencies(t *testing.T) {
+	data := []byte(`{
+		"name": "app",
+		"dependencies": {
+			"caret": "^1.2.3",
+			"any": "*",
+			"empty": "",
+			"latest": "latest",
+			"ws": "workspace:^2.0.0",
+			"ws-any": "workspace:*",
+			"ws-caret": "workspace:^",
+			"alias": "npm:other@~3.1.0",
+			"scoped-alias": "npm:@scope/other",
+			"git": "git+https://github.com/user/repo.git#v1.0.0",
+			"github": "github:user/repo",
+			"shorthand": "user/repo#main",
+			"tarball": "https://example.com/pkg-1.0.0.tgz",
+			"local": "file:../lib",
+			"hyphen": "1.2.3 - 2.3.4",
+			"bad": ">=1.2.3 <"
+		},
+		"devDependencies": {
+			"caret": "^9.0.0",
+			"test": "~4.1.0"
+		}
+	}`)
+
+	deps, err := ParsePackageJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := []struct {
+		name    string
+		kind    NpmSpecKind
+		admits  string
+		rejects string
+		dev     bool
+	}{
+		{"caret", NpmRange, "1.9.0", "2.0.0", false},
+		{"any", NpmRange, "7.0.0", "", false},
+		{"empty", NpmRange, "0.1.0", "", false},
+		{"latest", NpmTag, "", "", false},
+		{"ws", NpmWorkspace, "2.4.0", "3.0.0", false},
+		{"ws-any", NpmWorkspace, "0.0.1", "", false},
+		{"ws-caret", NpmWorkspace, "", "", false},
+		{"alias", NpmAlias, "3.1.9", "3.2.0", false},
+		{"scoped-alias", NpmAlias, "5.0.0", "", false},
+		{"git", NpmGit, "", "", false},
+		{"github", NpmGit, "", "", false},
+		{"shorthand", NpmGit, "", "", false},
+		{"tarball", NpmURL, "", "", false},
+		{"local", NpmPath, "", "", false},
+		{"hyphen", NpmRange, "2.3.4", "2.3.5", false},
+		{"bad", NpmInvalid, "", "", false},
+		{"test", NpmRange, "4.1.7", "4.2.0", true},
+	}
+
+	if len(deps) != len(tests) {
+		t.Errorf("expected %d dependencies but got %d", len(tests), len(deps))
+	}
+	for _, tc := range tests {
+		d, ok := deps[tc.name]
+		if !ok {
+			t.Errorf("expected a dependency named %s", tc.name)
+			continue
+		}
+		if d.Name != tc.name || d.Kind != tc.kind || d.Dev != tc.dev {
+			t.Errorf("expected %s to be a %s dependency with dev %t but got %s %s with dev %t", tc.name, tc.kind, tc.dev, d.Name, d.Kind, d.Dev)
+		}
+		hasRange := tc.admits != ""
+		if (d.Constraint != nil) != hasRange {
+			t.Errorf("expected %s (%q) to have a constraint to be %t", tc.name, d.Spec, hasRange)
+			continue
+		}
+		if tc.admits != "" && !d.Constraint.Check(MustParse(tc.admits)) {
+			t.Errorf("expected %s (%q) to admit %s", tc.name, d.Spec, tc.admits)
+		}
+		if tc.rejects != "" && d.Constraint.Check(MustParse(tc.rejects)) {
+			t.Errorf("expected %s (%q) not to admit %s", tc.name, d.Spec, tc.rejects)
+		}
+	}
+
+	if deps["caret"].Constraint.Options() != NpmOptions() {
+		t.Errorf("expected npm ranges to use NpmOptions")
+	}
+	if _, err := ParsePackageJSON([]byte(`{"dependencies": []}`)); err == nil {
+		t.Error("expected an error for invalid dependencies")
+	}
+}
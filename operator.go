@@ -0,0 +1,156 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// OperatorFunc expands a clause written with an operator registered with
+// RegisterOperator into standard constraints. It is passed the version
+// written after the operator, such as 1.2 for ~=1.2, or "" when there is
+// none, and returns the constraints to use in its place. These may have ||
+// branches, which are distributed over the group the clause is in, so
+// `>=2.1 @lts` with @lts expanding to `^2.4 || ^3.1` is read as
+// `>=2.1 ^2.4 || >=2.1 ^3.1`. An error is returned from the parse.
+type OperatorFunc func(version string) (string, error)
+
+// operatorsHolder wraps the operators so an atomic.Value always stores the
+// same type. The operators are never modified once stored.
+type operatorsHolder struct {
+	funcs map[string]OperatorFunc
+
+	// re matches an operator and its version. The operator must start the
+	// input or follow whitespace, a comma, or the | of a || written without
+	// spaces.
+	re *regexp.Regexp
+}
+
+var (
+	installedOperators atomic.Value
+	operatorsMu        sync.Mutex
+)
+
+// RegisterOperator adds an operator to constraints for the whole process,
+// replacing any registered before with the same name. A clause written with
+// it is expanded by expand before the constraints are parsed, so operators
+// such as ~= can be read as ~ and company specific ones such as @lts can
+// name the versions they stand for. The constraints parsed hold only the
+// standard operators and render as the expansion. A nil expand removes the
+// operator. Constraints already held by a ConstraintCache are not affected.
+//
+// The operator cannot be one of the standard operators, contain whitespace,
+// a comma, or a |, or start with a character that can start a version.
+func RegisterOperator(op string, expand OperatorFunc) error {
+	if err := validateOperator(op); err != nil {
+		return err
+	}
+
+	operatorsMu.Lock()
+	defer operatorsMu.Unlock()
+
+	h, _ := installedOperators.Load().(operatorsHolder)
+	funcs := make(map[string]OperatorFunc, len(h.funcs)+1)
+	for k, f := range h.funcs {
+		funcs[k] = f
+	}
+	if expand == nil {
+		delete(funcs, op)
+	} else {
+		funcs[op] = expand
+	}
+	installedOperators.Store(newOperatorsHolder(funcs))
+	return nil
+}
+
+// validateOperator returns an error when op cannot be registered.
+func validateOperator(op string) error {
+	switch {
+	case op == "":
+		return fmt.Errorf("an operator cannot be empty")
+	case strings.ContainsAny(op, ",| \t\r\n\v\f"):
+		return fmt.Errorf("the operator %q cannot contain whitespace, a comma, or a |", op)
+	case isRangeChar(op[0]):
+		return fmt.Errorf("the operator %q cannot start with a character that can start a version", op)
+	}
+	if _, ok := constraintOps[op]; ok {
+		return fmt.Errorf("the operator %q is a standard operator", op)
+	}
+	return nil
+}
+
+// newOperatorsHolder creates the holder for the operators, with the longest
+// operators first in the regular expression so ~== is not read as ~=.
+func newOperatorsHolder(funcs map[string]OperatorFunc) operatorsHolder {
+	if len(funcs) == 0 {
+		return operatorsHolder{}
+	}
+	ops := make([]string, 0, len(funcs))
+	for k := range funcs {
+		ops = append(ops, k)
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if len(ops[i]) != len(ops[j]) {
+			return len(ops[i]) > len(ops[j])
+		}
+		return ops[i] < ops[j]
+	})
+	for k, op := range ops {
+		ops[k] = regexp.QuoteMeta(op)
+	}
+	re := regexp.MustCompile(fmt.Sprintf(`(^|[\s,|])(%s)(?:\s*(%s))?`, strings.Join(ops, "|"), cvRegex))
+	return operatorsHolder{funcs: funcs, re: re}
+}
+
+// expandOperators rewrites the clauses of c written with registered
+// operators into their expansions. c is returned as is when there are none.
+func expandOperators(c string) (string, error) {
+	h, ok := installedOperators.Load().(operatorsHolder)
+	if !ok || h.re == nil || !h.re.MatchString(c) {
+		return c, nil
+	}
+
+	var out []string
+	for _, branch := range strings.Split(c, "||") {
+		expanded, err := h.expandBranch(branch)
+		if err != nil {
+			return "", err
+		}
+		out = append(out, expanded...)
+	}
+	return strings.Join(out, " || "), nil
+}
+
+// expandBranch returns the branches a || branch expands to, one for each
+// combination of the branches of the expansions in it.
+func (h operatorsHolder) expandBranch(b string) ([]string, error) {
+	out := []string{""}
+	pos := 0
+	for _, m := range h.re.FindAllStringSubmatchIndex(b, -1) {
+		op := b[m[4]:m[5]]
+		version := ""
+		if m[6] >= 0 {
+			version = b[m[6]:m[7]]
+		}
+		e, err := h.funcs[op](version)
+		if err != nil {
+			return nil, fmt.Errorf("unable to expand %s%s: %s", op, version, err)
+		}
+
+		lit := b[pos:m[4]]
+		next := make([]string, 0, len(out))
+		for _, prefix := range out {
+			for _, alt := range strings.Split(e, "||") {
+				next = append(next, prefix+lit+strings.TrimSpace(alt)+" ")
+			}
+		}
+		out, pos = next, m[1]
+	}
+	for k := range out {
+		out[k] = strings.TrimSpace(out[k] + b[pos:])
+	}
+	return out, nil
+}
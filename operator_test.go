@@ -0,0 +1,87 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegisterOperator(t *testing.T) {
+	tilde := func(v string) (string, error) { return "~" + v, nil }
+	lts := func(v string) (string, error) {
+		if v != "" {
+			return "", errors.New("takes no version")
+		}
+		return "^2.4 || ^3.1", nil
+	}
+	if err := RegisterOperator("~=", tilde); err != nil {
+		t.Fatal(err)
+	}
+	defer RegisterOperator("~=", nil)
+	if err := RegisterOperator("@lts", lts); err != nil {
+		t.Fatal(err)
+	}
+	defer RegisterOperator("@lts", nil)
+
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{"~=1.2", "~1.2"},
+		{"~= 1.2.3", "~1.2.3"},
+		{">=1, ~=1.4", ">=1 ~1.4"},
+		{"^0.9 || ~=1.4", "^0.9 || ~1.4"},
+		{"^0.9||~=1.4", "^0.9 || ~1.4"},
+		{"^0.9 ||~=1.4", "^0.9 || ~1.4"},
+		{"~=1.2||@lts", "~1.2 || ^2.4 || ^3.1"},
+		{"@lts", "^2.4 || ^3.1"},
+		{">=2.5 @lts", ">=2.5 ^2.4 || >=2.5 ^3.1"},
+		{"@lts,!=3.1.2", "^2.4 !=3.1.2 || ^3.1 !=3.1.2"},
+		{"~1.2", "~1.2"},
+	}
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Errorf("unexpected error parsing %s: %s", tc.constraint, err)
+			continue
+		}
+		want := mustConstraint(t, tc.expected)
+		if !c.Eq(want) || c.String() != want.String() {
+			t.Errorf("expected %s to parse as %s but got %s", tc.constraint, want, c)
+		}
+	}
+
+	if _, err := NewConstraint("@lts 2"); err == nil || !strings.Contains(err.Error(), "takes no version") {
+		t.Errorf("expected the expansion error but got %v", err)
+	}
+
+	RegisterOperator("@lts", nil)
+	if _, err := NewConstraint("@lts"); err == nil {
+		t.Error("expected an error for an operator that was removed")
+	}
+}
+
+func TestRegisterOperatorTrace(t *testing.T) {
+	if err := RegisterOperator("~=", func(v string) (string, error) { return "~" + v, nil }); err != nil {
+		t.Fatal(err)
+	}
+	defer RegisterOperator("~=", nil)
+
+	_, tr, err := NewConstraintWithTrace("~=1.2 || ^2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := tr.Steps[0]; s.Stage != TraceOperator || s.Input != "~=1.2 || ^2" || s.Output != "~1.2 || ^2" {
+		t.Errorf("unexpected first step %+v", s)
+	}
+}
+
+func TestRegisterOperatorInvalid(t *testing.T) {
+	f := func(v string) (string, error) { return v, nil }
+	for _, op := range []string{"", ">=", "~", "@ lts", "a,b", "||", "1x", "v", "*"} {
+		if err := RegisterOperator(op, f); err == nil {
+			RegisterOperator(op, nil)
+			t.Errorf("expected an error registering %q", op)
+		}
+	}
+}
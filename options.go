@@ -0,0 +1,308 @@
+package semver
+
+import "strings"
+
+// PrereleasePolicy controls when prerelease versions satisfy constraints.
+type PrereleasePolicy int
+
+const (
+	// PrereleaseExplicit only lets a prerelease satisfy a constraint that
+	// names a prerelease itself. For example, >=1.2.3-0 admits 1.4.0-beta.1
+	// while >=1.2.3 does not. This is the default.
+	PrereleaseExplicit PrereleasePolicy = iota
+
+	// PrereleaseScoped follows npm. A prerelease only satisfies a group of AND
+	// constraints when one of them names a prerelease with the same major,
+	// minor, and patch version. For example, >=1.2.3-beta admits 1.2.3-rc.1
+	// but not 1.4.0-rc.1.
+	PrereleaseScoped
+
+	// PrereleaseInclude compares prereleases like any other version. For
+	// example, >=1.2.3 admits 1.4.0-beta.1.
+	PrereleaseInclude
+)
+
+// MetadataPolicy controls how build metadata is treated when checking versions
+// against constraints.
+type MetadataPolicy int
+
+const (
+	// MetadataIgnore ignores build metadata as the spec requires when
+	// determining precedence. For example, =1.2.3+abc admits 1.2.3+def. This is
+	// the default.
+	MetadataIgnore MetadataPolicy = iota
+
+	// MetadataEqual requires = and != constraints to have the same metadata as
+	// the version. For example, =1.2.3+sha.abc only admits 1.2.3+sha.abc and
+	// =1.2.3 only admits 1.2.3 without metadata. Other operators ignore
+	// metadata.
+	MetadataEqual
+
+	// MetadataOrdered places versions with equal precedence into a total
+	// order using their metadata. A version without metadata comes first and
+	// metadata identifiers are compared the same way as prerelease
+	// identifiers. For example, >1.2.3+build.9 admits 1.2.3+build.10.
+	MetadataOrdered
+)
+
+// CaretPolicy controls how the caret (^) operator expands for versions with a
+// major version of 0. Ecosystems differ on this and the policies allow each to
+// be evaluated faithfully. Versions with a major version above 0 always
+// expand to the next major version.
+type CaretPolicy int
+
+const (
+	// CaretLeftmostNonZero fixes the leftmost non-zero segment as npm and
+	// Cargo do. ^0.2.3 is >=0.2.3 <0.3.0 and ^0.0.3 is >=0.0.3 <0.0.4. This
+	// is the default.
+	CaretLeftmostNonZero CaretPolicy = iota
+
+	// CaretMinor fixes the minor version of 0.y.z versions, including 0.0.z,
+	// as Dart's pub does. ^0.2.3 is >=0.2.3 <0.3.0 and ^0.0.3 is
+	// >=0.0.3 <0.1.0.
+	CaretMinor
+
+	// CaretMajor gives 0.y.z versions no special treatment. ^0.2.3 is
+	// >=0.2.3 <1.0.0 and ^0.0.3 is >=0.0.3 <1.0.0.
+	CaretMajor
+)
+
+// TildePolicy controls which prereleases a tilde (~) constraint that names a
+// prerelease admits.
+type TildePolicy int
+
+const (
+	// TildePrereleaseRange admits any prerelease within the range. For
+	// example, ~1.2.3-beta.2 admits 1.2.3-beta.3 and 1.2.5-alpha but not
+	// 1.3.0-alpha. This is the default.
+	TildePrereleaseRange TildePolicy = iota
+
+	// TildePrereleaseSameRelease follows node-semver and only admits
+	// prereleases of the release the tilde names. For example, ~1.2.3-beta.2
+	// admits 1.2.3-beta.3 but not 1.2.5-alpha. Releases in the range, such as
+	// 1.2.5, are admitted either way.
+	TildePrereleaseSameRelease
+)
+
+// PartialPolicy controls how a constraint with segments left off, such as
+// >1.2, is interpreted. Ecosystems disagree on this. The tilde (~) and caret
+// (^) operators define their own meaning for partial versions and are not
+// affected. Neither are versions using an explicit wildcard, such as >1.2.x.
+type PartialPolicy int
+
+const (
+	// PartialWildcard treats left off segments as wildcards. For example,
+	// >1.2 is >=1.3.0, <=1.2 is <1.3.0, and =1.2 is >=1.2.0 <1.3.0. This is
+	// the default.
+	PartialWildcard PartialPolicy = iota
+
+	// PartialZero fills left off segments with 0. For example, >1.2 is
+	// >1.2.0, <=1.2 is <=1.2.0, and =1.2 is =1.2.0.
+	PartialZero
+)
+
+// PessimisticPolicy controls the precision of the pessimistic (~>) operator.
+// The ~ operator is not affected.
+type PessimisticPolicy int
+
+const (
+	// PessimisticTilde treats ~> the same as ~. For example, ~>1.2 and
+	// ~>1.2.3 are both below 1.3.0. This is the default.
+	PessimisticTilde PessimisticPolicy = iota
+
+	// PessimisticRubyGems follows RubyGems and drops the last segment
+	// written before incrementing. For example, ~>1.2 is >=1.2.0 <2.0.0 while
+	// ~>1.2.3 is >=1.2.3 <1.3.0.
+	PessimisticRubyGems
+)
+
+// MatchOptions controls how versions are checked against constraints. The
+// zero value is the behavior of Check and Validate. Options are passed per
+// call, rather than set on the package, so different callers in the same
+// process can use different policies.
+type MatchOptions struct {
+	// Prerelease controls when prerelease versions satisfy constraints.
+	Prerelease PrereleasePolicy
+
+	// Metadata controls how build metadata is compared.
+	Metadata MetadataPolicy
+
+	// Caret controls how ^ expands for 0.y.z versions.
+	Caret CaretPolicy
+
+	// Tilde controls which prereleases ~ admits when it names a prerelease.
+	Tilde TildePolicy
+
+	// Partial controls whether left off segments are wildcards or 0.
+	Partial PartialPolicy
+
+	// Pessimistic controls how far ~> widens based on the segments written.
+	Pessimistic PessimisticPolicy
+
+	// FoldPrereleaseCase compares prerelease identifiers without regard to
+	// case so 1.0.0-RC.1 and 1.0.0-rc.1 are treated as the same release. By
+	// default the spec's ASCII ordering is used where RC sorts before rc.
+	FoldPrereleaseCase bool
+
+	// LegacyPrereleaseOrder compares prerelease identifiers the way earlier
+	// releases of this package did rather than exactly as section 11 of the
+	// spec describes. The only difference is that numeric identifiers too
+	// large to fit in a uint64 were compared as alphanumeric identifiers. For
+	// example, 1.0.0-100000000000000000000 sorted before
+	// 1.0.0-99999999999999999999. This is for users depending on the previous
+	// order.
+	LegacyPrereleaseOrder bool
+}
+
+// compare compares two versions the same way as Version.Compare while
+// applying the metadata policy.
+func (o *MatchOptions) compare(v, c *Version) int {
+	if v == nil || c == nil {
+		return compareNil(v, c)
+	}
+	if o.FoldPrereleaseCase {
+		v, c = foldPrerelease(v), foldPrerelease(c)
+	}
+
+	d := v.compare(c, o.LegacyPrereleaseOrder)
+	if d != 0 || o.Metadata != MetadataOrdered {
+		return d
+	}
+	return compareMetadata(v.Metadata(), c.Metadata())
+}
+
+// equal reports if two versions are equal while applying the metadata policy.
+func (o *MatchOptions) equal(v, c *Version) bool {
+	if o.compare(v, c) != 0 {
+		return false
+	}
+	if v == nil {
+		return true
+	}
+	return o.Metadata != MetadataEqual || v.Metadata() == c.Metadata()
+}
+
+// compareMetadata orders build metadata. No metadata comes first and the
+// identifiers are compared like prerelease identifiers. Metadata differing only
+// in numeric formatting, such as 01 and 1, falls back to a string comparison
+// so the order is total.
+func compareMetadata(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return -1
+	}
+	if b == "" {
+		return 1
+	}
+	if d := comparePrerelease(a, b, false); d != 0 {
+		return d
+	}
+	return strings.Compare(a, b)
+}
+
+// clauseOptions returns the options a clause is checked with: its own when it
+// has them and otherwise o, the options of the constraints holding it.
+func clauseOptions(c *constraint, o *MatchOptions) *MatchOptions {
+	if c.opts != nil {
+		return c.opts
+	}
+	return o
+}
+
+// mixedGroup reports if any clause of an AND group has options or a scope of
+// its own.
+func mixedGroup(group []*constraint) bool {
+	for _, c := range group {
+		if c.opts != nil || c.scope != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// groupPart is the clauses of an AND group that are checked with the same
+// options and scope. A version satisfies the group when it satisfies every
+// part, each checked with its own options.
+type groupPart struct {
+	clauses []*constraint
+	opts    *MatchOptions
+	scope   int
+}
+
+// groupParts splits an AND group into parts by the options and scopes of its
+// clauses, in the order they first appear. When own is false the options and
+// scopes of the clauses are ignored and the whole group is checked with o.
+func groupParts(group []*constraint, o *MatchOptions, own bool) []groupPart {
+	if !own || !mixedGroup(group) {
+		return []groupPart{{clauses: group, opts: o}}
+	}
+	var parts []groupPart
+	for _, c := range group {
+		co := clauseOptions(c, o)
+		found := false
+		for k := range parts {
+			if *parts[k].opts == *co && parts[k].scope == c.scope {
+				parts[k].clauses = append(parts[k].clauses, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			parts = append(parts, groupPart{clauses: []*constraint{c}, opts: co, scope: c.scope})
+		}
+	}
+	return parts
+}
+
+// partOf returns the part of parts holding the clause c.
+func partOf(parts []groupPart, c *constraint, o *MatchOptions) groupPart {
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	co := clauseOptions(c, o)
+	for _, p := range parts {
+		if *p.opts == *co && p.scope == c.scope {
+			return p
+		}
+	}
+	return parts[0]
+}
+
+// groupOptions returns the options each constraint in an AND group should be
+// checked with. It returns false when v cannot satisfy the group at all.
+func groupOptions(v *Version, group []*constraint, o MatchOptions) (MatchOptions, bool) {
+	if o.Prerelease != PrereleaseScoped || v.Prerelease() == "" {
+		return o, true
+	}
+
+	// Once a constraint in the group names a prerelease of the same release
+	// the prerelease is compared like any other version.
+	for _, c := range group {
+		if c.con.Prerelease() != "" && sameRelease(c.con, v) {
+			o.Prerelease = PrereleaseInclude
+			return o, true
+		}
+	}
+	return o, false
+}
+
+// sameRelease reports if two versions have the same major, minor, and patch
+// versions.
+func sameRelease(a, b *Version) bool {
+	return a.major == b.major && a.minor == b.minor && a.patch == b.patch
+}
+
+// foldPrerelease returns the version with its prerelease in lower case. The
+// version is returned as is when there is nothing to fold.
+func foldPrerelease(v *Version) *Version {
+	pre := v.Prerelease()
+	l := strings.ToLower(pre)
+	if l == pre {
+		return v
+	}
+	f := *v
+	f.set(l, v.Metadata(), v.Original())
+	return &f
+}
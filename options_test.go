@@ -0,0 +1,352 @@
+package semver
+
+import "testing"
+
+func TestCheckWithOptionsPrerelease(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		policy     PrereleasePolicy
+		check      bool
+	}{
+		{">=1.2.3", "1.4.0-beta.1", PrereleaseExplicit, false},
+		{">=1.2.3", "1.4.0-beta.1", PrereleaseScoped, false},
+		{">=1.2.3", "1.4.0-beta.1", PrereleaseInclude, true},
+		{">=1.2.3-0", "1.4.0-beta.1", PrereleaseExplicit, true},
+		{">=1.2.3-0", "1.4.0-beta.1", PrereleaseScoped, false},
+		{">=1.2.3-0", "1.4.0-beta.1", PrereleaseInclude, true},
+		{">=1.2.3-beta, <2", "1.2.3-rc.1", PrereleaseScoped, true},
+		{">=1.2.3-beta, <2", "1.2.3-alpha", PrereleaseScoped, false},
+		{">=1.2.3, <1.4.0-0", "1.4.0-alpha", PrereleaseScoped, false},
+		{">=1.2.3, <1.4.0-beta", "1.4.0-alpha", PrereleaseScoped, true},
+		{"^1.2.3", "1.2.4-rc.1", PrereleaseExplicit, false},
+		{"^1.2.3", "1.2.4-rc.1", PrereleaseInclude, true},
+		{"^1.2.3", "2.0.0-rc.1", PrereleaseInclude, false},
+		{"~1.2.3", "1.2.5-rc.1", PrereleaseInclude, true},
+		{"1.2.x", "1.2.5-rc.1", PrereleaseInclude, true},
+		{"<1.2.3 || 1.2.3-beta.2", "1.2.3-beta.2", PrereleaseScoped, true},
+		{"^1.2.3", "1.2.4", PrereleaseScoped, true},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		o := MatchOptions{Prerelease: tc.policy}
+		if a := c.CheckWithOptions(v, o); a != tc.check {
+			t.Errorf("Constraint %q with policy %d failing with %q", tc.constraint, tc.policy, tc.version)
+		}
+
+		a, msgs := c.ValidateWithOptions(v, o)
+		if a != tc.check {
+			t.Errorf("Validating constraint %q with policy %d failing with %q", tc.constraint, tc.policy, tc.version)
+		}
+		if !a && len(msgs) == 0 {
+			t.Errorf("Validating constraint %q with policy %d gave no reason for %q", tc.constraint, tc.policy, tc.version)
+		}
+	}
+}
+
+func TestValidateWithOptionsScopedMessage(t *testing.T) {
+	c, err := NewConstraint(">=1.2.3-beta")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, msgs := c.ValidateWithOptions(MustParse("1.3.0-beta"), MatchOptions{Prerelease: PrereleaseScoped})
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message but got %d", len(msgs))
+	}
+	e := "1.3.0-beta is a prerelease version and the constraint does not name a prerelease of 1.3.0"
+	if msgs[0].Error() != e {
+		t.Errorf("expected message %q but got %q", e, msgs[0])
+	}
+}
+
+func TestCheckWithOptionsMetadata(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		policy     MetadataPolicy
+		check      bool
+	}{
+		{"=1.2.3+sha.abc", "1.2.3+sha.def", MetadataIgnore, true},
+		{"=1.2.3+sha.abc", "1.2.3+sha.def", MetadataEqual, false},
+		{"=1.2.3+sha.abc", "1.2.3+sha.abc", MetadataEqual, true},
+		{"=1.2.3+sha.abc", "1.2.3", MetadataEqual, false},
+		{"=1.2.3", "1.2.3+sha.abc", MetadataEqual, false},
+		{"=1.2.3", "1.2.3", MetadataEqual, true},
+		{"!=1.2.3+sha.abc", "1.2.3+sha.def", MetadataIgnore, false},
+		{"!=1.2.3+sha.abc", "1.2.3+sha.def", MetadataEqual, true},
+		{">=1.2.3+sha.abc", "1.2.3+sha.def", MetadataEqual, true},
+		{">1.2.3+build.9", "1.2.3+build.10", MetadataIgnore, false},
+		{">1.2.3+build.9", "1.2.3+build.10", MetadataOrdered, true},
+		{">1.2.3+build.9", "1.2.3+build.8", MetadataOrdered, false},
+		{"<1.2.3+build.9", "1.2.3", MetadataOrdered, true},
+		{"=1.2.3+sha.abc", "1.2.3+sha.abd", MetadataOrdered, false},
+		{"^1.2.3+build.5", "1.2.3+build.4", MetadataOrdered, false},
+		{"^1.2.3+build.5", "1.2.3+build.6", MetadataOrdered, true},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		o := MatchOptions{Metadata: tc.policy}
+		if a := c.CheckWithOptions(v, o); a != tc.check {
+			t.Errorf("Constraint %q with policy %d failing with %q", tc.constraint, tc.policy, tc.version)
+		}
+	}
+}
+
+func TestCompareMetadata(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", -1},
+		{"abc", "", 1},
+		{"build.9", "build.10", -1},
+		{"build.01", "build.1", -1},
+		{"build.1", "build.01", 1},
+		{"sha.abc", "sha.abc", 0},
+		{"b", "a", 1},
+	}
+
+	for _, tc := range tests {
+		if d := compareMetadata(tc.a, tc.b); d != tc.expected {
+			t.Errorf("expected %q compared to %q to be %d but got %d", tc.a, tc.b, tc.expected, d)
+		}
+	}
+}
+
+func TestCheckWithOptionsCaret(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		policy     CaretPolicy
+		check      bool
+	}{
+		{"^0.2.3", "0.2.9", CaretLeftmostNonZero, true},
+		{"^0.2.3", "0.3.0", CaretLeftmostNonZero, false},
+		{"^0.0.3", "0.0.3", CaretLeftmostNonZero, true},
+		{"^0.0.3", "0.0.4", CaretLeftmostNonZero, false},
+		{"^0.0.3", "0.2.3", CaretLeftmostNonZero, false},
+		{"^0.2.3", "0.3.0", CaretMinor, false},
+		{"^0.0.3", "0.0.4", CaretMinor, true},
+		{"^0.0.3", "0.1.0", CaretMinor, false},
+		{"^0.0.3", "0.0.2", CaretMinor, false},
+		{"^0.2.3", "0.3.0", CaretMajor, true},
+		{"^0.2.3", "0.2.2", CaretMajor, false},
+		{"^0.0.3", "0.9.0", CaretMajor, true},
+		{"^0.0.3", "1.0.0", CaretMajor, false},
+		{"^1.2.3", "1.9.0", CaretMinor, true},
+		{"^1.2.3", "2.0.0", CaretMajor, false},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		o := MatchOptions{Caret: tc.policy}
+		if a := c.CheckWithOptions(v, o); a != tc.check {
+			t.Errorf("Constraint %q with policy %d failing with %q", tc.constraint, tc.policy, tc.version)
+		}
+	}
+}
+
+func TestCheckWithOptionsTilde(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		policy     TildePolicy
+		check      bool
+	}{
+		{"~1.2.3-beta.2", "1.2.3-beta.3", TildePrereleaseRange, true},
+		{"~1.2.3-beta.2", "1.2.3-beta.1", TildePrereleaseRange, false},
+		{"~1.2.3-beta.2", "1.2.5-alpha", TildePrereleaseRange, true},
+		{"~1.2.3-beta.2", "1.2.5", TildePrereleaseRange, true},
+		{"~1.2.3-beta.2", "1.3.0-alpha", TildePrereleaseRange, false},
+		{"~1.2.3-beta.2", "1.2.3-beta.3", TildePrereleaseSameRelease, true},
+		{"~1.2.3-beta.2", "1.2.3-beta.1", TildePrereleaseSameRelease, false},
+		{"~1.2.3-beta.2", "1.2.5-alpha", TildePrereleaseSameRelease, false},
+		{"~1.2.3-beta.2", "1.2.5", TildePrereleaseSameRelease, true},
+		{"~1.2.3-beta.2", "1.2.3", TildePrereleaseSameRelease, true},
+		{"~>1.2.3-beta.2", "1.2.4-rc.1", TildePrereleaseSameRelease, false},
+		{"~1.2.3", "1.2.4-rc.1", TildePrereleaseSameRelease, false},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		o := MatchOptions{Tilde: tc.policy}
+		if a := c.CheckWithOptions(v, o); a != tc.check {
+			t.Errorf("Constraint %q with policy %d failing with %q", tc.constraint, tc.policy, tc.version)
+		}
+	}
+}
+
+func TestCheckWithOptionsPartial(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		policy     PartialPolicy
+		check      bool
+	}{
+		{">=1.2", "1.2.0", PartialWildcard, true},
+		{">=1.2", "1.2.0", PartialZero, true},
+		{">1.2", "1.2.1", PartialWildcard, false},
+		{">1.2", "1.2.1", PartialZero, true},
+		{">1", "1.0.1", PartialWildcard, false},
+		{">1", "1.0.1", PartialZero, true},
+		{"<=1.2", "1.2.5", PartialWildcard, true},
+		{"<=1.2", "1.2.5", PartialZero, false},
+		{"<=1.2", "1.2.0", PartialZero, true},
+		{"=1.2", "1.2.5", PartialWildcard, true},
+		{"=1.2", "1.2.5", PartialZero, false},
+		{"1.2", "1.2.0", PartialZero, true},
+		{"!=1.2", "1.2.5", PartialWildcard, false},
+		{"!=1.2", "1.2.5", PartialZero, true},
+		{">1.2.x", "1.2.1", PartialZero, false},
+		{"<=1.x", "1.5.0", PartialZero, true},
+		{"~1", "1.5.0", PartialZero, true},
+		{"~1.2", "1.2.5", PartialZero, true},
+		{"^1", "1.5.0", PartialZero, true},
+		{"^0.2", "0.2.5", PartialZero, true},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		o := MatchOptions{Partial: tc.policy}
+		if a := c.CheckWithOptions(v, o); a != tc.check {
+			t.Errorf("Constraint %q with policy %d failing with %q", tc.constraint, tc.policy, tc.version)
+		}
+	}
+}
+
+func TestCheckWithOptionsPessimistic(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		policy     PessimisticPolicy
+		check      bool
+	}{
+		{"~>1.2", "1.2.5", PessimisticTilde, true},
+		{"~>1.2", "1.3.0", PessimisticTilde, false},
+		{"~>1.2", "1.3.0", PessimisticRubyGems, true},
+		{"~>1.2", "1.1.0", PessimisticRubyGems, false},
+		{"~>1.2", "2.0.0", PessimisticRubyGems, false},
+		{"~>1.2.3", "1.2.9", PessimisticRubyGems, true},
+		{"~>1.2.3", "1.3.0", PessimisticRubyGems, false},
+		{"~>1", "1.9.0", PessimisticRubyGems, true},
+		{"~>1", "2.0.0", PessimisticRubyGems, false},
+		{"~>0.2", "0.9.0", PessimisticRubyGems, true},
+		{"~>0.2", "1.0.0", PessimisticRubyGems, false},
+		{"~1.2", "1.3.0", PessimisticRubyGems, false},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		o := MatchOptions{Pessimistic: tc.policy}
+		if a := c.CheckWithOptions(v, o); a != tc.check {
+			t.Errorf("Constraint %q with policy %d failing with %q", tc.constraint, tc.policy, tc.version)
+		}
+	}
+}
+
+func TestCheckWithOptionsFoldPrereleaseCase(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		fold       bool
+		check      bool
+	}{
+		{"=1.0.0-RC.1", "1.0.0-rc.1", false, false},
+		{"=1.0.0-RC.1", "1.0.0-rc.1", true, true},
+		{"=1.0.0-rc.1", "1.0.0-RC.1", true, true},
+		{"!=1.0.0-rc.1", "1.0.0-RC.1", true, false},
+		{">=1.0.0-beta", "1.0.0-RC.1", false, false},
+		{">=1.0.0-beta", "1.0.0-RC.1", true, true},
+		{"~1.0.0-Beta.2", "1.0.0-beta.3", true, true},
+		{"^1.0.0-ALPHA", "1.0.0-alpha", true, true},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Errorf("err: %s", err)
+			continue
+		}
+
+		o := MatchOptions{FoldPrereleaseCase: tc.fold}
+		if a := c.CheckWithOptions(v, o); a != tc.check {
+			t.Errorf("Constraint %q with fold %t failing with %q", tc.constraint, tc.fold, tc.version)
+		}
+	}
+}
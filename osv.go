@@ -0,0 +1,159 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// OSVEvent is one of the events of a range in an OSV advisory, as found in
+// the affected[].ranges[].events of the OSV schema. Exactly one of its fields
+// is set. An Introduced of "0" means the range starts before every version.
+type OSVEvent struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// osvInterval is a range of affected versions. A nil lo or hi is unbounded.
+type osvInterval struct {
+	lo, hi *Version
+	hiIncl bool
+}
+
+// osvOptions compares prereleases like any other version, as advisories
+// affect the prereleases within their ranges too.
+var osvOptions = MatchOptions{Prerelease: PrereleaseInclude}
+
+// FromOSV returns constraints admitting the versions affected by the events
+// of an OSV range of type SEMVER. The events are evaluated as the OSV schema
+// describes, in order of their versions, so they may be given in any order.
+// Prereleases within an affected range are affected too, so the constraints
+// are created with the PrereleaseInclude policy. When no version is affected
+// the constraints admit none.
+func FromOSV(events []OSVEvent) (*Constraints, error) {
+	ivs, err := osvIntervals(events)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]string, 0, len(ivs))
+	for _, iv := range ivs {
+		var clause []string
+		if iv.lo != nil {
+			clause = append(clause, ">="+iv.lo.String())
+		}
+		if iv.hi != nil && iv.hiIncl {
+			clause = append(clause, "<="+iv.hi.String())
+		} else if iv.hi != nil {
+			clause = append(clause, "<"+iv.hi.String())
+		}
+		if len(clause) == 0 {
+			clause = append(clause, "*")
+		}
+		branches = append(branches, strings.Join(clause, " "))
+	}
+	return osvConstraint(branches)
+}
+
+// FromOSVUnaffected returns constraints admitting the versions not affected
+// by the events of an OSV range, the complement of FromOSV.
+func FromOSVUnaffected(events []OSVEvent) (*Constraints, error) {
+	ivs, err := osvIntervals(events)
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []string
+	var lo string
+	for _, iv := range ivs {
+		if iv.lo != nil {
+			branches = append(branches, strings.TrimSpace(lo+" <"+iv.lo.String()))
+		}
+		switch {
+		case iv.hi == nil:
+			return osvConstraint(branches)
+		case iv.hiIncl:
+			lo = ">" + iv.hi.String()
+		default:
+			lo = ">=" + iv.hi.String()
+		}
+	}
+	if lo == "" {
+		lo = "*"
+	}
+	return osvConstraint(append(branches, lo))
+}
+
+// osvConstraint parses the branches, or returns constraints admitting no
+// version when there are none.
+func osvConstraint(branches []string) (*Constraints, error) {
+	if len(branches) == 0 {
+		branches = []string{"<0.0.0-0"}
+	}
+	return NewConstraintWithOptions(strings.Join(branches, " || "), osvOptions)
+}
+
+// osvIntervals evaluates the events into the affected intervals in ascending
+// order. Adjacent intervals, such as one fixed in 1.2.0 and another
+// introduced in 1.2.0, are merged.
+func osvIntervals(events []OSVEvent) ([]osvInterval, error) {
+	type point struct {
+		v    *Version
+		kind string
+	}
+
+	points := make([]point, 0, len(events))
+	for _, e := range events {
+		var kind, value string
+		n := 0
+		if e.Introduced != "" {
+			kind, value = "introduced", e.Introduced
+			n++
+		}
+		if e.Fixed != "" {
+			kind, value = "fixed", e.Fixed
+			n++
+		}
+		if e.LastAffected != "" {
+			kind, value = "last_affected", e.LastAffected
+			n++
+		}
+		if n != 1 {
+			return nil, fmt.Errorf("an OSV event must have exactly one of introduced, fixed, and last_affected but has %d", n)
+		}
+
+		if kind == "introduced" && value == "0" {
+			points = append(points, point{nil, kind})
+			continue
+		}
+		v, err := NewVersion(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s version %s: %s", kind, value, err)
+		}
+		points = append(points, point{v, kind})
+	}
+
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].v.Compare(points[j].v) < 0
+	})
+
+	var ivs []osvInterval
+	open := false
+	for _, p := range points {
+		switch {
+		case p.kind == "introduced" && !open:
+			open = true
+			if n := len(ivs); n > 0 && !ivs[n-1].hiIncl && ivs[n-1].hi.Equal(p.v) {
+				ivs[n-1].hi = nil
+				continue
+			}
+			ivs = append(ivs, osvInterval{lo: p.v})
+		case p.kind != "introduced" && open:
+			open = false
+			ivs[len(ivs)-1].hi = p.v
+			ivs[len(ivs)-1].hiIncl = p.kind == "last_affected"
+		}
+	}
+	return ivs, nil
+}
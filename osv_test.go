@@ -0,0 +1,92 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFromOSV(t *testing.T) {
+	tests := []struct {
+		events     string
+		affected   string
+		unaffected string
+	}{
+		{`[{"introduced": "0"}, {"fixed": "1.2.3"}]`, "<1.2.3", ">=1.2.3"},
+		{`[{"introduced": "1.0.0"}, {"fixed": "1.2.3"}]`, ">=1.0.0 <1.2.3", "<1.0.0 || >=1.2.3"},
+		{`[{"introduced": "1.0.0"}, {"last_affected": "1.4.0"}]`, ">=1.0.0 <=1.4.0", "<1.0.0 || >1.4.0"},
+		{`[{"introduced": "1.0.0"}]`, ">=1.0.0", "<1.0.0"},
+		{`[{"introduced": "0"}]`, "*", "<0.0.0-0"},
+		{`[]`, "<0.0.0-0", "*"},
+		{`[{"fixed": "2.0.1"}, {"introduced": "2.0.0"}, {"fixed": "1.5.2"}, {"introduced": "1.0.0"}]`,
+			">=1.0.0 <1.5.2 || >=2.0.0 <2.0.1", "<1.0.0 || >=1.5.2 <2.0.0 || >=2.0.1"},
+		{`[{"introduced": "1.0.0"}, {"fixed": "1.2.0"}, {"introduced": "1.2.0"}, {"fixed": "1.3.0"}]`,
+			">=1.0.0 <1.3.0", "<1.0.0 || >=1.3.0"},
+		{`[{"introduced": "1.0.0"}, {"introduced": "1.1.0"}, {"fixed": "1.2.0"}, {"fixed": "1.3.0"}]`,
+			">=1.0.0 <1.2.0", "<1.0.0 || >=1.2.0"},
+	}
+
+	for _, tc := range tests {
+		var events []OSVEvent
+		if err := json.Unmarshal([]byte(tc.events), &events); err != nil {
+			t.Fatalf("unable to decode %s: %s", tc.events, err)
+		}
+
+		affected, err := FromOSV(events)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.events, err)
+			continue
+		}
+		if affected.String() != tc.affected {
+			t.Errorf("%s: expected the affected versions %q but got %q", tc.events, tc.affected, affected)
+		}
+
+		unaffected, err := FromOSVUnaffected(events)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.events, err)
+			continue
+		}
+		if unaffected.String() != tc.unaffected {
+			t.Errorf("%s: expected the unaffected versions %q but got %q", tc.events, tc.unaffected, unaffected)
+		}
+
+		for _, s := range []string{"0.0.1", "1.0.0-rc.1", "1.0.0", "1.2.3-beta", "1.2.3", "1.4.0", "1.5.2-0", "2.0.0", "3.0.0"} {
+			v := MustParse(s)
+			if affected.Check(v) == unaffected.Check(v) {
+				t.Errorf("%s: expected exactly one of %s and %s to admit %s", tc.events, affected, unaffected, s)
+			}
+		}
+	}
+}
+
+func TestFromOSVPrereleases(t *testing.T) {
+	c, err := FromOSV([]OSVEvent{{Introduced: "1.0.0"}, {Fixed: "1.2.3"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !c.Check(MustParse("1.2.3-rc.1")) || !c.Check(MustParse("1.1.0-beta")) {
+		t.Error("expected prereleases within the range to be affected")
+	}
+	if c.Check(MustParse("1.0.0-rc.1")) {
+		t.Error("expected a prerelease before the range not to be affected")
+	}
+}
+
+func TestFromOSVErrors(t *testing.T) {
+	tests := []struct {
+		events   []OSVEvent
+		expected string
+	}{
+		{[]OSVEvent{{}}, "an OSV event must have exactly one of introduced, fixed, and last_affected but has 0"},
+		{[]OSVEvent{{Introduced: "1.0.0", Fixed: "1.2.0"}}, "an OSV event must have exactly one of introduced, fixed, and last_affected but has 2"},
+		{[]OSVEvent{{Introduced: "0"}, {Fixed: "banana"}}, "invalid fixed version banana: Invalid Semantic Version"},
+	}
+
+	for _, tc := range tests {
+		if _, err := FromOSV(tc.events); err == nil || err.Error() != tc.expected {
+			t.Errorf("expected the error %q but got %v", tc.expected, err)
+		}
+		if _, err := FromOSVUnaffected(tc.events); err == nil || err.Error() != tc.expected {
+			t.Errorf("expected the error %q but got %v", tc.expected, err)
+		}
+	}
+}
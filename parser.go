@@ -0,0 +1,65 @@
+package semver
+
+import (
+	"strings"
+	"sync"
+)
+
+// Parser parses constraints with fixed options and its own aliases, words
+// such as "latest" or "stable" that manifest formats use in place of
+// constraints. The aliases only apply to the parser they are added to, so
+// different formats can be read side by side. It is safe for concurrent use.
+type Parser struct {
+	opts MatchOptions
+
+	mu      sync.RWMutex
+	aliases map[string]*Constraints
+}
+
+// NewParser creates a parser parsing constraints with the options.
+func NewParser(opts MatchOptions) *Parser {
+	return &Parser{opts: opts, aliases: map[string]*Constraints{}}
+}
+
+// Alias makes the word parse to c, replacing any constraints it was an alias
+// for before. Words are compared without regard to case or surrounding
+// whitespace, and "" is an alias for blank constraints. A nil c makes the word
+// parse to Any.
+func (p *Parser) Alias(word string, c *Constraints) {
+	if c == nil {
+		c = Any()
+		c.opts = p.opts
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.aliases[aliasKey(word)] = c
+}
+
+// AliasString makes the word parse to the constraints c, parsed with the
+// options of the parser.
+func (p *Parser) AliasString(word, c string) error {
+	cs, err := NewConstraintWithOptions(c, p.opts)
+	if err != nil {
+		return err
+	}
+	p.Alias(word, cs)
+	return nil
+}
+
+// Parse returns the constraints an alias stands for, or parses c with
+// NewConstraintWithOptions when it is not an alias. The constraints of an
+// alias are shared and must not be modified.
+func (p *Parser) Parse(c string) (*Constraints, error) {
+	p.mu.RLock()
+	cs, ok := p.aliases[aliasKey(c)]
+	p.mu.RUnlock()
+	if ok {
+		return cs, nil
+	}
+	return NewConstraintWithOptions(c, p.opts)
+}
+
+// aliasKey returns the form aliases are looked up by.
+func aliasKey(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
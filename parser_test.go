@@ -0,0 +1,77 @@
+package semver
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestParser(t *testing.T) {
+	p := NewParser(MatchOptions{Caret: CaretMajor})
+	p.Alias("latest", nil)
+	p.Alias("", nil)
+	if err := p.AliasString(" Stable ", "^0.2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AliasString("broken", "<<1"); err == nil {
+		t.Error("expected an error for an alias of invalid constraints")
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+		admits   string
+	}{
+		{"latest", "*", "3.0.0"},
+		{"LATEST", "*", "3.0.0"},
+		{"  ", "*", "3.0.0"},
+		{"stable", "^0.2", "0.9.0"},
+		{"^0.3", "^0.3", "0.9.0"},
+	}
+	for _, tc := range tests {
+		c, err := p.Parse(tc.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.input, err)
+			continue
+		}
+		if c.String() != tc.expected || c.Options() != p.opts {
+			t.Errorf("%q: expected %s with the parser's options but got %s", tc.input, tc.expected, c)
+		}
+		if !c.Check(MustParse(tc.admits)) {
+			t.Errorf("%q: expected %s to admit %s", tc.input, c, tc.admits)
+		}
+	}
+
+	for _, s := range []string{"broken", "any", "latest || ^1"} {
+		if _, err := p.Parse(s); err == nil {
+			t.Errorf("expected %q not to parse", s)
+		}
+	}
+	if _, err := NewConstraint("latest"); err == nil {
+		t.Error("expected the aliases of a parser not to apply to NewConstraint")
+	}
+
+	p.Alias("latest", mustConstraint(t, "^2"))
+	if c, _ := p.Parse("latest"); c.String() != "^2" {
+		t.Errorf("expected the alias to be replaced but got %s", c)
+	}
+}
+
+func TestParserConcurrent(t *testing.T) {
+	p := NewParser(MatchOptions{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			p.Alias("latest", nil)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = p.Parse("latest")
+		}()
+	}
+	wg.Wait()
+	if c, err := p.Parse("latest"); err != nil || !IsAny(c) {
+		t.Errorf("expected latest to parse to * but got %v %v", c, err)
+	}
+}
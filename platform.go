@@ -0,0 +1,124 @@
+package semver
+
+import (
+	"sort"
+	"strings"
+)
+
+// Platform is the operating system and architecture a build is for, as
+// written in the prerelease or metadata of versions such as
+// 1.2.3-linux-amd64 and 1.2.3+darwin.arm64.
+type Platform struct {
+	// OS is the operating system, such as linux, in lower case.
+	OS string
+
+	// Arch is what follows the operating system, such as amd64 or arm-v7, as
+	// written. It is empty when only the operating system is given.
+	Arch string
+}
+
+// String returns the platform in the form os/arch, or os when it has no
+// architecture.
+func (p Platform) String() string {
+	if p.Arch == "" {
+		return p.OS
+	}
+	return p.OS + "/" + p.Arch
+}
+
+// matches reports if p is the platform o asks for. Empty fields of o match
+// any value.
+func (p Platform) matches(o Platform) bool {
+	return (o.OS == "" || p.OS == strings.ToLower(o.OS)) && (o.Arch == "" || p.Arch == o.Arch)
+}
+
+// platformOSes are the operating systems recognized in a qualifier. They are
+// the values of GOOS along with common alternate names.
+var platformOSes = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true,
+	"freebsd": true, "illumos": true, "ios": true, "js": true, "linux": true,
+	"netbsd": true, "openbsd": true, "plan9": true, "solaris": true,
+	"wasip1": true, "windows": true, "macos": true, "osx": true, "win": true,
+}
+
+// SplitPlatform splits the platform qualifier out of a version. The
+// qualifier starts at the first identifier of the prerelease, or of the
+// metadata when the prerelease has none, naming an operating system, and runs
+// to the end of it. The version is returned without the qualifier, so
+// 1.2.3-rc.1.linux-amd64 is 1.2.3-rc.1 for linux/amd64 and 1.2.3-linux-amd64
+// is the release 1.2.3. The boolean is false when there is no qualifier, in
+// which case v is returned as is.
+func SplitPlatform(v *Version) (*Version, Platform, bool) {
+	if v == nil {
+		return nil, Platform{}, false
+	}
+
+	pre, metadata := v.Prerelease(), v.Metadata()
+	rest, p, ok := splitPlatform(pre)
+	if ok {
+		pre = rest
+	} else if rest, p, ok = splitPlatform(metadata); ok {
+		metadata = rest
+	} else {
+		return v, Platform{}, false
+	}
+
+	core := *v
+	core.setCanonical(pre, metadata, v.originalVPrefix())
+	return &core, p, true
+}
+
+// splitPlatform splits the dot separated identifiers of s at the first
+// identifier, or part of one between hyphens, naming an operating system.
+func splitPlatform(s string) (string, Platform, bool) {
+	for i := 0; i < len(s); i++ {
+		if i > 0 && s[i-1] != '.' && s[i-1] != '-' {
+			continue
+		}
+		end := strings.IndexAny(s[i:], ".-")
+		if end < 0 {
+			end = len(s) - i
+		}
+		name := strings.ToLower(s[i : i+end])
+		if !platformOSes[name] {
+			continue
+		}
+
+		p := Platform{OS: name}
+		if i+end < len(s) {
+			p.Arch = s[i+end+1:]
+		}
+		return strings.TrimRight(s[:i], ".-"), p, true
+	}
+	return s, Platform{}, false
+}
+
+// MatchPlatform returns the versions built for the platform whose version,
+// without the platform qualifier, the constraints admit. Empty fields of
+// the platform match any value, so Platform{OS: "linux"} matches every linux
+// build and Platform{} every version, including those without a qualifier.
+// The versions returned are sorted by their version without the qualifier,
+// keeping the order given for equal versions.
+func MatchPlatform(c *Constraints, versions []*Version, p Platform) []*Version {
+	type build struct {
+		v, core *Version
+	}
+
+	var builds []build
+	for _, v := range versions {
+		core, vp, _ := SplitPlatform(v)
+		if core == nil || !vp.matches(p) || c == nil || !c.Check(core) {
+			continue
+		}
+		builds = append(builds, build{v, core})
+	}
+
+	sort.SliceStable(builds, func(i, j int) bool {
+		return builds[i].core.LessThan(builds[j].core)
+	})
+	out := make([]*Version, len(builds))
+	for k, b := range builds {
+		out[k] = b.v
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package semver
+
+import "testing"
+
+func TestSplitPlatform(t *testing.T) {
+	tests := []struct {
+		version  string
+		core     string
+		platform string
+		ok       bool
+	}{
+		{"1.2.3-linux-amd64", "1.2.3", "linux/amd64", true},
+		{"1.2.3+darwin.arm64", "1.2.3", "darwin/arm64", true},
+		{"v1.2.3-Windows-x64", "1.2.3", "windows/x64", true},
+		{"1.2.3-rc.1.linux-arm-v7", "1.2.3-rc.1", "linux/arm-v7", true},
+		{"1.2.3-rc.1+build.5.freebsd", "1.2.3-rc.1+build.5", "freebsd", true},
+		{"1.2.3-beta+linux.amd64", "1.2.3-beta", "linux/amd64", true},
+		{"1.2.3-linuxish", "1.2.3-linuxish", "", false},
+		{"1.2.3-rc.1", "1.2.3-rc.1", "", false},
+		{"1.2.3", "1.2.3", "", false},
+	}
+
+	for _, tc := range tests {
+		core, p, ok := SplitPlatform(MustParse(tc.version))
+		if core.String() != tc.core || p.String() != tc.platform || ok != tc.ok {
+			t.Errorf("expected %s to split into %s for %q (%t) but got %s for %q (%t)", tc.version, tc.core, tc.platform, tc.ok, core, p, ok)
+		}
+	}
+
+	core, _, _ := SplitPlatform(MustParse("v1.2.3-linux-amd64"))
+	if core.Original() != "v1.2.3" {
+		t.Errorf("expected the original v1.2.3 but got %s", core.Original())
+	}
+	if core, _, ok := SplitPlatform(nil); core != nil || ok {
+		t.Errorf("expected nothing for a nil version but got %v %t", core, ok)
+	}
+}
+
+func TestMatchPlatform(t *testing.T) {
+	versions := []*Version{
+		MustParse("1.3.0-linux-amd64"),
+		MustParse("1.2.3-linux-amd64"),
+		MustParse("1.2.3-darwin-arm64"),
+		MustParse("1.2.3-linux-arm64"),
+		MustParse("1.4.0-rc.1-linux-amd64"),
+		MustParse("2.0.0-linux-amd64"),
+		MustParse("1.2.5"),
+		nil,
+	}
+
+	tests := []struct {
+		constraint string
+		platform   Platform
+		expected   []string
+	}{
+		{"^1.2", Platform{OS: "linux", Arch: "amd64"}, []string{"1.2.3-linux-amd64", "1.3.0-linux-amd64"}},
+		{"^1.2", Platform{OS: "Linux"}, []string{"1.2.3-linux-amd64", "1.2.3-linux-arm64", "1.3.0-linux-amd64"}},
+		{"^1.2", Platform{Arch: "arm64"}, []string{"1.2.3-darwin-arm64", "1.2.3-linux-arm64"}},
+		{"^1.2", Platform{}, []string{"1.2.3-linux-amd64", "1.2.3-darwin-arm64", "1.2.3-linux-arm64", "1.2.5", "1.3.0-linux-amd64"}},
+		{"^1.2.0-0", Platform{OS: "linux", Arch: "amd64"}, []string{"1.2.3-linux-amd64", "1.3.0-linux-amd64", "1.4.0-rc.1-linux-amd64"}},
+		{">=2", Platform{OS: "darwin"}, []string{}},
+	}
+
+	for _, tc := range tests {
+		got := MatchPlatform(mustConstraint(t, tc.constraint), versions, tc.platform)
+		s := make([]string, len(got))
+		for k, v := range got {
+			s[k] = v.String()
+		}
+		if !equalStrings(s, tc.expected) {
+			t.Errorf("%s for %s: expected %q but got %q", tc.constraint, tc.platform, tc.expected, s)
+		}
+	}
+
+	if got := MatchPlatform(nil, versions, Platform{}); len(got) != 0 {
+		t.Errorf("expected nil constraints to match nothing but got %v", got)
+	}
+}
@@ -0,0 +1,171 @@
+package semver
+
+import (
+	"sort"
+	"time"
+)
+
+// Release is a published version and the time it was published.
+type Release struct {
+	Version *Version
+	Date    time.Time
+}
+
+// Policy is a maintenance policy deciding which minor series are supported.
+// The latest SupportedMinors minor series with a release are supported, along
+// with the latest minor series of each major version in LTSMajors. For
+// example, Policy{SupportedMinors: 2, LTSMajors: []uint64{1}} supports 2.4
+// and 2.3 along with 1.9, the last minor series of 1.x.
+type Policy struct {
+	SupportedMinors int
+	LTSMajors       []uint64
+}
+
+// Maintenance is a Policy applied to a release history. It is created with
+// Policy.Apply.
+type Maintenance struct {
+	policy Policy
+	window *SupportWindow
+
+	// series holds the .0 release of each series with a release in ascending
+	// order, and first the date of its first release.
+	series []*Version
+	first  map[string]time.Time
+}
+
+// Apply applies the policy to a release history. The releases do not need to
+// be sorted and prereleases among them are ignored.
+func (p Policy) Apply(releases []Release) *Maintenance {
+	m := &Maintenance{
+		policy: p,
+		first:  map[string]time.Time{},
+	}
+
+	versions := make([]*Version, 0, len(releases))
+	for _, r := range releases {
+		if r.Version.Prerelease() != "" {
+			continue
+		}
+		versions = append(versions, r.Version)
+
+		s := Series(r.Version)
+		first, ok := m.first[s]
+		if !ok {
+			m.series = append(m.series, newCoreVersion(r.Version.major, r.Version.minor, 0))
+		}
+		if !ok || r.Date.Before(first) {
+			m.first[s] = r.Date
+		}
+	}
+
+	sort.Sort(Collection(m.series))
+	m.window = NewSupportWindow(versions, p.SupportedMinors)
+	return m
+}
+
+// SupportedSeries returns the supported series, such as "1.9", newest first.
+func (m *Maintenance) SupportedSeries() []string {
+	supported := m.supported()
+	out := make([]string, len(supported))
+	for k, s := range supported {
+		out[len(out)-1-k] = Series(s)
+	}
+	return out
+}
+
+// SupportedConstraint returns constraints admitting the versions of the
+// supported series, such as `~1.9 || ~2.3 || ~2.4`. When no series is
+// supported the constraints admit no version.
+func (m *Maintenance) SupportedConstraint() *Constraints {
+	return seriesConstraint(m.supported())
+}
+
+// Status classifies a version as Supported when it is in a supported series,
+// Unreleased when it is in a series newer than every release, and EOL
+// otherwise.
+func (m *Maintenance) Status(v *Version) SupportStatus {
+	status := m.window.Status(v)
+	if status == EOL && m.lts(v) {
+		return Supported
+	}
+	return status
+}
+
+// IsSupported reports if a version is in a supported series.
+func (m *Maintenance) IsSupported(v *Version) bool {
+	return m.Status(v) == Supported
+}
+
+// EOLDate returns the date the series of a version stopped being supported.
+// That is the first release of the series pushing it out of the latest
+// SupportedMinors series or, for an LTS major version, the first release of
+// the next minor series of that major version, whichever is later. The
+// boolean is false when the series is still supported or has no release.
+func (m *Maintenance) EOLDate(v *Version) (time.Time, bool) {
+	if m.Status(v) != EOL {
+		return time.Time{}, false
+	}
+	if _, ok := m.first[Series(v)]; !ok {
+		return time.Time{}, false
+	}
+
+	var index int
+	for k, s := range m.series {
+		if s.major == v.major && s.minor == v.minor {
+			index = k
+			break
+		}
+	}
+
+	var eol time.Time
+	if n := index + m.policy.SupportedMinors; n < len(m.series) {
+		eol = m.first[Series(m.series[n])]
+	}
+	if m.ltsMajor(v.major) {
+		for _, s := range m.series[index+1:] {
+			if s.major == v.major {
+				if d := m.first[Series(s)]; d.After(eol) {
+					eol = d
+				}
+				break
+			}
+		}
+	}
+	return eol, true
+}
+
+// supported returns the .0 release of each supported series in ascending
+// order.
+func (m *Maintenance) supported() []*Version {
+	var out []*Version
+	for _, s := range m.series {
+		if m.Status(s) == Supported {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// lts reports if a version is in the latest minor series of an LTS major
+// version.
+func (m *Maintenance) lts(v *Version) bool {
+	if !m.ltsMajor(v.major) {
+		return false
+	}
+	for k := len(m.series) - 1; k >= 0; k-- {
+		if s := m.series[k]; s.major == v.major {
+			return s.minor == v.minor
+		}
+	}
+	return false
+}
+
+// ltsMajor reports if a major version is in the LTSMajors of the policy.
+func (m *Maintenance) ltsMajor(major uint64) bool {
+	for _, l := range m.policy.LTSMajors {
+		if l == major {
+			return true
+		}
+	}
+	return false
+}
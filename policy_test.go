@@ -0,0 +1,66 @@
+package semver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicy(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	releases := []Release{
+		{MustParse("1.8.0"), day(1)},
+		{MustParse("1.9.0"), day(3)},
+		{MustParse("2.0.0"), day(5)},
+		{MustParse("1.9.1"), day(6)},
+		{MustParse("2.1.0-rc.1"), day(7)},
+		{MustParse("2.1.0"), day(8)},
+		{MustParse("2.2.0"), day(10)},
+		{MustParse("2.3.0"), day(12)},
+	}
+
+	m := Policy{SupportedMinors: 2, LTSMajors: []uint64{1}}.Apply(releases)
+	if s := m.SupportedSeries(); !equalStrings(s, []string{"2.3", "2.2", "1.9"}) {
+		t.Errorf("expected the supported series 2.3, 2.2, and 1.9 but got %q", s)
+	}
+	if c := m.SupportedConstraint().String(); c != "~1.9 || ~2.2 || ~2.3" {
+		t.Errorf("expected the constraint ~1.9 || ~2.2 || ~2.3 but got %q", c)
+	}
+
+	tests := []struct {
+		version string
+		status  SupportStatus
+		eol     time.Time
+	}{
+		{"2.3.1", Supported, time.Time{}},
+		{"2.2.0", Supported, time.Time{}},
+		{"1.9.4", Supported, time.Time{}},
+		{"2.1.0", EOL, day(12)},
+		{"2.0.0", EOL, day(10)},
+		{"1.8.0", EOL, day(5)},
+		{"1.5.0", EOL, time.Time{}},
+		{"2.4.0", Unreleased, time.Time{}},
+	}
+	for _, tc := range tests {
+		v := MustParse(tc.version)
+		if got := m.Status(v); got != tc.status {
+			t.Errorf("expected %s to be %s but got %s", tc.version, tc.status, got)
+		}
+		if m.IsSupported(v) != (tc.status == Supported) {
+			t.Errorf("expected %s to be supported to be %t", tc.version, tc.status == Supported)
+		}
+		eol, ok := m.EOLDate(v)
+		if ok != !tc.eol.IsZero() || !eol.Equal(tc.eol) {
+			t.Errorf("expected the EOL date of %s to be %s but got %s (%t)", tc.version, tc.eol, eol, ok)
+		}
+	}
+
+	none := Policy{}.Apply(releases)
+	if s := none.SupportedSeries(); len(s) != 0 {
+		t.Errorf("expected no supported series but got %q", s)
+	}
+	if eol, ok := none.EOLDate(MustParse("2.3.0")); !ok || !eol.Equal(day(12)) {
+		t.Errorf("expected 2.3 to reach EOL at its release but got %s (%t)", eol, ok)
+	}
+}
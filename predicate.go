@@ -0,0 +1,154 @@
+package semver
+
+import "time"
+
+// ReleaseInfo supplies what is known about released versions beyond the
+// version itself, such as from a package registry or a lockfile.
+type ReleaseInfo interface {
+	// ReleaseDate returns when v was released, and false when it is not
+	// known.
+	ReleaseDate(v *Version) (time.Time, bool)
+
+	// Attribute returns the value of the attribute name of v, such as
+	// "yanked" or "channel", and false when v does not have it.
+	Attribute(v *Version, name string) (string, bool)
+}
+
+// ReleaseIndex is a ReleaseInfo held in memory. Versions are looked up by
+// their string, so 1.2.3 and v1.2.3 are the same release. The zero value is
+// not usable, create one with NewReleaseIndex.
+type ReleaseIndex struct {
+	dates map[string]time.Time
+	attrs map[string]map[string]string
+}
+
+// NewReleaseIndex creates a ReleaseIndex with the dates of the releases.
+func NewReleaseIndex(releases []Release) *ReleaseIndex {
+	ix := &ReleaseIndex{
+		dates: make(map[string]time.Time, len(releases)),
+		attrs: map[string]map[string]string{},
+	}
+	for _, r := range releases {
+		if r.Version != nil {
+			ix.dates[r.Version.String()] = r.Date
+		}
+	}
+	return ix
+}
+
+// SetAttribute sets the attribute name of v to value.
+func (ix *ReleaseIndex) SetAttribute(v *Version, name, value string) {
+	key := v.String()
+	if ix.attrs[key] == nil {
+		ix.attrs[key] = map[string]string{}
+	}
+	ix.attrs[key][name] = value
+}
+
+// ReleaseDate returns the date v was released.
+func (ix *ReleaseIndex) ReleaseDate(v *Version) (time.Time, bool) {
+	if v == nil {
+		return time.Time{}, false
+	}
+	d, ok := ix.dates[v.String()]
+	return d, ok
+}
+
+// Attribute returns the value of the attribute name of v.
+func (ix *ReleaseIndex) Attribute(v *Version, name string) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	value, ok := ix.attrs[v.String()][name]
+	return value, ok
+}
+
+// Predicate reports if a version is wanted. Predicates combine constraints
+// with what a ReleaseInfo knows about the versions, such as versions
+// matching ^1.4 released before 2024, which no constraint can express.
+// A nil version never satisfies the predicates of this package.
+type Predicate func(v *Version) bool
+
+// Matching returns a predicate satisfied by the versions the constraints
+// admit. Nil constraints admit no versions.
+func Matching(c *Constraints) Predicate {
+	return func(v *Version) bool {
+		return c != nil && c.Check(v)
+	}
+}
+
+// ReleasedBefore returns a predicate satisfied by the versions released
+// before t. Versions without a known release date do not satisfy it.
+func ReleasedBefore(info ReleaseInfo, t time.Time) Predicate {
+	return func(v *Version) bool {
+		d, ok := info.ReleaseDate(v)
+		return ok && d.Before(t)
+	}
+}
+
+// ReleasedAfter returns a predicate satisfied by the versions released after
+// t. Versions without a known release date do not satisfy it.
+func ReleasedAfter(info ReleaseInfo, t time.Time) Predicate {
+	return func(v *Version) bool {
+		d, ok := info.ReleaseDate(v)
+		return ok && d.After(t)
+	}
+}
+
+// HasAttribute returns a predicate satisfied by the versions whose attribute
+// name is value.
+func HasAttribute(info ReleaseInfo, name, value string) Predicate {
+	return func(v *Version) bool {
+		got, ok := info.Attribute(v, name)
+		return ok && got == value
+	}
+}
+
+// And returns a predicate satisfied when p and every one of others are.
+func (p Predicate) And(others ...Predicate) Predicate {
+	return func(v *Version) bool {
+		if !p(v) {
+			return false
+		}
+		for _, o := range others {
+			if !o(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a predicate satisfied when p or any one of others is.
+func (p Predicate) Or(others ...Predicate) Predicate {
+	return func(v *Version) bool {
+		if p(v) {
+			return true
+		}
+		for _, o := range others {
+			if o(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a predicate satisfied when p is not. Nil versions still do not
+// satisfy it.
+func (p Predicate) Not() Predicate {
+	return func(v *Version) bool {
+		return v != nil && !p(v)
+	}
+}
+
+// Filter returns the versions satisfying the predicate, in the order given.
+func (p Predicate) Filter(versions []*Version) []*Version {
+	var out []*Version
+	for _, v := range versions {
+		if v != nil && p(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
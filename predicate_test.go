@@ -0,0 +1,83 @@
+package semver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPredicates(t *testing.T) {
+	day := func(y, m, d int) time.Time {
+		return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	}
+	ix := NewReleaseIndex([]Release{
+		{MustParse("1.3.0"), day(2023, 3, 1)},
+		{MustParse("1.4.0"), day(2023, 6, 1)},
+		{MustParse("1.4.1"), day(2023, 11, 20)},
+		{MustParse("1.5.0"), day(2024, 2, 1)},
+		{MustParse("2.0.0"), day(2024, 5, 1)},
+	})
+	ix.SetAttribute(MustParse("v1.4.1"), "yanked", "true")
+	versions := []*Version{
+		MustParse("1.3.0"),
+		MustParse("1.4.0"),
+		MustParse("1.4.1"),
+		MustParse("1.4.2"),
+		MustParse("1.5.0"),
+		MustParse("2.0.0"),
+		nil,
+	}
+
+	c := mustConstraint(t, "^1.4")
+	cutoff := day(2024, 1, 1)
+	tests := []struct {
+		name     string
+		p        Predicate
+		expected []string
+	}{
+		{"matching", Matching(c), []string{"1.4.0", "1.4.1", "1.4.2", "1.5.0"}},
+		{"nil constraints", Matching(nil), nil},
+		{"before", ReleasedBefore(ix, cutoff), []string{"1.3.0", "1.4.0", "1.4.1"}},
+		{"after", ReleasedAfter(ix, cutoff), []string{"1.5.0", "2.0.0"}},
+		{"and", Matching(c).And(ReleasedBefore(ix, cutoff)), []string{"1.4.0", "1.4.1"}},
+		{"and not", Matching(c).And(ReleasedBefore(ix, cutoff), HasAttribute(ix, "yanked", "true").Not()), []string{"1.4.0"}},
+		{"or", ReleasedAfter(ix, day(2024, 3, 1)).Or(HasAttribute(ix, "yanked", "true")), []string{"1.4.1", "2.0.0"}},
+		{"not", ReleasedBefore(ix, cutoff).Not(), []string{"1.4.2", "1.5.0", "2.0.0"}},
+	}
+
+	for _, tc := range tests {
+		got := tc.p.Filter(versions)
+		s := make([]string, len(got))
+		for k, v := range got {
+			s[k] = v.String()
+		}
+		if !equalStrings(s, tc.expected) {
+			t.Errorf("%s: expected %q but got %q", tc.name, tc.expected, s)
+		}
+		if tc.p(nil) {
+			t.Errorf("%s: expected a nil version not to satisfy the predicate", tc.name)
+		}
+	}
+}
+
+func TestReleaseIndex(t *testing.T) {
+	d := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+	ix := NewReleaseIndex([]Release{{MustParse("v1.2.3"), d}, {nil, d}})
+
+	if got, ok := ix.ReleaseDate(MustParse("1.2.3")); !ok || !got.Equal(d) {
+		t.Errorf("expected 1.2.3 to be released on %s but got %s %t", d, got, ok)
+	}
+	if _, ok := ix.ReleaseDate(MustParse("1.2.4")); ok {
+		t.Error("expected 1.2.4 to have no release date")
+	}
+	if _, ok := ix.ReleaseDate(nil); ok {
+		t.Error("expected a nil version to have no release date")
+	}
+
+	ix.SetAttribute(MustParse("1.2.3"), "channel", "beta")
+	if value, ok := ix.Attribute(MustParse("1.2.3"), "channel"); !ok || value != "beta" {
+		t.Errorf("expected the channel beta but got %q %t", value, ok)
+	}
+	if _, ok := ix.Attribute(MustParse("1.2.3"), "yanked"); ok {
+		t.Error("expected 1.2.3 not to have the yanked attribute")
+	}
+}
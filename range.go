@@ -0,0 +1,131 @@
+package semver
+
+import "strings"
+
+// RangeConstraint is a contiguous range of versions, less any single versions
+// excluded from within it. It lets tooling read the bounds of constraints, for
+// example to convert them to the syntax of another system.
+type RangeConstraint interface {
+	// Min returns the lower bound of the range or nil when the range is
+	// unbounded below.
+	Min() *Version
+
+	// Max returns the upper bound of the range or nil when the range is
+	// unbounded above.
+	Max() *Version
+
+	// IncludesMin reports if Min is itself within the range.
+	IncludesMin() bool
+
+	// IncludesMax reports if Max is itself within the range.
+	IncludesMax() bool
+
+	// Excluded returns the versions between Min and Max that are not within
+	// the range, in ascending order.
+	Excluded() []*Version
+}
+
+// Range is a contiguous range of versions admitted by constraints. It
+// implements RangeConstraint.
+type Range struct {
+	interval Interval
+	excluded []*Version
+}
+
+// Min returns the lower bound of the range or nil when the range is unbounded
+// below.
+func (r *Range) Min() *Version {
+	return r.interval.Min()
+}
+
+// Max returns the upper bound of the range or nil when the range is unbounded
+// above.
+func (r *Range) Max() *Version {
+	return r.interval.Max()
+}
+
+// IncludesMin reports if Min is itself within the range.
+func (r *Range) IncludesMin() bool {
+	return r.interval.IncludesMin()
+}
+
+// IncludesMax reports if Max is itself within the range.
+func (r *Range) IncludesMax() bool {
+	return r.interval.IncludesMax()
+}
+
+// Excluded returns the versions between Min and Max that are not within the
+// range, in ascending order.
+func (r *Range) Excluded() []*Version {
+	return append([]*Version(nil), r.excluded...)
+}
+
+// String renders the range as constraints, such as `>=1.2.0 <2.0.0 !=1.4.2`.
+func (r *Range) String() string {
+	s := []string{r.interval.String()}
+	for _, v := range r.excluded {
+		s = append(s, "!="+v.String())
+	}
+	return strings.Join(s, " ")
+}
+
+// Ranges returns the ranges of release versions the constraints admit, in
+// ascending order. The bounds are normalized to an inclusive min and an
+// exclusive max made up of release versions, so `>1.2.3, <=1.4` has a Min of
+// 1.2.4 and a Max of 1.5.0. An unbounded range is returned for constraints
+// admitting every release.
+//
+// Ranges only describe releases. The prereleases admitted, such as those of
+// `>=1.2.3-beta`, are returned by PrereleaseRanges.
+//
+// Ranges hold versions by precedence. With MetadataEqual, MetadataOrdered, or
+// LegacyPrereleaseOrder they hold every version the constraints may admit,
+// so `=1.2.3+abc` with MetadataEqual has the range of 1.2.3 though 1.2.3+def
+// is not admitted.
+func (cs Constraints) Ranges() []*Range {
+	may, _ := cs.sets()
+	return newRanges(may.releases, true)
+}
+
+// PrereleaseRanges returns the ranges of prerelease versions the constraints
+// admit, in ascending order. Prereleases are only admitted when the
+// constraints ask for them so most constraints have none. Releases within the
+// ranges are not admitted. As with Ranges, the ranges hold every prerelease
+// the constraints may admit.
+func (cs Constraints) PrereleaseRanges() []*Range {
+	may, _ := cs.sets()
+	return newRanges(may.prereleases, false)
+}
+
+// newRanges creates ranges from merged intervals. Neighbouring intervals
+// separated by a single version are joined into one range with the version
+// excluded. For normalized release intervals the gap is from an exclusive max
+// to an inclusive min on the next patch, otherwise it is between an exclusive
+// max and exclusive min on the same version.
+func newRanges(in []Interval, releases bool) []*Range {
+	var out []*Range
+	for k := 0; k < len(in); k++ {
+		r := &Range{interval: in[k]}
+		for k+1 < len(in) && singleGap(r.interval.max, in[k+1].min, releases) {
+			// Prerelease ranges never hold releases so a gap on a release
+			// excludes nothing from them.
+			if releases || r.interval.max.v.Prerelease() != "" {
+				r.excluded = append(r.excluded, r.interval.max.v)
+			}
+			k++
+			r.interval.max = in[k].max
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func singleGap(max, min bound, releases bool) bool {
+	if max.v == nil || min.v == nil || max.inclusive {
+		return false
+	}
+	if releases {
+		return min.inclusive && min.v.Equal(nextRelease(max.v.major, max.v.minor, max.v.patch))
+	}
+	return !min.inclusive && min.v.Equal(max.v)
+}
@@ -0,0 +1,78 @@
+package semver
+
+import "testing"
+
+func TestRanges(t *testing.T) {
+	tests := []struct {
+		constraint  string
+		releases    []string
+		prereleases []string
+	}{
+		{"^1.2", []string{">=1.2.0 <2.0.0"}, nil},
+		{">1.2.3, <=1.4", []string{">=1.2.4 <1.5.0"}, nil},
+		{">=1.2, !=1.4.2", []string{">=1.2.0 !=1.4.2"}, nil},
+		{"*", []string{"*"}, nil},
+		{"~1.2 || ~1.4", []string{">=1.2.0 <1.3.0", ">=1.4.0 <1.5.0"}, nil},
+		{"~1.4 || ~1.2", []string{">=1.2.0 <1.3.0", ">=1.4.0 <1.5.0"}, nil},
+		{">=1.2.3-beta, <2", []string{">=1.2.3 <2.0.0"}, nil},
+		{">=1.2.3-beta", []string{">=1.2.3"}, []string{">=1.2.3-beta"}},
+		{"!=1.2.3-beta", []string{"*"}, []string{"* !=1.2.3-beta"}},
+		{">2, <1", nil, nil},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.constraint, err)
+		}
+
+		if got := rangeStrings(c.Ranges()); !equalStrings(got, tc.releases) {
+			t.Errorf("expected %q to have release ranges %q but got %q", tc.constraint, tc.releases, got)
+		}
+		if got := rangeStrings(c.PrereleaseRanges()); !equalStrings(got, tc.prereleases) {
+			t.Errorf("expected %q to have prerelease ranges %q but got %q", tc.constraint, tc.prereleases, got)
+		}
+	}
+}
+
+func TestRangeAccessors(t *testing.T) {
+	c, err := NewConstraint(">1.2.3, <=1.4, !=1.3.0, !=1.3.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := c.Ranges()
+	if len(rs) != 1 {
+		t.Fatalf("expected a single range but got %d", len(rs))
+	}
+
+	var r RangeConstraint = rs[0]
+	if r.Min().String() != "1.2.4" || !r.IncludesMin() {
+		t.Errorf("expected an inclusive min of 1.2.4 but got %s (inclusive %t)", r.Min(), r.IncludesMin())
+	}
+	if r.Max().String() != "1.5.0" || r.IncludesMax() {
+		t.Errorf("expected an exclusive max of 1.5.0 but got %s (inclusive %t)", r.Max(), r.IncludesMax())
+	}
+	if ex := versionStrings(r.Excluded()); !equalStrings(ex, []string{"1.3.0", "1.3.5"}) {
+		t.Errorf("expected 1.3.0 and 1.3.5 to be excluded but got %q", ex)
+	}
+
+	// Excluded returns a copy.
+	r.Excluded()[0] = nil
+	if r.Excluded()[0] == nil {
+		t.Error("expected changes to the excluded versions not to change the range")
+	}
+
+	c, _ = NewConstraint("*")
+	r = c.Ranges()[0]
+	if r.Min() != nil || r.Max() != nil || r.IncludesMin() || r.IncludesMax() {
+		t.Errorf("expected an unbounded range but got %s", r)
+	}
+}
+
+func rangeStrings(rs []*Range) []string {
+	var s []string
+	for _, r := range rs {
+		s = append(s, r.String())
+	}
+	return s
+}
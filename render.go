@@ -0,0 +1,144 @@
+package semver
+
+import "strings"
+
+// VPrefixPolicy controls the leading v on versions when rendering
+// constraints.
+type VPrefixPolicy int
+
+const (
+	// VPrefixAsWritten keeps each version as it was written. This is the
+	// default.
+	VPrefixAsWritten VPrefixPolicy = iota
+
+	// VPrefixAdd writes every version with a leading v, as in `>=v1.2`.
+	VPrefixAdd
+
+	// VPrefixStrip writes every version without a leading v, as in `>=1.2`.
+	VPrefixStrip
+)
+
+// RenderOptions control how Render writes constraints so they can follow the
+// idiom of the ecosystem they are written for. The zero value renders the same
+// way as String.
+type RenderOptions struct {
+	// Shorthand writes a pair of >= and < clauses as a ^ or ~ clause when it
+	// admits the same versions. For example, `>=1.2.3 <2.0.0` is written as
+	// `^1.2.3`.
+	Shorthand bool
+
+	// Hyphen writes a pair of >= and <= clauses as a hyphen range. For
+	// example, `>=1.2 <=1.4` is written as `1.2 - 1.4`.
+	Hyphen bool
+
+	// VPrefix controls the leading v on versions.
+	VPrefix VPrefixPolicy
+
+	// OperatorSpace writes a space between an operator and its version, as
+	// in `>= 1.2`.
+	OperatorSpace bool
+
+	// AndSeparator separates the clauses of a group. It defaults to a space.
+	// A comma and space is common as well.
+	AndSeparator string
+
+	// OrSeparator separates the || groups. It defaults to ` || `.
+	OrSeparator string
+}
+
+// Render writes the constraints using the options. The groups are written in
+// the same canonical order as String. Clauses are rewritten only where they
+// admit the same versions, with the options the constraints were created
+// with, so the result parses back to equivalent constraints.
+func (cs Constraints) Render(o RenderOptions) string {
+	and, or := o.AndSeparator, o.OrSeparator
+	if and == "" {
+		and = " "
+	}
+	if or == "" {
+		or = " || "
+	}
+
+	groups := cs.sortedGroups()
+	buf := make([]string, len(groups))
+	for k, group := range groups {
+		buf[k] = strings.Join(renderGroup(group, &o, &cs.opts), and)
+	}
+	return strings.Join(buf, or)
+}
+
+// renderGroup returns the rendered clauses of a group.
+func renderGroup(group []*constraint, o *RenderOptions, mo *MatchOptions) []string {
+	out := make([]string, 0, len(group))
+	used := make([]bool, len(group))
+	for i, c := range group {
+		if used[i] {
+			continue
+		}
+		if s, j := combineClauses(group, i, used, o, mo); j >= 0 {
+			used[j] = true
+			out = append(out, s)
+			continue
+		}
+		out = append(out, renderClause(c.origfunc, c.orig, o))
+	}
+	return out
+}
+
+// combineClauses looks for a later clause that combines with the >= clause at
+// i into a hyphen range or shorthand. It returns the combined clause and the
+// index of the later clause, or -1 when there is none.
+func combineClauses(group []*constraint, i int, used []bool, o *RenderOptions, mo *MatchOptions) (string, int) {
+	c := group[i]
+	if c.origfunc != ">=" && c.origfunc != "=>" {
+		return "", -1
+	}
+
+	for j := i + 1; j < len(group); j++ {
+		d := group[j]
+		if used[j] {
+			continue
+		}
+
+		switch {
+		case o.Hyphen && (d.origfunc == "<=" || d.origfunc == "=<"):
+			// This is the form a hyphen range is parsed into so it always
+			// admits the same versions.
+			return renderVersion(c.orig, o) + " - " + renderVersion(d.orig, o), j
+		case o.Shorthand && d.origfunc == "<" && c.con.Prerelease() == "" && d.con.Prerelease() == "" &&
+			*clauseOptions(c, mo) == *clauseOptions(d, mo) && c.region(mo).empty() && d.region(mo).empty():
+			mo := clauseOptions(c, mo)
+			want, _ := andSets([]*constraint{c, d}, mo)
+			for _, op := range []string{"^", "~"} {
+				sc, err := parseConstraint(op + c.orig)
+				if err != nil {
+					continue
+				}
+				if s := sc.set(mo); s.subsetOf(want) && want.subsetOf(s) {
+					return renderClause(op, c.orig, o), j
+				}
+			}
+		}
+	}
+	return "", -1
+}
+
+func renderClause(op, v string, o *RenderOptions) string {
+	if op != "" && o.OperatorSpace {
+		op += " "
+	}
+	return op + renderVersion(v, o)
+}
+
+func renderVersion(v string, o *RenderOptions) string {
+	switch o.VPrefix {
+	case VPrefixAdd:
+		// A wildcard, such as *, is not a version to prefix.
+		if !strings.HasPrefix(v, "v") && v != "" && !isX(strings.SplitN(v, ".", 2)[0]) {
+			return "v" + v
+		}
+	case VPrefixStrip:
+		return strings.TrimPrefix(v, "v")
+	}
+	return v
+}
@@ -0,0 +1,62 @@
+package semver
+
+import "testing"
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		constraint string
+		opts       RenderOptions
+		expected   string
+	}{
+		{">= 1.2, <2 || v3.x", RenderOptions{}, ">=1.2 <2 || v3.x"},
+		{">=1.2.3, <2.0.0", RenderOptions{Shorthand: true}, "^1.2.3"},
+		{">=1.2, <2", RenderOptions{Shorthand: true}, "^1.2"},
+		{">=0.2.3, <0.3.0", RenderOptions{Shorthand: true}, "^0.2.3"},
+		{">=0.0.3, <0.0.4", RenderOptions{Shorthand: true}, "^0.0.3"},
+		{">=1.2.3, <1.3.0", RenderOptions{Shorthand: true}, "~1.2.3"},
+		{">=1.2.3, !=1.5.0, <2", RenderOptions{Shorthand: true}, "^1.2.3 !=1.5.0"},
+		{">=1.2.3, <1.9.0", RenderOptions{Shorthand: true}, ">=1.2.3 <1.9.0"},
+		{">=1.2.3-beta, <2.0.0", RenderOptions{Shorthand: true}, ">=1.2.3-beta <2.0.0"},
+		{">=1.2, <=1.4.5", RenderOptions{Hyphen: true}, "1.2 - 1.4.5"},
+		{"1.2 - 1.4.5", RenderOptions{}, ">=1.2 <=1.4.5"},
+		{">=1.2, <=1.4 || >=2, <3", RenderOptions{Hyphen: true, Shorthand: true}, "1.2 - 1.4 || ^2"},
+		{">=1.2, v2.x, *", RenderOptions{VPrefix: VPrefixAdd}, ">=v1.2 v2.x *"},
+		{">=v1.2, v2.x", RenderOptions{VPrefix: VPrefixStrip}, ">=1.2 2.x"},
+		{">=1.2, <2 || 3", RenderOptions{OperatorSpace: true, AndSeparator: ", "}, ">= 1.2, < 2 || 3"},
+		{"^1 || ^2", RenderOptions{OrSeparator: "||"}, "^1||^2"},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.constraint, err)
+		}
+
+		got := c.Render(tc.opts)
+		if got != tc.expected {
+			t.Errorf("expected %q rendered with %+v to be %q but got %q", tc.constraint, tc.opts, tc.expected, got)
+		}
+
+		// The rendering parses back to the same versions.
+		p, err := NewConstraint(got)
+		if err != nil {
+			t.Errorf("unable to parse %q, the rendering of %q: %s", got, tc.constraint, err)
+			continue
+		}
+		if !c.Eq(p) {
+			t.Errorf("expected %q to admit the same versions as %q", got, tc.constraint)
+		}
+	}
+}
+
+func TestRenderShorthandOptions(t *testing.T) {
+	// With CaretMajor ^0.2.3 admits up to 1.0.0 so it is not the shorthand
+	// for <0.3.0.
+	c, err := NewConstraintWithOptions(">=0.2.3, <0.3.0", MatchOptions{Caret: CaretMajor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Render(RenderOptions{Shorthand: true}); got != "~0.2.3" {
+		t.Errorf("expected ~0.2.3 but got %q", got)
+	}
+}
@@ -0,0 +1,106 @@
+package semver
+
+import (
+	"sync"
+	"time"
+)
+
+// Satisfies parses a version and constraints and reports if the version
+// satisfies them, for scripts that need nothing more. Both are cached, so
+// calling it again with the same strings does not parse them again. An error
+// is returned when either cannot be parsed.
+func Satisfies(version, constraint string) (bool, error) {
+	return SatisfiesWithOptions(version, constraint, MatchOptions{})
+}
+
+// SatisfiesWithOptions is Satisfies with the constraints parsed with the
+// options.
+func SatisfiesWithOptions(version, constraint string, opts MatchOptions) (bool, error) {
+	v, err := defaultVersionCache.Get(version)
+	if err != nil {
+		return false, err
+	}
+	c, err := constraintCacheFor(opts).Get(constraint)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(v), nil
+}
+
+// optionCaches holds a ConstraintCache for each of the options used with
+// SatisfiesWithOptions other than the defaults, which use
+// defaultConstraintCache.
+var optionCaches = struct {
+	sync.Mutex
+	m map[MatchOptions]*ConstraintCache
+}{m: map[MatchOptions]*ConstraintCache{}}
+
+// constraintCacheFor returns the cache for constraints parsed with opts.
+func constraintCacheFor(opts MatchOptions) *ConstraintCache {
+	if opts == (MatchOptions{}) {
+		return defaultConstraintCache
+	}
+	optionCaches.Lock()
+	defer optionCaches.Unlock()
+	cc, ok := optionCaches.m[opts]
+	if !ok {
+		cc = NewConstraintCache(opts, 4096)
+		optionCaches.m[opts] = cc
+	}
+	return cc
+}
+
+// versionCache parses versions once in the same way as ConstraintCache does
+// constraints, including caching errors and not caching inputs over the
+// Limits.
+type versionCache struct {
+	size int
+
+	mu      sync.RWMutex
+	entries map[string]versionEntry
+}
+
+type versionEntry struct {
+	v   *Version
+	err error
+}
+
+// defaultVersionCache is the cache used by Satisfies.
+var defaultVersionCache = &versionCache{size: 4096, entries: map[string]versionEntry{}}
+
+// Get returns the parsed version, parsing it when it is not cached.
+func (vc *versionCache) Get(s string) (*Version, error) {
+	h := currentHooks()
+	if h == nil {
+		v, _, err := vc.get(s)
+		return v, err
+	}
+	start := time.Now()
+	v, hit, err := vc.get(s)
+	parseHook(h, ParseVersion, s, start, hit, err)
+	return v, err
+}
+
+// get returns the parsed version along with whether it was cached.
+func (vc *versionCache) get(s string) (*Version, bool, error) {
+	if err := checkVersionLimits(s); err != nil {
+		return nil, false, err
+	}
+
+	vc.mu.RLock()
+	e, ok := vc.entries[s]
+	vc.mu.RUnlock()
+	if ok {
+		return e.v, true, e.err
+	}
+
+	e.v, e.err = newVersion(s)
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	if vc.size > 0 && len(vc.entries) >= vc.size {
+		vc.entries = map[string]versionEntry{}
+	}
+	vc.entries[s] = e
+	return e.v, false, e.err
+}
@@ -0,0 +1,67 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		version, constraint string
+		expected            bool
+		err                 bool
+	}{
+		{"1.4.0", "^1.2", true, false},
+		{"v1.4.0", "^1.2", true, false},
+		{"2.0.0", "^1.2", false, false},
+		{"1.5.0-rc.1", "^1.2", false, false},
+		{"banana", "^1.2", false, true},
+		{"1.4.0", "<<1", false, true},
+		{strings.Repeat("1", 300), "*", false, true},
+	}
+
+	for round := 0; round < 2; round++ {
+		for _, tc := range tests {
+			ok, err := Satisfies(tc.version, tc.constraint)
+			if ok != tc.expected || (err != nil) != tc.err {
+				t.Errorf("round %d: expected %s to satisfy %s %t with error %t but got %t %v", round, tc.version, tc.constraint, tc.expected, tc.err, ok, err)
+			}
+		}
+	}
+
+	defer SetLimits(Limits{})
+	SetLimits(RecommendedLimits)
+	if _, err := Satisfies(strings.Repeat("1", 300), "*"); !isInputTooLarge(err) {
+		t.Errorf("expected ErrInputTooLarge but got %v", err)
+	}
+}
+
+func TestSatisfiesWithOptions(t *testing.T) {
+	ok, err := SatisfiesWithOptions("0.9.0", "^0.2", MatchOptions{Caret: CaretMajor})
+	if err != nil || !ok {
+		t.Errorf("expected 0.9.0 to satisfy ^0.2 with CaretMajor but got %t %v", ok, err)
+	}
+	ok, err = Satisfies("0.9.0", "^0.2")
+	if err != nil || ok {
+		t.Errorf("expected 0.9.0 not to satisfy ^0.2 by default but got %t %v", ok, err)
+	}
+	if constraintCacheFor(MatchOptions{Caret: CaretMajor}) != constraintCacheFor(MatchOptions{Caret: CaretMajor}) {
+		t.Error("expected the same options to share a cache")
+	}
+}
+
+func TestSatisfiesHooks(t *testing.T) {
+	r := &recordHooks{}
+	SetHooks(r)
+	defer SetHooks(nil)
+
+	for i := 0; i < 2; i++ {
+		if _, err := Satisfies("3.1.4-hooks", ">=3.1.4-0"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(r.parses) != 4 || r.parses[0].CacheHit || !r.parses[2].CacheHit || !r.parses[3].CacheHit {
+		t.Errorf("expected the second call to be answered by the caches but got %+v", r.parses)
+	}
+}
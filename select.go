@@ -0,0 +1,75 @@
+package semver
+
+import "sort"
+
+// Preference ranks two candidate versions. It returns a negative number when
+// a is preferred over b, a positive number when b is preferred over a, and 0
+// when it has no preference between them. Preferences never rule a version
+// out, they only order the versions the hard constraints admit.
+type Preference func(a, b *Version) int
+
+// PreferStable prefers versions without a prerelease over those with one.
+func PreferStable() Preference {
+	return func(a, b *Version) int {
+		return boolInt(a.Prerelease() != "") - boolInt(b.Prerelease() != "")
+	}
+}
+
+// PreferSameMinor prefers versions in the same minor series as current, such
+// as 1.4.x for 1.4.2, over versions in other series. With a nil current it
+// has no preference.
+func PreferSameMinor(current *Version) Preference {
+	return func(a, b *Version) int {
+		if current == nil {
+			return 0
+		}
+		return boolInt(!sameMinor(a, current)) - boolInt(!sameMinor(b, current))
+	}
+}
+
+// PreferNewest prefers greater versions over lesser ones.
+func PreferNewest() Preference {
+	return func(a, b *Version) int {
+		return b.Compare(a)
+	}
+}
+
+// Rank returns the candidates the constraints admit, most preferred first.
+// The preferences are applied in order, so a later preference only decides
+// between versions the earlier ones have no preference between. Versions
+// still tied are ordered newest first. Nil candidates are skipped and the
+// candidates are not modified.
+func (cs Constraints) Rank(candidates []*Version, prefs ...Preference) []*Version {
+	ranked := make([]*Version, 0, len(candidates))
+	for _, v := range candidates {
+		if v != nil && cs.Check(v) {
+			ranked = append(ranked, v)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		for _, p := range prefs {
+			if c := p(a, b); c != 0 {
+				return c < 0
+			}
+		}
+		return a.Compare(b) > 0
+	})
+	return ranked
+}
+
+// Select returns the most preferred of the candidates the constraints admit,
+// as ranked by Rank, or nil when they admit none of them.
+func (cs Constraints) Select(candidates []*Version, prefs ...Preference) *Version {
+	ranked := cs.Rank(candidates, prefs...)
+	if len(ranked) == 0 {
+		return nil
+	}
+	return ranked[0]
+}
+
+// sameMinor reports if a and b are in the same minor series.
+func sameMinor(a, b *Version) bool {
+	return a.major == b.major && a.minor == b.minor
+}
@@ -0,0 +1,64 @@
+package semver
+
+import "testing"
+
+func TestRank(t *testing.T) {
+	candidates := []*Version{
+		MustParse("1.4.2"),
+		MustParse("1.4.5"),
+		MustParse("1.5.0"),
+		MustParse("1.6.0-rc.1"),
+		MustParse("1.6.1"),
+		MustParse("2.0.0"),
+		nil,
+	}
+	current := MustParse("1.4.2")
+
+	tests := []struct {
+		constraint string
+		prefs      []Preference
+		expected   []string
+	}{
+		{"^1.4", nil, []string{"1.6.1", "1.5.0", "1.4.5", "1.4.2"}},
+		{"^1.4", []Preference{PreferSameMinor(current)}, []string{"1.4.5", "1.4.2", "1.6.1", "1.5.0"}},
+		{"^1.4", []Preference{PreferSameMinor(nil)}, []string{"1.6.1", "1.5.0", "1.4.5", "1.4.2"}},
+		{">=1.4.0-0", []Preference{PreferNewest()}, []string{"2.0.0", "1.6.1", "1.6.0-rc.1", "1.5.0", "1.4.5", "1.4.2"}},
+		{">=1.4.0-0 <2.0.0-0", []Preference{PreferStable(), PreferSameMinor(current)}, []string{"1.4.5", "1.4.2", "1.6.1", "1.5.0", "1.6.0-rc.1"}},
+		{">=1.4.0-0 <2.0.0-0", []Preference{PreferSameMinor(current), PreferStable()}, []string{"1.4.5", "1.4.2", "1.6.1", "1.5.0", "1.6.0-rc.1"}},
+		{"~1.6.0-0", []Preference{PreferStable()}, []string{"1.6.1", "1.6.0-rc.1"}},
+		{"^3", []Preference{PreferStable()}, []string{}},
+	}
+
+	for _, tc := range tests {
+		c := mustConstraint(t, tc.constraint)
+		ranked := c.Rank(candidates, tc.prefs...)
+		got := make([]string, len(ranked))
+		for k, v := range ranked {
+			got[k] = v.String()
+		}
+		if !equalStrings(got, tc.expected) {
+			t.Errorf("%s: expected %q but got %q", tc.constraint, tc.expected, got)
+		}
+	}
+
+	if candidates[0].String() != "1.4.2" || candidates[6] != nil {
+		t.Error("expected the candidates not to be modified")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	candidates := []*Version{MustParse("1.2.0"), MustParse("1.3.0-beta"), MustParse("1.2.7")}
+
+	c := mustConstraint(t, ">=1.2.0-0")
+	if v := c.Select(candidates, PreferStable()); v == nil || v.String() != "1.2.7" {
+		t.Errorf("expected 1.2.7 but got %v", v)
+	}
+	if v := c.Select(candidates, PreferNewest()); v == nil || v.String() != "1.3.0-beta" {
+		t.Errorf("expected 1.3.0-beta but got %v", v)
+	}
+
+	c = mustConstraint(t, "^2")
+	if v := c.Select(candidates, PreferStable()); v != nil {
+		t.Errorf("expected no version but got %s", v)
+	}
+}
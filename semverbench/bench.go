@@ -0,0 +1,95 @@
+// Package semverbench has realistic corpora of versions and constraints along
+// with standard benchmarks over them. Contributors use them to measure changes
+// to parsing and matching, and consumers can run the same benchmarks, or their
+// own corpora through them, to measure the package in their own setting.
+//
+// The benchmarks take a *testing.B and are called from a benchmark function.
+// For example,
+//
+//	func BenchmarkCheck(b *testing.B) {
+//	    semverbench.Check(b, semverbench.Npm())
+//	}
+package semverbench
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// NewVersion benchmarks parsing every version in the corpus with
+// semver.NewVersion. Versions that are not valid are parsed too, so the time
+// spent reporting errors is included.
+func NewVersion(b *testing.B, c Corpus) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range c.Versions {
+			_, _ = semver.NewVersion(v)
+		}
+	}
+}
+
+// NewConstraint benchmarks parsing every constraint in the corpus with
+// semver.NewConstraint.
+func NewConstraint(b *testing.B, c Corpus) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, cs := range c.Constraints {
+			_, _ = semver.NewConstraint(cs)
+		}
+	}
+}
+
+// Check benchmarks checking every valid version in the corpus against every
+// valid constraint in the corpus. Parsing is not included.
+func Check(b *testing.B, c Corpus) {
+	versions := parseVersions(c)
+	constraints := parseConstraints(c)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, cs := range constraints {
+			for _, v := range versions {
+				cs.Check(v)
+			}
+		}
+	}
+}
+
+// Sort benchmarks sorting the valid versions in the corpus. Parsing is not
+// included.
+func Sort(b *testing.B, c Corpus) {
+	versions := parseVersions(c)
+	vs := make([]*semver.Version, len(versions))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(vs, versions)
+		sort.Sort(semver.Collection(vs))
+	}
+}
+
+func parseVersions(c Corpus) []*semver.Version {
+	var vs []*semver.Version
+	for _, s := range c.Versions {
+		if v, err := semver.NewVersion(s); err == nil {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+func parseConstraints(c Corpus) []*semver.Constraints {
+	var cs []*semver.Constraints
+	for _, s := range c.Constraints {
+		if c, err := semver.NewConstraint(s); err == nil {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
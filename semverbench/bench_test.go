@@ -0,0 +1,28 @@
+package semverbench
+
+import "testing"
+
+func benchCorpora(b *testing.B, bench func(*testing.B, Corpus)) {
+	for _, c := range Corpora() {
+		c := c
+		b.Run(c.Name, func(b *testing.B) {
+			bench(b, c)
+		})
+	}
+}
+
+func BenchmarkNewVersion(b *testing.B) {
+	benchCorpora(b, NewVersion)
+}
+
+func BenchmarkNewConstraint(b *testing.B) {
+	benchCorpora(b, NewConstraint)
+}
+
+func BenchmarkCheck(b *testing.B) {
+	benchCorpora(b, Check)
+}
+
+func BenchmarkSort(b *testing.B) {
+	benchCorpora(b, Sort)
+}
@@ -0,0 +1,120 @@
+package semverbench
+
+// Corpus is a sample of the versions and constraints found in a package
+// registry.
+type Corpus struct {
+	// Name is the name of the registry the sample is from.
+	Name string
+
+	// Versions are published versions as the registry writes them. Not all of
+	// them are valid semantic versions.
+	Versions []string
+
+	// Constraints are dependency requirements rewritten in this package's
+	// constraint syntax.
+	Constraints []string
+}
+
+// Corpora returns every corpus in this package.
+func Corpora() []Corpus {
+	return []Corpus{Crates(), Npm(), PyPI()}
+}
+
+// Crates returns a sample from crates.io, the Rust package registry. It is
+// mostly releases with a long tail of patch versions, some prereleases, and
+// some build metadata.
+func Crates() Corpus {
+	return Corpus{
+		Name: "crates",
+		Versions: []string{
+			"1.0.0", "1.0.3", "1.0.6", "1.0.9", "1.0.12", "1.0.15", "1.0.18", "1.0.21",
+			"1.0.24", "1.0.27", "1.0.30", "1.0.33", "1.0.36", "1.0.39", "1.0.42",
+			"1.0.45", "1.0.48", "1.0.51", "1.0.54", "1.0.57", "1.0.60", "1.0.63",
+			"1.0.66", "1.0.69", "1.0.72", "1.0.75", "1.0.78", "1.0.81", "1.0.84",
+			"1.0.87", "1.0.90", "1.0.93", "1.0.96", "1.0.99", "1.0.102", "1.0.105",
+			"1.0.108", "1.0.111", "1.0.114", "1.0.117", "1.0.120", "1.0.123",
+			"1.0.126", "1.0.129", "1.0.132", "1.0.135", "1.0.138", "1.0.141",
+			"1.0.144", "1.0.147", "1.0.150", "1.0.153", "1.0.156", "1.0.159",
+			"1.0.162", "1.0.165", "1.0.168", "1.0.171", "1.0.174", "1.0.177",
+			"1.0.180", "1.0.183", "1.0.186", "1.0.189", "1.0.192", "1.0.195",
+			"1.0.198", "1.0.201", "1.0.204", "1.0.207", "1.0.210", "1.0.213", "0.9.15",
+			"0.8.23", "0.7.15", "0.1.0", "0.1.7", "0.1.14", "0.1.22", "0.2.0",
+			"0.2.11", "0.2.22", "0.2.25", "1.0.1", "1.5.0", "1.8.5", "1.14.1",
+			"1.17.0", "1.20.6", "1.25.3", "1.28.2", "1.32.0", "1.35.1", "1.38.1",
+			"1.40.0", "1.41.1", "1.0.0-alpha.1", "1.0.0-alpha.2", "1.0.0-beta.1",
+			"1.0.0-rc.1", "0.3.0-alpha.5", "0.3.0-beta.1", "2.0.0-rc.3", "4.0.0-pre.0",
+			"2.0.0", "2.0.7", "2.0.14", "2.0.21", "2.0.28", "2.0.35", "2.0.42",
+			"2.0.49", "2.0.56", "2.0.63", "2.0.70", "2.0.77", "2.0.84", "0.8.5",
+			"0.8.4", "0.7.3", "0.9.0-alpha.1", "0.9.0-beta.3", "0.9.0", "4.5.0",
+			"4.5.2", "4.5.4", "4.5.6", "4.5.8", "4.5.10", "4.5.12", "4.5.14", "4.5.16",
+			"4.5.18", "4.5.20", "3.2.25", "2.34.0", "4.0.0-rc.4",
+			"1.0.0+wasi-snapshot-preview1", "0.11.0+wasi-snapshot-preview1",
+			"0.2.3+zstd.1.5.6",
+		},
+		Constraints: []string{
+			"^1.0", "^1.0.130", "1.0.193", "=1.0.193", "~0.4", "~0.4.11",
+			">=0.2, <0.4", "*", "0.8", "^0.8.5", ">=1.20, <1.40", "^2", "^0.2.25",
+			"~1.35", "=2.0.0-rc.3", "^1.0.0-alpha.1", ">=4.0.0-rc.1, <5", "1.x", "0.*",
+			"^0.0.3", ">=1.0.100, <1.0.200, !=1.0.150", "^1.17 || ^1.32", "<0.3",
+			">0.9.0-beta.1",
+		},
+	}
+}
+
+// Npm returns a sample from npm, the JavaScript package registry. It has
+// many prereleases including long canary, nightly, and experimental builds.
+func Npm() Corpus {
+	return Corpus{
+		Name: "npm",
+		Versions: []string{
+			"16.0.0", "16.4.2", "16.8.6", "16.13.1", "16.14.0", "17.0.0", "17.0.1",
+			"17.0.2", "18.0.0", "18.1.0", "18.2.0", "18.3.0", "18.3.1", "19.0.0",
+			"19.1.0", "18.0.0-rc.0", "18.0.0-rc.3", "19.0.0-rc-935180c7e0-20240524",
+			"0.0.0-experimental-58af67a8f8-20240628",
+			"19.0.0-beta-26f2496093-20240514", "0.0.0-insiders.5cd8c8a", "4.17.0",
+			"4.17.1", "4.17.2", "4.17.3", "4.17.4", "4.17.5", "4.17.6", "4.17.7",
+			"4.17.8", "4.17.9", "4.17.10", "4.17.11", "4.17.12", "4.17.13", "4.17.14",
+			"4.17.15", "4.17.16", "4.17.17", "4.17.18", "4.17.19", "4.17.20",
+			"4.17.21", "5.0.2", "5.1.6", "5.2.2", "5.3.3", "5.4.5", "5.5.4", "5.6.2",
+			"5.6.3", "5.7.0-dev.20240820", "5.7.0-beta", "5.6.0-dev.20240701",
+			"5.0.0-beta", "4.9.0-dev.20220902", "4.16.4", "4.17.1", "4.18.2", "4.19.2",
+			"4.21.0", "5.0.0-beta.1", "5.0.0-beta.3", "5.0.1", "10.0.0", "10.2.4",
+			"10.5.0", "10.8.3", "10.9.0", "9.9.3", "8.19.4", "7.24.2", "1.0.0-next.0",
+			"1.0.0-next.3", "1.0.0-next.6", "1.0.0-next.9", "2.0.0-canary.1",
+			"2.0.0-canary.17", "2.0.0-canary.102", "3.3.3", "3.4.1", "3.4.14",
+			"3.5.0-alpha.1", "13.5.6", "14.2.15", "15.0.0-canary.148",
+		},
+		Constraints: []string{
+			"^18.2.0", "~4.17.21", ">=14 <17", "16.x || 17.x || 18.x", "1.2.3 - 2.3.4",
+			"*", "^5.0.0-beta", ">=10.0.0", "^0.0.0-insiders", "~5.6",
+			"^4.17.0 || ^5.0.0", ">=16.8.0 <19", "18", "^18.0.0 || ^19.0.0-0",
+			"<=5.7.0-dev.20240820", "x", ">=4.0.0, !=4.18.0", "~13.5.6 || ^14", "3.x",
+			">3.4.0 <3.5.0-0", "^1.0.0-next.0", "=2.0.0-canary.17",
+		},
+	}
+}
+
+// PyPI returns a sample from PyPI, the Python package registry. Python
+// versions follow PEP 440 rather than semantic versioning so many of them,
+// such as 2.0.0rc1 and 1!2.0, do not parse.
+func PyPI() Corpus {
+	return Corpus{
+		Name: "pypi",
+		Versions: []string{
+			"1.21.6", "1.22.4", "1.23.5", "1.24.4", "1.25.2", "1.26.0", "1.26.4",
+			"2.0.0", "2.0.2", "2.1.0", "2.1.3", "2.0.0rc1", "2.0.0b1", "1.26.0rc1",
+			"2.1.0rc1", "4.2", "4.2.1", "4.2.16", "5.0", "5.0.9", "5.1", "5.1.2",
+			"5.1a1", "5.1b1", "5.1rc1", "3.2.25", "2.2.28", "2.31.0", "2.32.3",
+			"2.28.2", "2.25.1", "1.0.post1", "0.10.0.dev0", "2024.1", "2023.3.post1",
+			"2022.7.1", "3.12.0a7", "3.12.0b4", "3.12.0rc3", "3.12.0", "3.11.9",
+			"1!2.0", "0.1.dev1+g1234abc", "8.3.3", "8.2.0", "7.4.4", "0.110.0",
+			"0.111.1", "0.112.2", "0.115.0", "1.10.18", "2.9.2", "2.10.0b2", "v1.0",
+			"1.0.0", "1.0.0-1", "1.0.0.0", "20.3.4", "24.2", "68.0.0", "75.1.0",
+		},
+		Constraints: []string{
+			">=3.8", ">=1.21, <2", "<3 || >=3.2", "!=2.0.0", ">=4.2, <5.0",
+			">=2.28, <3", "2.x", ">=0.110, <0.116", "^1.26", "~4.2.0", ">=2023.3",
+			">=8, !=8.2.0", ">=68",
+		},
+	}
+}
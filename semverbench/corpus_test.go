@@ -0,0 +1,26 @@
+package semverbench
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func TestCorpora(t *testing.T) {
+	for _, c := range Corpora() {
+		if len(c.Versions) == 0 || len(c.Constraints) == 0 {
+			t.Errorf("expected %s to have versions and constraints", c.Name)
+		}
+
+		// Constraints are rewritten in this package's syntax so they all
+		// parse, while only some versions need to.
+		for _, cs := range c.Constraints {
+			if _, err := semver.NewConstraint(cs); err != nil {
+				t.Errorf("unable to parse %s constraint %q: %s", c.Name, cs, err)
+			}
+		}
+		if n := len(parseVersions(c)); n < len(c.Versions)/2 {
+			t.Errorf("expected most %s versions to parse but only %d of %d do", c.Name, n, len(c.Versions))
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package semverdiff compares this package with other semantic version
+// implementations, github.com/Masterminds/semver and golang.org/x/mod/semver,
+// and reports each input on which they disagree as a Divergence. It is meant
+// to give confidence before moving a large system from one implementation to
+// another.
+//
+// It is a separate module so the semver package itself does not depend on the
+// implementations it is compared with.
+package semverdiff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	mm "github.com/Masterminds/semver/v3"
+	"github.com/jesseduffield/semver/v3"
+	xmod "golang.org/x/mod/semver"
+)
+
+// The implementations compared with this package.
+const (
+	Masterminds = "masterminds"
+	XMod        = "x/mod"
+)
+
+// The operations compared.
+const (
+	OpParse      = "parse"
+	OpString     = "string"
+	OpCompare    = "compare"
+	OpConstraint = "constraint"
+	OpCheck      = "check"
+)
+
+// Divergence is a single input on which another implementation disagrees with
+// this package.
+type Divergence struct {
+	// Impl is the implementation that disagrees, Masterminds or XMod.
+	Impl string `json:"impl"`
+
+	// Op is the operation compared, such as OpParse or OpCheck.
+	Op string `json:"op"`
+
+	// Input is the input to the operation. It is a version for OpParse and
+	// OpString, two versions for OpCompare, a constraint for OpConstraint, and
+	// a constraint and version for OpCheck.
+	Input []string `json:"input"`
+
+	// Ours and Theirs are the results of this package and the other
+	// implementation.
+	Ours   string `json:"ours"`
+	Theirs string `json:"theirs"`
+}
+
+// String returns the divergence on a single line.
+func (d Divergence) String() string {
+	return fmt.Sprintf("%s %s %q: ours %s, theirs %s", d.Impl, d.Op, strings.Join(d.Input, " "), d.Ours, d.Theirs)
+}
+
+// Versions compares the parsing, formatting, and ordering of versions. Every
+// pair of versions that both implementations accept is compared.
+//
+// x/mod requires a leading v so one is added to versions without it before
+// they are passed to x/mod. x/mod also drops build metadata when formatting,
+// so it is dropped from this package's formatting before comparing.
+func Versions(versions []string) []Divergence {
+	var d []Divergence
+	d = append(d, versusMasterminds(versions)...)
+	d = append(d, versusXMod(versions)...)
+	return d
+}
+
+// Constraints compares the parsing of constraints with Masterminds/semver and,
+// for each constraint both accept, whether it matches each version both
+// accept. x/mod has no constraints so it is not compared.
+func Constraints(constraints, versions []string) []Divergence {
+	type pair struct {
+		s      string
+		ours   *semver.Version
+		theirs *mm.Version
+	}
+	var vs []pair
+	for _, s := range versions {
+		ov, oerr := semver.NewVersion(s)
+		tv, terr := mm.NewVersion(s)
+		if oerr == nil && terr == nil {
+			vs = append(vs, pair{s, ov, tv})
+		}
+	}
+
+	var d []Divergence
+	for _, s := range constraints {
+		oc, oerr := semver.NewConstraint(s)
+		tc, terr := mm.NewConstraint(s)
+		if (oerr == nil) != (terr == nil) {
+			d = append(d, Divergence{Masterminds, OpConstraint, []string{s}, validity(oerr), validity(terr)})
+			continue
+		}
+		if oerr != nil {
+			continue
+		}
+
+		for _, v := range vs {
+			ours, theirs := oc.Check(v.ours), tc.Check(v.theirs)
+			if ours != theirs {
+				d = append(d, Divergence{Masterminds, OpCheck, []string{s, v.s}, strconv.FormatBool(ours), strconv.FormatBool(theirs)})
+			}
+		}
+	}
+	return d
+}
+
+func versusMasterminds(versions []string) []Divergence {
+	var d []Divergence
+	var ours []*semver.Version
+	var theirs []*mm.Version
+	var inputs []string
+	for _, s := range versions {
+		ov, oerr := semver.NewVersion(s)
+		tv, terr := mm.NewVersion(s)
+		if (oerr == nil) != (terr == nil) {
+			d = append(d, Divergence{Masterminds, OpParse, []string{s}, validity(oerr), validity(terr)})
+			continue
+		}
+		if oerr != nil {
+			continue
+		}
+
+		if ov.String() != tv.String() {
+			d = append(d, Divergence{Masterminds, OpString, []string{s}, ov.String(), tv.String()})
+		}
+		ours = append(ours, ov)
+		theirs = append(theirs, tv)
+		inputs = append(inputs, s)
+	}
+
+	for i := range ours {
+		for j := i + 1; j < len(ours); j++ {
+			o, t := ours[i].Compare(ours[j]), theirs[i].Compare(theirs[j])
+			if o != t {
+				d = append(d, Divergence{Masterminds, OpCompare, []string{inputs[i], inputs[j]}, strconv.Itoa(o), strconv.Itoa(t)})
+			}
+		}
+	}
+	return d
+}
+
+func versusXMod(versions []string) []Divergence {
+	var d []Divergence
+	var ours []*semver.Version
+	var theirs []string
+	var inputs []string
+	for _, s := range versions {
+		ov, oerr := semver.NewVersion(s)
+		tv := s
+		if !strings.HasPrefix(tv, "v") {
+			tv = "v" + tv
+		}
+		if (oerr == nil) != xmod.IsValid(tv) {
+			d = append(d, Divergence{XMod, OpParse, []string{s}, validity(oerr), strconv.FormatBool(xmod.IsValid(tv))})
+			continue
+		}
+		if oerr != nil {
+			continue
+		}
+
+		o := "v" + strings.SplitN(ov.String(), "+", 2)[0]
+		if t := xmod.Canonical(tv); o != t {
+			d = append(d, Divergence{XMod, OpString, []string{s}, o, t})
+		}
+		ours = append(ours, ov)
+		theirs = append(theirs, tv)
+		inputs = append(inputs, s)
+	}
+
+	for i := range ours {
+		for j := i + 1; j < len(ours); j++ {
+			o, t := ours[i].Compare(ours[j]), xmod.Compare(theirs[i], theirs[j])
+			if o != t {
+				d = append(d, Divergence{XMod, OpCompare, []string{inputs[i], inputs[j]}, strconv.Itoa(o), strconv.Itoa(t)})
+			}
+		}
+	}
+	return d
+}
+
+// validity renders whether parsing succeeded in the way both implementations
+// can be compared on.
+func validity(err error) string {
+	return strconv.FormatBool(err == nil)
+}
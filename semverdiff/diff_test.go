@@ -0,0 +1,32 @@
+package semverdiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVersions(t *testing.T) {
+	got := Versions([]string{"1.2.3", "v1.2", "1.2.3-beta.1+build", "01.2.3", "nope"})
+	expected := []Divergence{
+		{Masterminds, OpParse, []string{"01.2.3"}, "true", "false"},
+		{XMod, OpParse, []string{"01.2.3"}, "true", "false"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected divergences %v but got %v", expected, got)
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	got := Constraints([]string{"^1.2", ">=1.2.3-beta, <2", "nope"}, []string{"1.2.3", "1.2.4-beta", "01.2.3"})
+	if len(got) != 0 {
+		t.Errorf("expected no divergences but got %v", got)
+	}
+}
+
+func TestDivergenceString(t *testing.T) {
+	d := Divergence{Masterminds, OpCheck, []string{"^1.2", "1.2.3"}, "true", "false"}
+	expected := `masterminds check "^1.2 1.2.3": ours true, theirs false`
+	if d.String() != expected {
+		t.Errorf("expected %s but got %s", expected, d.String())
+	}
+}
@@ -0,0 +1,67 @@
+//go:build differential
+// +build differential
+
+package semverdiff
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+	"github.com/jesseduffield/semver/v3/semvertest"
+)
+
+var out = flag.String("divergences", "", "write the divergences found as JSON lines to this file")
+
+// TestDifferential compares the implementations across the seed corpora and
+// generated versions. Divergences are expected, as the implementations differ
+// in places, so they are reported rather than failing the test.
+func TestDifferential(t *testing.T) {
+	versions := semvertest.VersionSeeds()
+	r := rand.New(rand.NewSource(1))
+	for _, v := range semver.GenerateVersions(r, 500, semver.GenOpts{
+		MaxMajor:              5,
+		MaxMinor:              5,
+		MaxPatch:              5,
+		PrereleaseProbability: 0.3,
+		MetadataProbability:   0.1,
+	}) {
+		versions = append(versions, v.Original())
+	}
+
+	constraints := semvertest.ConstraintSeeds()
+	for i := 0; i < 200; i++ {
+		c := (*semver.Constraints)(nil).Generate(r, i%50).Interface().(*semver.Constraints)
+		constraints = append(constraints, c.String())
+	}
+
+	d := Versions(versions)
+	d = append(d, Constraints(constraints, versions)...)
+
+	counts := map[string]int{}
+	for _, dv := range d {
+		counts[dv.Impl+" "+dv.Op]++
+		if testing.Verbose() {
+			t.Log(dv)
+		}
+	}
+	t.Logf("%d divergences across %d versions: %v", len(d), len(versions), counts)
+
+	if *out == "" {
+		return
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	for _, dv := range d {
+		if err := enc.Encode(dv); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
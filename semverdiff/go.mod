@@ -0,0 +1,11 @@
+module github.com/jesseduffield/semver/v3/semverdiff
+
+go 1.22.0
+
+replace github.com/jesseduffield/semver/v3 => ../
+
+require (
+	github.com/Masterminds/semver/v3 v3.3.1
+	github.com/jesseduffield/semver/v3 v3.0.3
+	golang.org/x/mod v0.22.0
+)
@@ -0,0 +1,108 @@
+// Package semverflat is a flat API over the semver package taking and
+// returning only strings, numbers, booleans, and slices of strings. It has no
+// pointers or interfaces in its signatures, which makes it simple to bind to
+// other environments such as JavaScript through WebAssembly.
+//
+// Functions that can fail return an error message as their last result. The
+// message is empty on success.
+package semverflat
+
+import (
+	"sort"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// Valid reports if version can be parsed.
+func Valid(version string) bool {
+	_, err := semver.NewVersion(version)
+	return err == nil
+}
+
+// Normalize returns the canonical form of version. For example, v1.2 is
+// normalized to 1.2.0.
+func Normalize(version string) (string, string) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return "", err.Error()
+	}
+	return v.String(), ""
+}
+
+// Compare returns -1, 0, or 1 as a is less than, equal to, or greater than b.
+func Compare(a, b string) (int, string) {
+	va, err := semver.NewVersion(a)
+	if err != nil {
+		return 0, err.Error()
+	}
+	vb, err := semver.NewVersion(b)
+	if err != nil {
+		return 0, err.Error()
+	}
+	return va.Compare(vb), ""
+}
+
+// Satisfies reports if version satisfies constraint.
+func Satisfies(version, constraint string) (bool, string) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, err.Error()
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false, err.Error()
+	}
+	return c.Check(v), ""
+}
+
+// ValidateConstraint returns an error message if constraint can not be
+// parsed.
+func ValidateConstraint(constraint string) string {
+	if _, err := semver.NewConstraint(constraint); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// MaxSatisfying returns the highest of versions, as it was written, that
+// satisfies constraint. It returns an empty string when none do.
+func MaxSatisfying(versions []string, constraint string) (string, string) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", err.Error()
+	}
+
+	var max *semver.Version
+	for _, s := range versions {
+		v, err := semver.NewVersion(s)
+		if err != nil {
+			return "", err.Error()
+		}
+		if c.Check(v) && (max == nil || v.GreaterThan(max)) {
+			max = v
+		}
+	}
+	if max == nil {
+		return "", ""
+	}
+	return max.Original(), ""
+}
+
+// Sort returns versions, as they were written, sorted in ascending order.
+func Sort(versions []string) ([]string, string) {
+	vs := make([]*semver.Version, len(versions))
+	for k, s := range versions {
+		v, err := semver.NewVersion(s)
+		if err != nil {
+			return nil, err.Error()
+		}
+		vs[k] = v
+	}
+	sort.Stable(semver.Collection(vs))
+
+	out := make([]string, len(vs))
+	for k, v := range vs {
+		out[k] = v.Original()
+	}
+	return out, ""
+}
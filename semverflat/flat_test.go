@@ -0,0 +1,74 @@
+package semverflat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidAndNormalize(t *testing.T) {
+	if !Valid("v1.2") || Valid("nope") {
+		t.Error("unexpected validity")
+	}
+
+	if v, err := Normalize("v1.2"); v != "1.2.0" || err != "" {
+		t.Errorf("expected 1.2.0 but got %q %q", v, err)
+	}
+	if v, err := Normalize("nope"); v != "" || err == "" {
+		t.Errorf("expected an error but got %q %q", v, err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	if r, err := Compare("1.2.3", "1.10.0"); r != -1 || err != "" {
+		t.Errorf("expected -1 but got %d %q", r, err)
+	}
+	if _, err := Compare("1.2.3", "nope"); err == "" {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		version, constraint string
+		expected            bool
+		err                 bool
+	}{
+		{"1.4.0", "^1.2", true, false},
+		{"2.0.0", "^1.2", false, false},
+		{"nope", "^1.2", false, true},
+		{"1.4.0", "nope", false, true},
+	}
+
+	for _, tc := range tests {
+		ok, err := Satisfies(tc.version, tc.constraint)
+		if ok != tc.expected || (err != "") != tc.err {
+			t.Errorf("unexpected result for %s satisfies %q: %t %q", tc.version, tc.constraint, ok, err)
+		}
+	}
+
+	if ValidateConstraint("^1.2") != "" || ValidateConstraint("nope") == "" {
+		t.Error("unexpected constraint validation")
+	}
+}
+
+func TestMaxSatisfying(t *testing.T) {
+	if v, err := MaxSatisfying([]string{"1.2.0", "v1.9.1", "2.0.0"}, "^1.2"); v != "v1.9.1" || err != "" {
+		t.Errorf("expected v1.9.1 but got %q %q", v, err)
+	}
+	if v, err := MaxSatisfying([]string{"1.2.0"}, "^3"); v != "" || err != "" {
+		t.Errorf("expected no version but got %q %q", v, err)
+	}
+	if _, err := MaxSatisfying([]string{"nope"}, "^1"); err == "" {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestSort(t *testing.T) {
+	s, err := Sort([]string{"2.0.0", "v1.0", "1.5.0-rc.1"})
+	if err != "" || !reflect.DeepEqual(s, []string{"v1.0", "1.5.0-rc.1", "2.0.0"}) {
+		t.Errorf("unexpected sort %q %q", s, err)
+	}
+	if _, err := Sort([]string{"nope"}); err == "" {
+		t.Error("expected an error for an invalid version")
+	}
+}
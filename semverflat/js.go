@@ -0,0 +1,74 @@
+//go:build js && wasm
+// +build js,wasm
+
+package semverflat
+
+import "syscall/js"
+
+// Register adds the functions of this package to a JavaScript object set on
+// the global object under name. Results are returned to JavaScript as objects
+// with a value and, when the call fails, an error message. For example:
+//
+//	semver.compare("1.2.3", "1.10.0") // {value: -1}
+//	semver.compare("1.2.3", "nope")   // {error: "Invalid Semantic Version"}
+func Register(name string) {
+	o := js.Global().Get("Object").New()
+	o.Set("valid", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return result(Valid(arg(args, 0)), "")
+	}))
+	o.Set("normalize", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return result(Normalize(arg(args, 0)))
+	}))
+	o.Set("compare", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return result(Compare(arg(args, 0), arg(args, 1)))
+	}))
+	o.Set("satisfies", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return result(Satisfies(arg(args, 0), arg(args, 1)))
+	}))
+	o.Set("validateConstraint", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return result(nil, ValidateConstraint(arg(args, 0)))
+	}))
+	o.Set("maxSatisfying", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return result(MaxSatisfying(stringsArg(args, 0), arg(args, 1)))
+	}))
+	o.Set("sort", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		s, err := Sort(stringsArg(args, 0))
+		l := make([]interface{}, len(s))
+		for k, v := range s {
+			l[k] = v
+		}
+		return result(l, err)
+	}))
+	js.Global().Set(name, o)
+}
+
+// arg returns the string argument at i or an empty string when it is missing.
+func arg(args []js.Value, i int) string {
+	if i >= len(args) || args[i].Type() != js.TypeString {
+		return ""
+	}
+	return args[i].String()
+}
+
+// stringsArg returns the array of strings argument at i.
+func stringsArg(args []js.Value, i int) []string {
+	if i >= len(args) || args[i].Type() != js.TypeObject {
+		return nil
+	}
+	a := args[i]
+	s := make([]string, a.Length())
+	for k := range s {
+		s[k] = a.Index(k).String()
+	}
+	return s
+}
+
+func result(v interface{}, err string) interface{} {
+	r := map[string]interface{}{}
+	if err != "" {
+		r["error"] = err
+	} else if v != nil {
+		r["value"] = v
+	}
+	return r
+}
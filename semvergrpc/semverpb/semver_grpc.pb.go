@@ -0,0 +1,251 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: semverpb/semver.proto
+
+package semverpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SemverService_Validate_FullMethodName      = "/semver.v1.SemverService/Validate"
+	SemverService_Compare_FullMethodName       = "/semver.v1.SemverService/Compare"
+	SemverService_Match_FullMethodName         = "/semver.v1.SemverService/Match"
+	SemverService_MaxSatisfying_FullMethodName = "/semver.v1.SemverService/MaxSatisfying"
+)
+
+// SemverServiceClient is the client API for SemverService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SemverService evaluates versions and constraints using the rules of the
+// Go semver package. Requests holding an invalid version or constraint, other
+// than in Validate, fail with INVALID_ARGUMENT.
+type SemverServiceClient interface {
+	// Validate parses a version and reports its fields.
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// Compare compares two versions.
+	Compare(ctx context.Context, in *CompareRequest, opts ...grpc.CallOption) (*CompareResponse, error)
+	// Match checks if a version satisfies a constraint.
+	Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error)
+	// MaxSatisfying selects the highest version satisfying a constraint.
+	MaxSatisfying(ctx context.Context, in *MaxSatisfyingRequest, opts ...grpc.CallOption) (*MaxSatisfyingResponse, error)
+}
+
+type semverServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSemverServiceClient(cc grpc.ClientConnInterface) SemverServiceClient {
+	return &semverServiceClient{cc}
+}
+
+func (c *semverServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, SemverService_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semverServiceClient) Compare(ctx context.Context, in *CompareRequest, opts ...grpc.CallOption) (*CompareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareResponse)
+	err := c.cc.Invoke(ctx, SemverService_Compare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semverServiceClient) Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchResponse)
+	err := c.cc.Invoke(ctx, SemverService_Match_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semverServiceClient) MaxSatisfying(ctx context.Context, in *MaxSatisfyingRequest, opts ...grpc.CallOption) (*MaxSatisfyingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MaxSatisfyingResponse)
+	err := c.cc.Invoke(ctx, SemverService_MaxSatisfying_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SemverServiceServer is the server API for SemverService service.
+// All implementations must embed UnimplementedSemverServiceServer
+// for forward compatibility.
+//
+// SemverService evaluates versions and constraints using the rules of the
+// Go semver package. Requests holding an invalid version or constraint, other
+// than in Validate, fail with INVALID_ARGUMENT.
+type SemverServiceServer interface {
+	// Validate parses a version and reports its fields.
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// Compare compares two versions.
+	Compare(context.Context, *CompareRequest) (*CompareResponse, error)
+	// Match checks if a version satisfies a constraint.
+	Match(context.Context, *MatchRequest) (*MatchResponse, error)
+	// MaxSatisfying selects the highest version satisfying a constraint.
+	MaxSatisfying(context.Context, *MaxSatisfyingRequest) (*MaxSatisfyingResponse, error)
+	mustEmbedUnimplementedSemverServiceServer()
+}
+
+// UnimplementedSemverServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSemverServiceServer struct{}
+
+func (UnimplementedSemverServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedSemverServiceServer) Compare(context.Context, *CompareRequest) (*CompareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Compare not implemented")
+}
+func (UnimplementedSemverServiceServer) Match(context.Context, *MatchRequest) (*MatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Match not implemented")
+}
+func (UnimplementedSemverServiceServer) MaxSatisfying(context.Context, *MaxSatisfyingRequest) (*MaxSatisfyingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MaxSatisfying not implemented")
+}
+func (UnimplementedSemverServiceServer) mustEmbedUnimplementedSemverServiceServer() {}
+func (UnimplementedSemverServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSemverServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SemverServiceServer will
+// result in compilation errors.
+type UnsafeSemverServiceServer interface {
+	mustEmbedUnimplementedSemverServiceServer()
+}
+
+func RegisterSemverServiceServer(s grpc.ServiceRegistrar, srv SemverServiceServer) {
+	// If the following call panics, it indicates UnimplementedSemverServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SemverService_ServiceDesc, srv)
+}
+
+func _SemverService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemverServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SemverService_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemverServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SemverService_Compare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemverServiceServer).Compare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SemverService_Compare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemverServiceServer).Compare(ctx, req.(*CompareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SemverService_Match_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemverServiceServer).Match(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SemverService_Match_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemverServiceServer).Match(ctx, req.(*MatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SemverService_MaxSatisfying_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaxSatisfyingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemverServiceServer).MaxSatisfying(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SemverService_MaxSatisfying_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemverServiceServer).MaxSatisfying(ctx, req.(*MaxSatisfyingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SemverService_ServiceDesc is the grpc.ServiceDesc for SemverService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SemverService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "semver.v1.SemverService",
+	HandlerType: (*SemverServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Validate",
+			Handler:    _SemverService_Validate_Handler,
+		},
+		{
+			MethodName: "Compare",
+			Handler:    _SemverService_Compare_Handler,
+		},
+		{
+			MethodName: "Match",
+			Handler:    _SemverService_Match_Handler,
+		},
+		{
+			MethodName: "MaxSatisfying",
+			Handler:    _SemverService_MaxSatisfying_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "semverpb/semver.proto",
+}
@@ -0,0 +1,129 @@
+// Package semvergrpc implements the SemverService gRPC service defined in
+// semverpb/semver.proto using the semver package, so services in other
+// languages can call the canonical implementation.
+//
+// It is a separate module so the semver package itself has no dependency on
+// gRPC.
+package semvergrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative semverpb/semver.proto
+
+import (
+	"context"
+
+	"github.com/jesseduffield/semver/v3"
+	"github.com/jesseduffield/semver/v3/semvergrpc/semverpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements semverpb.SemverServiceServer.
+type Server struct {
+	semverpb.UnimplementedSemverServiceServer
+}
+
+// NewServer creates a server for the SemverService.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Register registers a new server for the SemverService with s.
+func Register(s *grpc.Server) {
+	semverpb.RegisterSemverServiceServer(s, NewServer())
+}
+
+// Validate parses a version. An invalid version is reported in the response
+// rather than as an error.
+func (s *Server) Validate(ctx context.Context, req *semverpb.ValidateRequest) (*semverpb.ValidateResponse, error) {
+	v, err := semver.NewVersion(req.GetVersion())
+	if err != nil {
+		return &semverpb.ValidateResponse{Error: err.Error()}, nil
+	}
+	return &semverpb.ValidateResponse{Valid: true, Version: toProto(v)}, nil
+}
+
+// Compare compares two versions.
+func (s *Server) Compare(ctx context.Context, req *semverpb.CompareRequest) (*semverpb.CompareResponse, error) {
+	a, err := parseVersion(req.GetA())
+	if err != nil {
+		return nil, err
+	}
+	b, err := parseVersion(req.GetB())
+	if err != nil {
+		return nil, err
+	}
+	return &semverpb.CompareResponse{Result: int32(a.Compare(b))}, nil
+}
+
+// Match checks if a version satisfies a constraint.
+func (s *Server) Match(ctx context.Context, req *semverpb.MatchRequest) (*semverpb.MatchResponse, error) {
+	c, err := parseConstraint(req.GetConstraint())
+	if err != nil {
+		return nil, err
+	}
+	v, err := parseVersion(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	matched, errs := c.Validate(v)
+	resp := &semverpb.MatchResponse{Matched: matched}
+	for _, e := range errs {
+		resp.Reasons = append(resp.Reasons, e.Error())
+	}
+	return resp, nil
+}
+
+// MaxSatisfying selects the highest version satisfying a constraint.
+func (s *Server) MaxSatisfying(ctx context.Context, req *semverpb.MaxSatisfyingRequest) (*semverpb.MaxSatisfyingResponse, error) {
+	c, err := parseConstraint(req.GetConstraint())
+	if err != nil {
+		return nil, err
+	}
+
+	var max *semver.Version
+	for _, s := range req.GetVersions() {
+		v, err := parseVersion(s)
+		if err != nil {
+			return nil, err
+		}
+		if c.Check(v) && (max == nil || v.GreaterThan(max)) {
+			max = v
+		}
+	}
+
+	resp := &semverpb.MaxSatisfyingResponse{}
+	if max != nil {
+		resp.Version = toProto(max)
+	}
+	return resp, nil
+}
+
+func toProto(v *semver.Version) *semverpb.Version {
+	return &semverpb.Version{
+		Original:   v.Original(),
+		Version:    v.String(),
+		Major:      v.Major(),
+		Minor:      v.Minor(),
+		Patch:      v.Patch(),
+		Prerelease: v.Prerelease(),
+		Metadata:   v.Metadata(),
+	}
+}
+
+func parseVersion(s string) (*semver.Version, error) {
+	v, err := semver.NewVersion(s)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%q: %s", s, err)
+	}
+	return v, nil
+}
+
+func parseConstraint(s string) (*semver.Constraints, error) {
+	c, err := semver.NewConstraint(s)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%q: %s", s, err)
+	}
+	return c, nil
+}
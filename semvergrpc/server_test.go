@@ -0,0 +1,125 @@
+package semvergrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3/semvergrpc/semverpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newClient starts a server on an in memory listener and returns a client
+// connected to it.
+func newClient(t *testing.T) semverpb.SemverServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	Register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return semverpb.NewSemverServiceClient(conn)
+}
+
+func TestValidate(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	resp, err := c.Validate(ctx, &semverpb.ValidateRequest{Version: "v1.2.3-beta+b1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := resp.GetVersion()
+	if !resp.GetValid() || v.GetVersion() != "1.2.3-beta+b1" || v.GetOriginal() != "v1.2.3-beta+b1" || v.GetPrerelease() != "beta" {
+		t.Errorf("unexpected response %v", resp)
+	}
+
+	resp, err = c.Validate(ctx, &semverpb.ValidateRequest{Version: "nope"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetValid() || resp.GetError() == "" {
+		t.Errorf("unexpected response for an invalid version %v", resp)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	c := newClient(t)
+
+	resp, err := c.Compare(context.Background(), &semverpb.CompareRequest{A: "1.10.0", B: "1.2.3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetResult() != 1 {
+		t.Errorf("expected 1 but got %d", resp.GetResult())
+	}
+
+	_, err = c.Compare(context.Background(), &semverpb.CompareRequest{A: "1.10.0", B: "nope"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected an invalid argument error but got %v", err)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	resp, err := c.Match(ctx, &semverpb.MatchRequest{Constraint: "^1.2", Version: "1.4.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetMatched() || len(resp.GetReasons()) != 0 {
+		t.Errorf("unexpected response %v", resp)
+	}
+
+	resp, err = c.Match(ctx, &semverpb.MatchRequest{Constraint: "^1.2", Version: "2.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetMatched() || len(resp.GetReasons()) != 1 {
+		t.Errorf("unexpected response for a version outside of the range %v", resp)
+	}
+
+	_, err = c.Match(ctx, &semverpb.MatchRequest{Constraint: "nope", Version: "2.0.0"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected an invalid argument error but got %v", err)
+	}
+}
+
+func TestMaxSatisfying(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	resp, err := c.MaxSatisfying(ctx, &semverpb.MaxSatisfyingRequest{
+		Constraint: "^1.2",
+		Versions:   []string{"1.2.0", "1.9.1", "2.0.0", "1.4.0", "1.10.0-beta"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetVersion().GetOriginal() != "1.9.1" {
+		t.Errorf("expected 1.9.1 but got %v", resp.GetVersion())
+	}
+
+	resp, err = c.MaxSatisfying(ctx, &semverpb.MaxSatisfyingRequest{Constraint: "^3", Versions: []string{"1.2.0"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetVersion() != nil {
+		t.Errorf("expected no version but got %v", resp.GetVersion())
+	}
+}
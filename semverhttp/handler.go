@@ -0,0 +1,243 @@
+// Package semverhttp serves the parsing and matching rules of the semver
+// package over HTTP so services written in other languages can reuse them.
+//
+// Every endpoint accepts a POST with a JSON body and responds with JSON. A
+// request that can not be decoded, or that holds an invalid version or
+// constraint where one is required, responds with 400 Bad Request and a body
+// of the form {"error": "..."}.
+//
+//	POST /validate        {"version": "1.2.3"}
+//	POST /compare         {"a": "1.2.3", "b": "1.10.0"}
+//	POST /match           {"constraint": "^1.2", "version": "1.4.0"}
+//	POST /max-satisfying  {"constraint": "^1.2", "versions": ["1.2.0", "1.4.0"]}
+package semverhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// maxBodySize limits the size of request bodies.
+const maxBodySize = 1 << 20
+
+// Version is the JSON form of a parsed version.
+type Version struct {
+	Original   string `json:"original"`
+	Version    string `json:"version"`
+	Major      uint64 `json:"major"`
+	Minor      uint64 `json:"minor"`
+	Patch      uint64 `json:"patch"`
+	Prerelease string `json:"prerelease,omitempty"`
+	Metadata   string `json:"metadata,omitempty"`
+}
+
+func newVersion(v *semver.Version) *Version {
+	return &Version{
+		Original:   v.Original(),
+		Version:    v.String(),
+		Major:      v.Major(),
+		Minor:      v.Minor(),
+		Patch:      v.Patch(),
+		Prerelease: v.Prerelease(),
+		Metadata:   v.Metadata(),
+	}
+}
+
+// ValidateRequest is the body of a request to /validate.
+type ValidateRequest struct {
+	Version string `json:"version"`
+}
+
+// ValidateResponse is the response from /validate. An invalid version is
+// reported in the response rather than as a bad request.
+type ValidateResponse struct {
+	Valid   bool     `json:"valid"`
+	Error   string   `json:"error,omitempty"`
+	Version *Version `json:"version,omitempty"`
+}
+
+// CompareRequest is the body of a request to /compare.
+type CompareRequest struct {
+	A string `json:"a"`
+	B string `json:"b"`
+}
+
+// CompareResponse is the response from /compare. Result is -1, 0, or 1 the
+// same as Version.Compare.
+type CompareResponse struct {
+	Result int `json:"result"`
+}
+
+// MatchRequest is the body of a request to /match.
+type MatchRequest struct {
+	Constraint string `json:"constraint"`
+	Version    string `json:"version"`
+}
+
+// MatchResponse is the response from /match. Reasons explain why a version
+// that does not match failed the constraint.
+type MatchResponse struct {
+	Matched bool     `json:"matched"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// MaxSatisfyingRequest is the body of a request to /max-satisfying.
+type MaxSatisfyingRequest struct {
+	Constraint string   `json:"constraint"`
+	Versions   []string `json:"versions"`
+}
+
+// MaxSatisfyingResponse is the response from /max-satisfying. Version is
+// null when none of the versions satisfy the constraint.
+type MaxSatisfyingResponse struct {
+	Version *Version `json:"version"`
+}
+
+// ErrorResponse is the body of a 400 Bad Request response.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHandler returns a handler serving the endpoints described in the
+// package documentation. It can be mounted under a prefix with
+// http.StripPrefix.
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate", validate)
+	mux.HandleFunc("/compare", compare)
+	mux.HandleFunc("/match", match)
+	mux.HandleFunc("/max-satisfying", maxSatisfying)
+	return mux
+}
+
+func validate(w http.ResponseWriter, r *http.Request) {
+	var req ValidateRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	v, err := semver.NewVersion(req.Version)
+	if err != nil {
+		writeJSON(w, http.StatusOK, ValidateResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, ValidateResponse{Valid: true, Version: newVersion(v)})
+}
+
+func compare(w http.ResponseWriter, r *http.Request) {
+	var req CompareRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	a, ok := parseVersion(w, req.A)
+	if !ok {
+		return
+	}
+	b, ok := parseVersion(w, req.B)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, CompareResponse{Result: a.Compare(b)})
+}
+
+func match(w http.ResponseWriter, r *http.Request) {
+	var req MatchRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	c, ok := parseConstraint(w, req.Constraint)
+	if !ok {
+		return
+	}
+	v, ok := parseVersion(w, req.Version)
+	if !ok {
+		return
+	}
+
+	matched, errs := c.Validate(v)
+	resp := MatchResponse{Matched: matched}
+	for _, e := range errs {
+		resp.Reasons = append(resp.Reasons, e.Error())
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func maxSatisfying(w http.ResponseWriter, r *http.Request) {
+	var req MaxSatisfyingRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	c, ok := parseConstraint(w, req.Constraint)
+	if !ok {
+		return
+	}
+
+	var max *semver.Version
+	for _, s := range req.Versions {
+		v, ok := parseVersion(w, s)
+		if !ok {
+			return
+		}
+		if c.Check(v) && (max == nil || v.GreaterThan(max)) {
+			max = v
+		}
+	}
+
+	resp := MaxSatisfyingResponse{}
+	if max != nil {
+		resp.Version = newVersion(max)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// decode reads the JSON request body into v. When the request can not be
+// decoded an error response is written and false is returned.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, ErrorResponse{Error: "only POST is supported"})
+		return false
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		badRequest(w, fmt.Errorf("invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+func parseVersion(w http.ResponseWriter, s string) (*semver.Version, bool) {
+	v, err := semver.NewVersion(s)
+	if err != nil {
+		badRequest(w, fmt.Errorf("%q: %s", s, err))
+		return nil, false
+	}
+	return v, true
+}
+
+func parseConstraint(w http.ResponseWriter, s string) (*semver.Constraints, bool) {
+	c, err := semver.NewConstraint(s)
+	if err != nil {
+		badRequest(w, fmt.Errorf("%q: %s", s, err))
+		return nil, false
+	}
+	return c, true
+}
+
+func badRequest(w http.ResponseWriter, err error) {
+	writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
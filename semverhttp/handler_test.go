@@ -0,0 +1,113 @@
+package semverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// post sends a request body to the handler and decodes the response into v.
+func post(t *testing.T, path, body string, v interface{}) int {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	NewHandler().ServeHTTP(w, r)
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected a JSON content type from %s but got %q", path, ct)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("unable to decode response from %s: %s: %s", path, err, w.Body)
+	}
+	return w.Code
+}
+
+func TestValidate(t *testing.T) {
+	var resp ValidateResponse
+	if code := post(t, "/validate", `{"version": "v1.2.3-beta+b1"}`, &resp); code != http.StatusOK {
+		t.Fatalf("unexpected status %d", code)
+	}
+	if !resp.Valid || resp.Version.Version != "1.2.3-beta+b1" || resp.Version.Prerelease != "beta" || resp.Version.Metadata != "b1" {
+		t.Errorf("unexpected response %+v %+v", resp, resp.Version)
+	}
+
+	resp = ValidateResponse{}
+	if code := post(t, "/validate", `{"version": "nope"}`, &resp); code != http.StatusOK {
+		t.Fatalf("unexpected status %d", code)
+	}
+	if resp.Valid || resp.Error == "" || resp.Version != nil {
+		t.Errorf("unexpected response for an invalid version %+v", resp)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var resp CompareResponse
+	if code := post(t, "/compare", `{"a": "1.2.3", "b": "1.10.0"}`, &resp); code != http.StatusOK {
+		t.Fatalf("unexpected status %d", code)
+	}
+	if resp.Result != -1 {
+		t.Errorf("expected -1 but got %d", resp.Result)
+	}
+
+	var e ErrorResponse
+	if code := post(t, "/compare", `{"a": "1.2.3", "b": "nope"}`, &e); code != http.StatusBadRequest || !strings.Contains(e.Error, `"nope"`) {
+		t.Errorf("unexpected response for an invalid version %d: %+v", code, e)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	var resp MatchResponse
+	if code := post(t, "/match", `{"constraint": "^1.2", "version": "1.4.0"}`, &resp); code != http.StatusOK {
+		t.Fatalf("unexpected status %d", code)
+	}
+	if !resp.Matched || len(resp.Reasons) != 0 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	resp = MatchResponse{}
+	post(t, "/match", `{"constraint": "^1.2", "version": "2.0.0"}`, &resp)
+	if resp.Matched || len(resp.Reasons) != 1 {
+		t.Errorf("unexpected response for a version outside of the range %+v", resp)
+	}
+
+	var e ErrorResponse
+	if code := post(t, "/match", `{"constraint": "nope", "version": "2.0.0"}`, &e); code != http.StatusBadRequest {
+		t.Errorf("expected a bad request for an invalid constraint but got %d", code)
+	}
+}
+
+func TestMaxSatisfying(t *testing.T) {
+	var resp MaxSatisfyingResponse
+	body := `{"constraint": "^1.2", "versions": ["1.2.0", "1.9.1", "2.0.0", "1.4.0", "1.10.0-beta"]}`
+	if code := post(t, "/max-satisfying", body, &resp); code != http.StatusOK {
+		t.Fatalf("unexpected status %d", code)
+	}
+	if resp.Version == nil || resp.Version.Original != "1.9.1" {
+		t.Errorf("expected 1.9.1 but got %+v", resp.Version)
+	}
+
+	resp = MaxSatisfyingResponse{}
+	post(t, "/max-satisfying", `{"constraint": "^3", "versions": ["1.2.0"]}`, &resp)
+	if resp.Version != nil {
+		t.Errorf("expected no version but got %+v", resp.Version)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	var e ErrorResponse
+	if code := post(t, "/match", `{"constraint": "^1", "extra": true}`, &e); code != http.StatusBadRequest {
+		t.Errorf("expected a bad request for an unknown field but got %d", code)
+	}
+	if code := post(t, "/match", `{`, &e); code != http.StatusBadRequest {
+		t.Errorf("expected a bad request for malformed JSON but got %d", code)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/validate", nil)
+	w := httptest.NewRecorder()
+	NewHandler().ServeHTTP(w, r)
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("expected GET to not be allowed but got %d", w.Code)
+	}
+}
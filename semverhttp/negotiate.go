@@ -0,0 +1,62 @@
+package semverhttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// VersionHeader is the header a client requests an API version with and the
+// server responds with the version it chose.
+const VersionHeader = "API-Version"
+
+// ErrNoAcceptableVersion is returned by Negotiate when none of the supported
+// versions satisfy the version requested.
+var ErrNoAcceptableVersion = errors.New("no supported API version satisfies the request")
+
+// Negotiate picks the API version to serve for the version a client
+// requested. The request is a version or constraint, such as 2, 2.1, or
+// ">=2.1, <4", and the greatest supported version it admits is chosen. A
+// version without all its parts admits every version it is a prefix of, so
+// 2 admits 2.4.0. An empty request chooses the greatest supported version.
+// ErrNoAcceptableVersion is returned when no supported version is admitted.
+func Negotiate(requested string, supported []*semver.Version) (*semver.Version, error) {
+	requested = strings.TrimSpace(requested)
+	if requested == "" {
+		requested = "*"
+	}
+	c, err := semver.NewConstraint(requested)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a version or constraint: %s", requested, err)
+	}
+
+	var best *semver.Version
+	for _, v := range supported {
+		if c.Check(v) && (best == nil || v.GreaterThan(best)) {
+			best = v
+		}
+	}
+	if best == nil {
+		return nil, ErrNoAcceptableVersion
+	}
+	return best, nil
+}
+
+// NegotiateRequest negotiates the API version for a request from its
+// VersionHeader. The chosen version is set as the VersionHeader of the
+// response, along with a Vary header so caches keep the responses for each
+// version apart. The error is that of Negotiate and leaves the response
+// headers unchanged, so the caller can respond with 400 Bad Request or 406
+// Not Acceptable as suits the service.
+func NegotiateRequest(w http.ResponseWriter, r *http.Request, supported []*semver.Version) (*semver.Version, error) {
+	v, err := Negotiate(r.Header.Get(VersionHeader), supported)
+	if err != nil {
+		return nil, err
+	}
+	w.Header().Set(VersionHeader, v.String())
+	w.Header().Add("Vary", VersionHeader)
+	return v, nil
+}
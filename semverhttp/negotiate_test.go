@@ -0,0 +1,70 @@
+package semverhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func supportedVersions() []*semver.Version {
+	var vs []*semver.Version
+	for _, s := range []string{"1.0.0", "1.2.0", "2.0.0", "2.1.0", "2.1.3", "3.0.0-beta.1"} {
+		vs = append(vs, semver.MustParse(s))
+	}
+	return vs
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		requested string
+		expected  string
+	}{
+		{"", "2.1.3"},
+		{"1", "1.2.0"},
+		{"2.0", "2.0.0"},
+		{"v2.1", "2.1.3"},
+		{"^1.1", "1.2.0"},
+		{">=1.1, <2.1", "2.0.0"},
+		{">=3.0.0-0", "3.0.0-beta.1"},
+		{"1.0.0", "1.0.0"},
+	}
+
+	for _, tc := range tests {
+		v, err := Negotiate(tc.requested, supportedVersions())
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", tc.requested, err)
+			continue
+		}
+		if v.String() != tc.expected {
+			t.Errorf("expected %q to negotiate %s but got %s", tc.requested, tc.expected, v)
+		}
+	}
+
+	if _, err := Negotiate("4", supportedVersions()); err != ErrNoAcceptableVersion {
+		t.Errorf("expected ErrNoAcceptableVersion but got %v", err)
+	}
+	if _, err := Negotiate("nope", supportedVersions()); err == nil || err == ErrNoAcceptableVersion {
+		t.Errorf("expected an error for an invalid request but got %v", err)
+	}
+}
+
+func TestNegotiateRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(VersionHeader, "2.0")
+	w := httptest.NewRecorder()
+	v, err := NegotiateRequest(w, r, supportedVersions())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v.String() != "2.0.0" || w.Header().Get(VersionHeader) != "2.0.0" || w.Header().Get("Vary") != VersionHeader {
+		t.Errorf("expected 2.0.0 with its headers but got %s and %v", v, w.Header())
+	}
+
+	r.Header.Set(VersionHeader, "5")
+	w = httptest.NewRecorder()
+	if _, err := NegotiateRequest(w, r, supportedVersions()); err != ErrNoAcceptableVersion || len(w.Header()) != 0 {
+		t.Errorf("expected ErrNoAcceptableVersion without headers but got %v and %v", err, w.Header())
+	}
+}
@@ -0,0 +1,276 @@
+package semvertest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// The constraint dialects RunConformance has vectors for.
+const (
+	// DialectSemver is this package's own constraint syntax and default
+	// options, as parsed by semver.NewConstraint.
+	DialectSemver = "semver"
+
+	// DialectNpm is the node-semver behavior of semver.NpmOptions.
+	DialectNpm = "npm"
+
+	// DialectRubyGems is the ~> operator of RubyGems, as given by the
+	// semver.PessimisticRubyGems option.
+	DialectRubyGems = "rubygems"
+)
+
+// Implementation is what RunConformance checks. A nil field is filled in with
+// this package's own implementation, so a fork or dialect plugin only
+// supplies what it replaces.
+type Implementation struct {
+	// StrictParse parses a version written exactly as SemVer 2.0.0 allows
+	// and rejects anything else, as semver.StrictNewVersion does.
+	StrictParse func(string) (*semver.Version, error)
+
+	// Compare orders versions by SemVer 2.0.0 precedence, as
+	// semver.Version.Compare does.
+	Compare func(a, b *semver.Version) int
+
+	// Dialects maps a dialect, such as DialectNpm, to the parser for its
+	// constraints. The vectors of dialects not in the map are skipped. When
+	// nil the dialects of this package are used.
+	Dialects map[string]Parser
+}
+
+// PackageImplementation returns this package's own implementation, which
+// RunConformance uses for the fields of an Implementation left nil.
+func PackageImplementation() Implementation {
+	return Implementation{
+		StrictParse: semver.StrictNewVersion,
+		Compare: func(a, b *semver.Version) int {
+			return a.Compare(b)
+		},
+		Dialects: map[string]Parser{
+			DialectSemver: semver.NewConstraint,
+			DialectNpm: func(c string) (*semver.Constraints, error) {
+				return semver.NewConstraintWithOptions(c, semver.NpmOptions())
+			},
+			DialectRubyGems: func(c string) (*semver.Constraints, error) {
+				return semver.NewConstraintWithOptions(c, semver.MatchOptions{Pessimistic: semver.PessimisticRubyGems})
+			},
+		},
+	}
+}
+
+// RunConformance checks an implementation against the examples of the
+// SemVer 2.0.0 specification at semver.org and a curated set of constraint
+// vectors for each dialect, running each as a subtest. The data is compiled
+// into this package, so it runs wherever the package is imported without
+// access to its source.
+//
+// The versions the specification's examples list as valid must parse and the
+// invalid ones must not, apart from the example with numbers too large for a
+// uint64, which is left out. The ordering examples must sort in the order
+// given, and versions differing only in build metadata must have the same
+// precedence.
+func RunConformance(t *testing.T, impl Implementation) {
+	t.Helper()
+	for _, c := range conformanceChecks(impl) {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t)
+		})
+	}
+}
+
+// conformanceCheck is a single check run by RunConformance.
+type conformanceCheck struct {
+	name string
+	run  func(t testing.TB)
+}
+
+// conformanceChecks returns the checks of an implementation, filling in the
+// fields left nil.
+func conformanceChecks(impl Implementation) []conformanceCheck {
+	def := PackageImplementation()
+	if impl.StrictParse == nil {
+		impl.StrictParse = def.StrictParse
+	}
+	if impl.Compare == nil {
+		impl.Compare = def.Compare
+	}
+	if impl.Dialects == nil {
+		impl.Dialects = def.Dialects
+	}
+
+	var checks []conformanceCheck
+	for _, s := range specValid {
+		s := s
+		checks = append(checks, conformanceCheck{"valid/" + s, func(t testing.TB) {
+			if _, err := impl.StrictParse(s); err != nil {
+				t.Errorf("expected %q to be valid: %s", s, err)
+			}
+		}})
+	}
+	for _, s := range specInvalid {
+		s := s
+		checks = append(checks, conformanceCheck{"invalid/" + s, func(t testing.TB) {
+			if v, err := impl.StrictParse(s); err == nil {
+				t.Errorf("expected %q to be invalid but got %s", s, v)
+			}
+		}})
+	}
+
+	checks = append(checks, conformanceCheck{"ordering", func(t testing.TB) {
+		vs, ok := parseAll(t, impl.StrictParse, specOrdering)
+		if !ok {
+			return
+		}
+		for i := range vs {
+			for j := range vs {
+				if d, e := impl.Compare(vs[i], vs[j]), compareInts(i, j); d != e {
+					t.Errorf("expected comparing %s to %s to be %d but got %d", specOrdering[i], specOrdering[j], e, d)
+				}
+			}
+		}
+	}})
+
+	checks = append(checks, conformanceCheck{"metadata", func(t testing.TB) {
+		for _, pair := range specEqual {
+			vs, ok := parseAll(t, impl.StrictParse, pair[:])
+			if !ok {
+				return
+			}
+			if d := impl.Compare(vs[0], vs[1]); d != 0 {
+				t.Errorf("expected %s and %s to have the same precedence but got %d", pair[0], pair[1], d)
+			}
+		}
+	}})
+
+	for k, v := range dialectVectors {
+		parse, ok := impl.Dialects[v.dialect]
+		if !ok {
+			continue
+		}
+		gc := GoldenCase{Input: v.input, Error: v.err, Match: v.match, Reject: v.reject}
+		checks = append(checks, conformanceCheck{fmt.Sprintf("%s/%d", v.dialect, k), func(t testing.TB) {
+			runGoldenCase(t, parse, gc)
+		}})
+	}
+	return checks
+}
+
+// parseAll parses each version, failing the test when any is invalid.
+func parseAll(t testing.TB, parse func(string) (*semver.Version, error), in []string) ([]*semver.Version, bool) {
+	t.Helper()
+	out := make([]*semver.Version, len(in))
+	for k, s := range in {
+		v, err := parse(s)
+		if err != nil {
+			t.Fatalf("unable to parse %q: %s", s, err)
+			return nil, false
+		}
+		out[k] = v
+	}
+	return out, true
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// specValid are the valid versions listed by semver.org.
+var specValid = []string{
+	"0.0.4", "1.2.3", "10.20.30", "1.1.2-prerelease+meta", "1.1.2+meta",
+	"1.1.2+meta-valid", "1.0.0-alpha", "1.0.0-beta", "1.0.0-alpha.beta",
+	"1.0.0-alpha.beta.1", "1.0.0-alpha.1", "1.0.0-alpha0.valid",
+	"1.0.0-alpha.0valid", "1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay",
+	"1.0.0-rc.1+build.1", "2.0.0-rc.1+build.123", "1.2.3-beta",
+	"10.2.3-DEV-SNAPSHOT", "1.2.3-SNAPSHOT-123", "1.0.0", "2.0.0", "1.1.7",
+	"2.0.0+build.1848", "2.0.1-alpha.1227", "1.0.0-alpha+beta",
+	"1.2.3----RC-SNAPSHOT.12.9.1--.12+788", "1.2.3----R-S.12.9.1--.12+meta",
+	"1.2.3----RC-SNAPSHOT.12.9.1--.12", "1.0.0+0.build.1-rc.10000aaa-kk-0.1",
+	"1.0.0-0A.is.legal",
+
+	// The examples of the prerelease and metadata items of the
+	// specification.
+	"1.0.0-0.3.7", "1.0.0-x.7.z.92", "1.0.0-x-y-z.--", "1.0.0-alpha+001",
+	"1.0.0+20130313144700", "1.0.0-beta+exp.sha.5114f85",
+	"1.0.0+21AF26D3----117B344092BD",
+}
+
+// specInvalid are the invalid versions listed by semver.org.
+var specInvalid = []string{
+	"1", "1.2", "1.2.3-0123", "1.2.3-0123.0123", "1.1.2+.123", "+invalid",
+	"-invalid", "-invalid+invalid", "-invalid.01", "alpha", "alpha.beta",
+	"alpha.beta.1", "alpha.1", "alpha+beta", "alpha_beta", "alpha.", "alpha..",
+	"beta", "1.0.0-alpha_beta", "-alpha.", "1.0.0-alpha..", "1.0.0-alpha..1",
+	"1.0.0-alpha...1", "1.0.0-alpha....1", "1.0.0-alpha.....1",
+	"1.0.0-alpha......1", "1.0.0-alpha.......1", "01.1.1", "1.01.1", "1.1.01",
+	"1.2.3.DEV", "1.2-SNAPSHOT", "1.2.31.2.3----RC-SNAPSHOT.12.09.1--..12+788",
+	"1.2-RC-SNAPSHOT", "-1.0.3-gamma+b7718", "+justmeta", "9.8.7+meta+meta",
+	"9.8.7-whatever+meta+meta",
+	"99999999999999999999999.999999999.99999999999999999----RC-SNAPSHOT.12.09.1--------------------------------..12",
+}
+
+// specOrdering are the precedence examples of semver.org, lowest first.
+var specOrdering = []string{
+	"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+	"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "2.0.0", "2.1.0",
+	"2.1.1",
+}
+
+// specEqual are versions of the same precedence as they differ only in
+// build metadata.
+var specEqual = [][2]string{
+	{"1.0.0-alpha+001", "1.0.0-alpha"},
+	{"1.0.0+20130313144700", "1.0.0"},
+	{"1.0.0-beta+exp.sha.5114f85", "1.0.0-beta+exp.sha.5114f86"},
+	{"1.0.0+21AF26D3----117B344092BD", "1.0.0+0"},
+}
+
+// dialectVector is a constraint of a dialect with the versions it must and
+// must not admit, or that must fail to parse.
+type dialectVector struct {
+	dialect string
+	input   string
+	err     bool
+	match   []string
+	reject  []string
+}
+
+// dialectVectors are the curated constraint vectors. Where dialects differ
+// on the same input each has a vector of its own.
+var dialectVectors = []dialectVector{
+	{dialect: DialectSemver, input: "^1.2.3", match: []string{"1.2.3", "1.9.9"}, reject: []string{"1.2.2", "2.0.0", "1.3.0-beta"}},
+	{dialect: DialectSemver, input: "^0.2.3", match: []string{"0.2.3", "0.2.9"}, reject: []string{"0.3.0", "0.2.2"}},
+	{dialect: DialectSemver, input: "^0.0.3", match: []string{"0.0.3"}, reject: []string{"0.0.4", "0.0.2"}},
+	{dialect: DialectSemver, input: "^0", match: []string{"0.0.0", "0.9.9"}, reject: []string{"1.0.0"}},
+	{dialect: DialectSemver, input: "~1.2.3", match: []string{"1.2.3", "1.2.9"}, reject: []string{"1.3.0", "1.2.2"}},
+	{dialect: DialectSemver, input: "~1", match: []string{"1.0.0", "1.9.0"}, reject: []string{"2.0.0"}},
+	{dialect: DialectSemver, input: "1.2.x", match: []string{"1.2.0", "1.2.99"}, reject: []string{"1.3.0", "1.1.9"}},
+	{dialect: DialectSemver, input: "*", match: []string{"0.0.0", "9.9.9"}, reject: []string{"1.0.0-alpha"}},
+	{dialect: DialectSemver, input: "1.2.3 - 2.3.4", match: []string{"1.2.3", "2.3.4"}, reject: []string{"1.2.2", "2.3.5"}},
+	{dialect: DialectSemver, input: ">=1.0, <2.0 || >=3.0", match: []string{"1.5.0", "3.1.0"}, reject: []string{"2.5.0", "0.9.0"}},
+	{dialect: DialectSemver, input: "!=1.2.3", match: []string{"1.2.4", "1.2.2"}, reject: []string{"1.2.3"}},
+	{dialect: DialectSemver, input: ">=1.2.3-beta.2", match: []string{"1.2.3-beta.3", "1.2.3", "1.2.4-alpha"}, reject: []string{"1.2.3-beta.1"}},
+	{dialect: DialectSemver, input: "~>1.2", match: []string{"1.2.0", "1.2.9"}, reject: []string{"1.3.0"}},
+	{dialect: DialectSemver, input: "", err: true},
+	{dialect: DialectSemver, input: "nope", err: true},
+	{dialect: DialectSemver, input: "^", err: true},
+	{dialect: DialectSemver, input: ">=1.2.3 <", err: true},
+
+	{dialect: DialectNpm, input: "^1.2.3", match: []string{"1.2.3", "1.9.9"}, reject: []string{"2.0.0", "1.3.0-beta"}},
+	{dialect: DialectNpm, input: ">=1.2.3-beta.2", match: []string{"1.2.3-beta.3", "1.2.3", "2.0.0"}, reject: []string{"1.2.3-beta.1", "1.2.4-alpha"}},
+	{dialect: DialectNpm, input: ">1.2.3-alpha.3", match: []string{"1.2.3-alpha.7", "3.4.5"}, reject: []string{"3.4.5-alpha.9", "1.2.3-alpha.3"}},
+	{dialect: DialectNpm, input: "^1.2.3-beta.2", match: []string{"1.2.3-beta.4", "1.9.0"}, reject: []string{"1.3.0-beta", "2.0.0"}},
+	{dialect: DialectNpm, input: "~1.2.3-beta.2", match: []string{"1.2.3-beta.4", "1.2.9"}, reject: []string{"1.2.4-beta.2", "1.3.0"}},
+	{dialect: DialectNpm, input: "1.x || >=2.5.0 || 5.0.0 - 7.2.3", match: []string{"1.2.3", "2.5.0", "6.0.0"}, reject: []string{"2.0.0"}},
+
+	{dialect: DialectRubyGems, input: "~>1.2", match: []string{"1.2.0", "1.9.0"}, reject: []string{"2.0.0", "1.1.9"}},
+	{dialect: DialectRubyGems, input: "~>1.2.3", match: []string{"1.2.3", "1.2.9"}, reject: []string{"1.3.0"}},
+	{dialect: DialectRubyGems, input: "~>1", match: []string{"1.0.0", "1.9.0"}, reject: []string{"2.0.0"}},
+}
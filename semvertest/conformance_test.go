@@ -0,0 +1,53 @@
+package semvertest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func TestRunConformance(t *testing.T) {
+	RunConformance(t, PackageImplementation())
+}
+
+func TestRunConformanceDefaults(t *testing.T) {
+	// Only the dialect supplied is checked and the rest of the
+	// implementation is this package's own.
+	RunConformance(t, Implementation{
+		Dialects: map[string]Parser{DialectSemver: semver.NewConstraint},
+	})
+}
+
+func TestRunConformanceFailures(t *testing.T) {
+	tests := map[string]Implementation{
+		// A lenient parser accepts versions the specification does not.
+		"lenient": {StrictParse: semver.NewVersion},
+
+		// Ignoring prereleases gets their order wrong.
+		"compare": {Compare: func(a, b *semver.Version) int {
+			c, _ := semver.NewVersion(fmt.Sprintf("%d.%d.%d", a.Major(), a.Minor(), a.Patch()))
+			d, _ := semver.NewVersion(fmt.Sprintf("%d.%d.%d", b.Major(), b.Minor(), b.Patch()))
+			return c.Compare(d)
+		}},
+
+		// A dialect admitting everything fails the vectors.
+		"dialect": {Dialects: map[string]Parser{DialectSemver: func(string) (*semver.Constraints, error) {
+			return semver.NewConstraint(">=0.0.0-0")
+		}}},
+	}
+
+	for name, impl := range tests {
+		failed := 0
+		for _, c := range conformanceChecks(impl) {
+			r := &recorder{TB: t}
+			c.run(r)
+			if r.failed {
+				failed++
+			}
+		}
+		if failed == 0 {
+			t.Errorf("expected the %s implementation to fail conformance", name)
+		}
+	}
+}
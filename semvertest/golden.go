@@ -0,0 +1,111 @@
+package semvertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// GoldenCase is a single case of a golden file. A golden file is a JSON
+// array of cases.
+type GoldenCase struct {
+	// Input is the constraint as written in the syntax of the parser being
+	// tested.
+	Input string `json:"input"`
+
+	// Canonical is the expected rendering of the parsed constraints by
+	// Constraints.String. It is not checked when empty.
+	Canonical string `json:"canonical,omitempty"`
+
+	// Error is true when the input is expected to fail to parse.
+	Error bool `json:"error,omitempty"`
+
+	// Match lists versions the constraints must admit.
+	Match []string `json:"match,omitempty"`
+
+	// Reject lists versions the constraints must not admit.
+	Reject []string `json:"reject,omitempty"`
+}
+
+// Parser parses constraints written in some syntax into semver constraints.
+// semver.NewConstraint is the parser for this package's own syntax while
+// other syntaxes provide their own.
+type Parser func(string) (*semver.Constraints, error)
+
+// GoldenFile returns the path to the golden file shipped with this package
+// holding cases written in this package's constraint syntax.
+func GoldenFile() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata", "constraints.json")
+}
+
+// LoadGolden reads the cases in a golden file.
+func LoadGolden(path string) ([]GoldenCase, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cases []GoldenCase
+	if err := json.Unmarshal(b, &cases); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	for k, c := range cases {
+		if c.Error && (c.Canonical != "" || len(c.Match) > 0 || len(c.Reject) > 0) {
+			return nil, fmt.Errorf("%s: case %d for %q expects an error along with results", path, k, c.Input)
+		}
+	}
+	return cases, nil
+}
+
+// RunGolden runs each case of the golden files as a subtest, parsing the
+// inputs with parse. Every failure is reported rather than stopping at the
+// first.
+func RunGolden(t *testing.T, parse Parser, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		cases, err := LoadGolden(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for k, gc := range cases {
+			gc := gc
+			t.Run(fmt.Sprintf("%s/%d", filepath.Base(path), k), func(t *testing.T) {
+				runGoldenCase(t, parse, gc)
+			})
+		}
+	}
+}
+
+func runGoldenCase(t testing.TB, parse Parser, gc GoldenCase) {
+	c, err := parse(gc.Input)
+	if gc.Error {
+		if err == nil {
+			t.Errorf("expected %q to fail to parse but got %q", gc.Input, c)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("unable to parse %q: %s", gc.Input, err)
+	}
+
+	if gc.Canonical != "" && c.String() != gc.Canonical {
+		t.Errorf("expected %q to render as %q but got %q", gc.Input, gc.Canonical, c.String())
+	}
+	for _, s := range gc.Match {
+		if v := Version(t, s); !c.Check(v) {
+			t.Errorf("expected %q to admit %s", gc.Input, s)
+		}
+	}
+	for _, s := range gc.Reject {
+		if v := Version(t, s); c.Check(v) {
+			t.Errorf("expected %q not to admit %s", gc.Input, s)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package semvertest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func TestRunGolden(t *testing.T) {
+	RunGolden(t, semver.NewConstraint, GoldenFile())
+}
+
+func TestLoadGoldenErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "semvertest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := LoadGolden(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+
+	tests := []string{
+		`{"input": "^1"}`,
+		`[{"input": "nope", "error": true, "match": ["1.2.3"]}]`,
+	}
+	for _, tc := range tests {
+		path := filepath.Join(dir, "cases.json")
+		if err := ioutil.WriteFile(path, []byte(tc), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadGolden(path); err == nil {
+			t.Errorf("expected an error loading %s", tc)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package semvertest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// The Check functions verify the laws the semver package keeps, so forks and
+// code producing versions or constraints can confirm they preserve them. Each
+// returns an error describing the first violation found or nil. Inputs that
+// can not be parsed have nothing to check and return nil.
+
+// CheckVersionRoundTrip checks that the canonical form of a version parses
+// back to an equal version with the same canonical form.
+func CheckVersionRoundTrip(s string) error {
+	v, err := semver.NewVersion(s)
+	if err != nil {
+		return nil
+	}
+
+	p, err := semver.NewVersion(v.String())
+	if err != nil {
+		return fmt.Errorf("%q, the canonical form of %q, does not parse: %s", v.String(), s, err)
+	}
+	if p.Compare(v) != 0 || p.Metadata() != v.Metadata() {
+		return fmt.Errorf("%q parses to %s rather than %s", v.String(), p, v)
+	}
+	if p.String() != v.String() {
+		return fmt.Errorf("%q has the canonical form %q rather than itself", v.String(), p.String())
+	}
+	return nil
+}
+
+// CheckConstraintRoundTrip checks that the rendering of constraints parses
+// back to constraints with the same rendering that admit the same versions.
+// The versions are compared on the probes along with versions made from the
+// bounds of the constraints.
+func CheckConstraintRoundTrip(s string, probes ...*semver.Version) error {
+	c, err := semver.NewConstraint(s)
+	if err != nil {
+		return nil
+	}
+
+	p, err := semver.NewConstraint(c.String())
+	if err != nil {
+		return fmt.Errorf("%q, the rendering of %q, does not parse: %s", c.String(), s, err)
+	}
+	if p.String() != c.String() {
+		return fmt.Errorf("%q renders as %q rather than itself", c.String(), p.String())
+	}
+	return disagreement(c, p, probes)
+}
+
+// CheckUnionCommutative checks that a || b admits the same versions as
+// b || a, and that they admit exactly the versions admitted by a or by b.
+func CheckUnionCommutative(a, b string, probes ...*semver.Version) error {
+	ca, errA := semver.NewConstraint(a)
+	cb, errB := semver.NewConstraint(b)
+	if errA != nil || errB != nil {
+		return nil
+	}
+
+	ab, err := semver.NewConstraint(ca.String() + " || " + cb.String())
+	if err != nil {
+		return fmt.Errorf("the union of %q and %q does not parse: %s", a, b, err)
+	}
+	ba, err := semver.NewConstraint(cb.String() + " || " + ca.String())
+	if err != nil {
+		return fmt.Errorf("the union of %q and %q does not parse: %s", b, a, err)
+	}
+	if err := disagreement(ab, ba, probes); err != nil {
+		return err
+	}
+
+	for _, v := range append(boundProbes(ca, cb), probes...) {
+		if ab.Check(v) != (ca.Check(v) || cb.Check(v)) {
+			return fmt.Errorf("%q admitting %s is %t but %q or %q is %t", ab, v, ab.Check(v), a, b, !ab.Check(v))
+		}
+	}
+	return nil
+}
+
+// CheckIntersectionCommutative checks that the intersection of a and b
+// admits the same versions as the intersection of b and a, and that they
+// admit exactly the versions admitted by both a and b. The intersection is
+// made by joining every || branch of a with every branch of b.
+func CheckIntersectionCommutative(a, b string, probes ...*semver.Version) error {
+	ca, errA := semver.NewConstraint(a)
+	cb, errB := semver.NewConstraint(b)
+	if errA != nil || errB != nil {
+		return nil
+	}
+
+	ab, err := semver.NewConstraint(intersection(ca, cb))
+	if err != nil {
+		return fmt.Errorf("the intersection of %q and %q does not parse: %s", a, b, err)
+	}
+	ba, err := semver.NewConstraint(intersection(cb, ca))
+	if err != nil {
+		return fmt.Errorf("the intersection of %q and %q does not parse: %s", b, a, err)
+	}
+	if err := disagreement(ab, ba, probes); err != nil {
+		return err
+	}
+
+	for _, v := range append(boundProbes(ca, cb), probes...) {
+		if ab.Check(v) != (ca.Check(v) && cb.Check(v)) {
+			return fmt.Errorf("%q admitting %s is %t but %q and %q is %t", ab, v, ab.Check(v), a, b, !ab.Check(v))
+		}
+	}
+	return nil
+}
+
+// CheckCompareLaws checks that Compare orders the versions consistently. A
+// version is equal to itself, swapping the versions negates the result, and
+// the ordering is transitive.
+func CheckCompareLaws(a, b, c *semver.Version) error {
+	for _, v := range []*semver.Version{a, b, c} {
+		if v.Compare(v) != 0 {
+			return fmt.Errorf("%s does not compare as equal to itself", v)
+		}
+	}
+
+	pairs := [][2]*semver.Version{{a, b}, {b, c}, {a, c}}
+	for _, p := range pairs {
+		if p[0].Compare(p[1]) != -p[1].Compare(p[0]) {
+			return fmt.Errorf("comparing %s to %s is %d but the reverse is %d", p[0], p[1], p[0].Compare(p[1]), p[1].Compare(p[0]))
+		}
+	}
+
+	vs := []*semver.Version{a, b, c}
+	for _, x := range vs {
+		for _, y := range vs {
+			for _, z := range vs {
+				if x.Compare(y) <= 0 && y.Compare(z) <= 0 && x.Compare(z) > 0 {
+					return fmt.Errorf("%s <= %s and %s <= %s but %s > %s", x, y, y, z, x, z)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// intersection renders the constraints admitting what both a and b admit.
+func intersection(a, b *semver.Constraints) string {
+	var out []string
+	for _, x := range branches(a) {
+		for _, y := range branches(b) {
+			out = append(out, x+" "+y)
+		}
+	}
+	return strings.Join(out, " || ")
+}
+
+// branches returns the rendering of each || branch of the constraints.
+func branches(c *semver.Constraints) []string {
+	e := semver.Explain(c, nil)
+	out := make([]string, len(e.Branches))
+	for k, b := range e.Branches {
+		clauses := make([]string, len(b.Clauses))
+		for i, cl := range b.Clauses {
+			clauses[i] = cl.Clause
+		}
+		out[k] = strings.Join(clauses, " ")
+	}
+	return out
+}
+
+// disagreement returns an error for the first probe, or version made from the
+// bounds of the constraints, that a and b do not agree on. When they agree on
+// every probe but Constraints.Eq reports they admit different versions an
+// error without a version is returned.
+func disagreement(a, b *semver.Constraints, extra []*semver.Version) error {
+	for _, v := range append(boundProbes(a, b), extra...) {
+		if a.Check(v) != b.Check(v) {
+			return fmt.Errorf("%q and %q disagree on %s: %t and %t", a, b, v, a.Check(v), b.Check(v))
+		}
+	}
+	if !a.Eq(b) {
+		return fmt.Errorf("%q and %q admit different versions", a, b)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package semvertest
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func TestCheckVersionRoundTrip(t *testing.T) {
+	for _, s := range VersionSeeds() {
+		if err := CheckVersionRoundTrip(s); err != nil {
+			t.Error(err)
+		}
+	}
+
+	f := func(v *semver.Version) bool {
+		return CheckVersionRoundTrip(v.Original()) == nil
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckConstraintRoundTrip(t *testing.T) {
+	for _, s := range ConstraintSeeds() {
+		if err := CheckConstraintRoundTrip(s); err != nil {
+			t.Error(err)
+		}
+	}
+
+	f := func(c *semver.Constraints, v *semver.Version) bool {
+		return CheckConstraintRoundTrip(c.String(), v) == nil
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckCommutative(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{"^1.2", "~1.4"},
+		{">=1.2, <2 || 3.x", "!=1.4.2"},
+		{"1.2 - 1.4", "^1.3.0-beta || 2"},
+		{"*", ">2, <1"},
+	}
+
+	for _, tc := range tests {
+		if err := CheckUnionCommutative(tc.a, tc.b); err != nil {
+			t.Error(err)
+		}
+		if err := CheckIntersectionCommutative(tc.a, tc.b); err != nil {
+			t.Error(err)
+		}
+	}
+
+	f := func(a, b *semver.Constraints, v *semver.Version) bool {
+		return CheckUnionCommutative(a.String(), b.String(), v) == nil &&
+			CheckIntersectionCommutative(a.String(), b.String(), v) == nil
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckCompareLaws(t *testing.T) {
+	vs := Versions(t, "1.2.3", "1.2.3-beta", "1.2.3+build")
+	if err := CheckCompareLaws(vs[0], vs[1], vs[2]); err != nil {
+		t.Error(err)
+	}
+
+	f := func(a, b, c *semver.Version) bool {
+		return CheckCompareLaws(a, b, c) == nil
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestChecksIgnoreInvalidInput(t *testing.T) {
+	if CheckVersionRoundTrip("nope") != nil || CheckConstraintRoundTrip("nope") != nil ||
+		CheckUnionCommutative("nope", "^1") != nil || CheckIntersectionCommutative("^1", "nope") != nil {
+		t.Error("expected invalid input to have nothing to check")
+	}
+}
@@ -0,0 +1,205 @@
+package semvertest
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Mutation is a corrupted variant of a valid input, for testing how code built
+// on the semver package handles input it can not parse.
+type Mutation struct {
+	// Label describes the corruption, such as "swap 1,2" or "fullwidth digit
+	// at 0". Positions are rune offsets into the original input.
+	Label string
+
+	// Input is the corrupted input.
+	Input string
+}
+
+// String returns the label and input of the mutation.
+func (m Mutation) String() string {
+	return fmt.Sprintf("%s: %q", m.Label, m.Input)
+}
+
+// MutateVersion returns corrupted variants of a version. Adjacent characters
+// are swapped, characters and dot separated segments are dropped, numbers are
+// made too large for a uint64, and unicode lookalikes and invisible characters
+// are injected.
+//
+// The variants are generated systematically rather than randomly so the same
+// version always gives the same variants in the same order. A variant is not
+// guaranteed to be invalid. For example, dropping the patch of 1.2.3 gives 1.2,
+// which semver.NewVersion accepts and semver.StrictNewVersion does not, so
+// tests decide what to expect of each variant by its label.
+func MutateVersion(s string) []Mutation {
+	m := newMutator(s)
+	m.swaps()
+	m.deletes()
+	m.segments(".")
+	m.overflows()
+	m.unicode()
+	m.truncations()
+	return m.out
+}
+
+// MutateConstraint returns corrupted variants of constraints. Along with the
+// corruptions of MutateVersion it drops space, comma, and || separated
+// clauses and doubles operators.
+func MutateConstraint(s string) []Mutation {
+	m := newMutator(s)
+	m.swaps()
+	m.deletes()
+	m.segments(" ")
+	m.segments(",")
+	m.segments("||")
+	m.operators()
+	m.overflows()
+	m.unicode()
+	m.truncations()
+	return m.out
+}
+
+// mutator collects the distinct variants of an input.
+type mutator struct {
+	orig  string
+	runes []rune
+	seen  map[string]bool
+	out   []Mutation
+}
+
+func newMutator(s string) *mutator {
+	return &mutator{
+		orig:  s,
+		runes: []rune(s),
+		seen:  map[string]bool{s: true},
+	}
+}
+
+// add records a variant unless it is the original input or a variant already
+// recorded.
+func (m *mutator) add(label, input string) {
+	if m.seen[input] {
+		return
+	}
+	m.seen[input] = true
+	m.out = append(m.out, Mutation{Label: label, Input: input})
+}
+
+// replace returns the input with the rune at i replaced by r.
+func (m *mutator) replace(i int, r string) string {
+	return string(m.runes[:i]) + r + string(m.runes[i+1:])
+}
+
+func (m *mutator) swaps() {
+	for i := 0; i+1 < len(m.runes); i++ {
+		rs := append([]rune(nil), m.runes...)
+		rs[i], rs[i+1] = rs[i+1], rs[i]
+		m.add(fmt.Sprintf("swap %d,%d", i, i+1), string(rs))
+	}
+}
+
+func (m *mutator) deletes() {
+	for i := range m.runes {
+		m.add(fmt.Sprintf("delete %d", i), m.replace(i, ""))
+	}
+}
+
+// segments drops each segment separated by sep along with its separator, and
+// doubles each separator.
+func (m *mutator) segments(sep string) {
+	parts := strings.Split(m.orig, sep)
+	if len(parts) < 2 {
+		return
+	}
+	for i := range parts {
+		rest := append(append([]string(nil), parts[:i]...), parts[i+1:]...)
+		m.add(fmt.Sprintf("drop %q segment %d", sep, i), strings.Join(rest, sep))
+	}
+	for i := 1; i < len(parts); i++ {
+		doubled := strings.Join(parts[:i], sep) + sep + sep + strings.Join(parts[i:], sep)
+		m.add(fmt.Sprintf("double %q separator %d", sep, i), doubled)
+	}
+}
+
+// overflows replaces each run of digits with a number one more than the
+// largest uint64.
+func (m *mutator) overflows() {
+	for i := 0; i < len(m.runes); {
+		if !isDigit(m.runes[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(m.runes) && isDigit(m.runes[j]) {
+			j++
+		}
+		m.add(fmt.Sprintf("overflow number at %d", i), string(m.runes[:i])+"18446744073709551616"+string(m.runes[j:]))
+		i = j
+	}
+}
+
+// unicodeDashes look like a hyphen but are not one.
+var unicodeDashes = []struct {
+	name string
+	r    string
+}{
+	{"hyphen", "‐"},
+	{"en dash", "–"},
+	{"minus sign", "−"},
+}
+
+// unicode injects characters that look like, or are invisible next to, valid
+// ones.
+func (m *mutator) unicode() {
+	for i, r := range m.runes {
+		switch {
+		case isDigit(r):
+			m.add(fmt.Sprintf("fullwidth digit at %d", i), m.replace(i, string('０'+(r-'0'))))
+			m.add(fmt.Sprintf("arabic-indic digit at %d", i), m.replace(i, string('٠'+(r-'0'))))
+		case r == '-':
+			for _, d := range unicodeDashes {
+				m.add(fmt.Sprintf("unicode %s at %d", d.name, i), m.replace(i, d.r))
+			}
+		case r == '.':
+			m.add(fmt.Sprintf("fullwidth full stop at %d", i), m.replace(i, "．"))
+		case unicode.IsLetter(r) && r < unicode.MaxASCII:
+			m.add(fmt.Sprintf("accented letter at %d", i), m.replace(i, string(r)+"́"))
+		}
+	}
+
+	for _, i := range []int{0, len(m.runes) / 2, len(m.runes)} {
+		head, tail := string(m.runes[:i]), string(m.runes[i:])
+		m.add(fmt.Sprintf("zero width space at %d", i), head+"​"+tail)
+		m.add(fmt.Sprintf("no-break space at %d", i), head+" "+tail)
+		m.add(fmt.Sprintf("nul at %d", i), head+"\x00"+tail)
+	}
+}
+
+// operators doubles each operator. Reversed operators such as => come from
+// swaps.
+func (m *mutator) operators() {
+	ops := []string{">=", "<=", "!=", "~>", ">", "<", "=", "~", "^"}
+	for i := 0; i < len(m.runes); i++ {
+		for _, op := range ops {
+			if !strings.HasPrefix(string(m.runes[i:]), op) {
+				continue
+			}
+			head, tail := string(m.runes[:i]), string(m.runes[i+len(op):])
+			m.add(fmt.Sprintf("double %s at %d", op, i), head+op+op+tail)
+			i += len(op) - 1
+			break
+		}
+	}
+}
+
+// truncations cuts the input short at each position.
+func (m *mutator) truncations() {
+	for i := len(m.runes) - 1; i >= 0; i-- {
+		m.add(fmt.Sprintf("truncate at %d", i), string(m.runes[:i]))
+	}
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,99 @@
+package semvertest
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func mutationInput(ms []Mutation, label string) (string, bool) {
+	for _, m := range ms {
+		if m.Label == label {
+			return m.Input, true
+		}
+	}
+	return "", false
+}
+
+func TestMutateVersion(t *testing.T) {
+	ms := MutateVersion("1.2.3-beta")
+
+	tests := []struct {
+		label, input string
+	}{
+		{"swap 0,1", ".12.3-beta"},
+		{"delete 1", "12.3-beta"},
+		{`drop "." segment 2`, "1.2"},
+		{`double "." separator 1`, "1..2.3-beta"},
+		{"overflow number at 2", "1.18446744073709551616.3-beta"},
+		{"fullwidth digit at 0", "１.2.3-beta"},
+		{"unicode en dash at 5", "1.2.3–beta"},
+		{"zero width space at 10", "1.2.3-beta​"},
+		{"truncate at 5", "1.2.3"},
+	}
+	for _, tc := range tests {
+		got, ok := mutationInput(ms, tc.label)
+		if !ok {
+			t.Errorf("expected a mutation labelled %q", tc.label)
+		} else if got != tc.input {
+			t.Errorf("expected %q to give %q but got %q", tc.label, tc.input, got)
+		}
+	}
+
+	seen := map[string]bool{}
+	invalid := 0
+	for _, m := range ms {
+		if m.Input == "1.2.3-beta" || seen[m.Input] {
+			t.Errorf("expected distinct variants of the input but got %s again", m)
+		}
+		seen[m.Input] = true
+		if _, err := semver.StrictNewVersion(m.Input); err != nil {
+			invalid++
+		}
+	}
+	if invalid < len(ms)/2 {
+		t.Errorf("expected most mutations to be invalid but only %d of %d are", invalid, len(ms))
+	}
+}
+
+func TestMutateConstraint(t *testing.T) {
+	ms := MutateConstraint(">=1.2, <2")
+
+	tests := []struct {
+		label, input string
+	}{
+		{`drop "," segment 1`, ">=1.2"},
+		{`drop " " segment 0`, "<2"},
+		{"double >= at 0", ">=>=1.2, <2"},
+		{"swap 0,1", "=>1.2, <2"},
+		{"double < at 7", ">=1.2, <<2"},
+		{"fullwidth full stop at 3", ">=1．2, <2"},
+	}
+	for _, tc := range tests {
+		got, ok := mutationInput(ms, tc.label)
+		if !ok {
+			t.Errorf("expected a mutation labelled %q", tc.label)
+		} else if got != tc.input {
+			t.Errorf("expected %q to give %q but got %q", tc.label, tc.input, got)
+		}
+	}
+
+	// Mutations are deterministic.
+	again := MutateConstraint(">=1.2, <2")
+	if len(again) != len(ms) {
+		t.Fatalf("expected %d mutations again but got %d", len(ms), len(again))
+	}
+	for i := range ms {
+		if ms[i] != again[i] {
+			t.Errorf("expected mutation %d to be %s but got %s", i, ms[i], again[i])
+		}
+	}
+}
+
+func TestMutateEmpty(t *testing.T) {
+	for _, m := range MutateVersion("") {
+		if m.Input == "" {
+			t.Errorf("expected no empty mutation of an empty input but got %s", m)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package semvertest
+
+import "strings"
+
+// VersionSeeds returns a corpus of tricky version strings for seeding fuzz
+// tests of code that parses versions. It holds valid versions along with near
+// misses such as unicode dashes and digits, segments too large for a uint64,
+// and leading zeros. A new slice is returned on each call so callers can
+// append their own seeds.
+func VersionSeeds() []string {
+	return []string{
+		"1.2.3",
+		"v1.2.3",
+		"1.2",
+		"1",
+		"0.0.0",
+		"1.2.3-beta.1+build.5",
+		"1.2.3-0",
+		"1.2.3-alpha.01",
+		"1.2.3+001",
+		"1.2.3-",
+		"1.2.3+",
+		"1.2.3-beta..1",
+		"01.2.3",
+		"V1.2.3",
+		"vv1.2.3",
+		" 1.2.3",
+		"1.2.3 ",
+		"1.2.3.4",
+		"18446744073709551615.18446744073709551615.18446744073709551615",
+		"18446744073709551616.0.0",
+		"1.2.3-99999999999999999999999999999999",
+		"1.2.3‐beta",
+		"1.2.3–beta",
+		"1.2.3−beta",
+		"１.２.３",
+		"١.٢.٣",
+		"1.2.3-béta",
+		"1.2.3\x00",
+		"",
+		"x",
+		"*",
+		strings.Repeat("9", 100) + ".0.0",
+		"1.2.3-" + strings.Repeat("a.", 100) + "a",
+	}
+}
+
+// ConstraintSeeds returns a corpus of tricky constraint strings for seeding
+// fuzz tests of code that parses constraints. Along with valid constraints it
+// holds malformed operators, unicode lookalikes, huge numbers, and deeply
+// nested unions and intersections. A new slice is returned on each call so
+// callers can append their own seeds.
+func ConstraintSeeds() []string {
+	return []string{
+		"^1.2.3",
+		"~1.2",
+		"~>1.2",
+		">=1.2, <2",
+		">=1.2 <2 || 3.x",
+		"1.2 - 1.4.5",
+		"1.2.x",
+		"*",
+		"!=1.2.3",
+		"^0.0.3",
+		"~0.0.0",
+		">=1.2.3-beta, <2",
+		"=>1.2 =<2",
+		"^1.2.3-alpha || ~2",
+		"",
+		" ",
+		"||",
+		"1.2.3 ||",
+		"|| 1.2.3",
+		">=",
+		"^",
+		"<<1.2.3",
+		">=1.2.3,,<2",
+		"1.2 - ",
+		"- 1.2",
+		"1.2 - 1.4 - 1.6",
+		"≥1.2.3",
+		"1.2.3 – 1.4.5",
+		"^１.２",
+		">=18446744073709551616",
+		"^" + strings.Repeat("9", 100),
+		strings.Repeat("1.2.3 || ", 100) + "1.2.3",
+		strings.Repeat(">=1.2.3, ", 100) + "<2",
+		strings.Repeat("^1 ", 100),
+	}
+}
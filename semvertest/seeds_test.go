@@ -0,0 +1,41 @@
+package semvertest
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+func TestSeeds(t *testing.T) {
+	// The corpus is meant to hold both valid and invalid inputs.
+	valid, invalid := 0, 0
+	for _, s := range VersionSeeds() {
+		if _, err := semver.NewVersion(s); err != nil {
+			invalid++
+		} else {
+			valid++
+		}
+	}
+	if valid == 0 || invalid == 0 {
+		t.Errorf("expected valid and invalid version seeds but got %d valid and %d invalid", valid, invalid)
+	}
+
+	valid, invalid = 0, 0
+	for _, s := range ConstraintSeeds() {
+		if _, err := semver.NewConstraint(s); err != nil {
+			invalid++
+		} else {
+			valid++
+		}
+	}
+	if valid == 0 || invalid == 0 {
+		t.Errorf("expected valid and invalid constraint seeds but got %d valid and %d invalid", valid, invalid)
+	}
+
+	// Callers can append without changing the corpus seen by others.
+	a := VersionSeeds()
+	a[0] = "changed"
+	if VersionSeeds()[0] == "changed" {
+		t.Error("expected a new slice on each call")
+	}
+}
@@ -0,0 +1,134 @@
+// Package semvertest provides helpers for tests of code built on the semver
+// package. The fixture builders parse versions and constraints, failing the
+// test on invalid input, and the Require functions fail the test when a
+// version or constraint does not behave as expected.
+package semvertest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// Version parses a version, failing the test when it is invalid.
+func Version(t testing.TB, v string) *semver.Version {
+	t.Helper()
+	sv, err := semver.NewVersion(v)
+	if err != nil {
+		t.Fatalf("invalid version %q: %s", v, err)
+	}
+	return sv
+}
+
+// Versions parses each of the versions, failing the test when any is
+// invalid.
+func Versions(t testing.TB, vs ...string) []*semver.Version {
+	t.Helper()
+	out := make([]*semver.Version, len(vs))
+	for k, v := range vs {
+		out[k] = Version(t, v)
+	}
+	return out
+}
+
+// Constraint parses constraints, failing the test when they are invalid.
+func Constraint(t testing.TB, c string) *semver.Constraints {
+	t.Helper()
+	sc, err := semver.NewConstraint(c)
+	if err != nil {
+		t.Fatalf("invalid constraint %q: %s", c, err)
+	}
+	return sc
+}
+
+// RequireSatisfies fails the test when v does not satisfy c. The failure
+// includes the reasons reported by Constraints.Validate.
+func RequireSatisfies(t testing.TB, v *semver.Version, c *semver.Constraints) {
+	t.Helper()
+	if ok, errs := c.Validate(v); !ok {
+		reasons := make([]string, len(errs))
+		for k, e := range errs {
+			reasons[k] = e.Error()
+		}
+		t.Fatalf("expected %s to satisfy %q: %s", v, c, strings.Join(reasons, "; "))
+	}
+}
+
+// RequireNotSatisfies fails the test when v satisfies c.
+func RequireNotSatisfies(t testing.TB, v *semver.Version, c *semver.Constraints) {
+	t.Helper()
+	if c.Check(v) {
+		t.Fatalf("expected %s not to satisfy %q", v, c)
+	}
+}
+
+// RequireSorted fails the test when the versions are not in ascending order.
+// Versions that compare as equal, such as 1.2.3 and 1.2.3+build, may be in
+// either order.
+func RequireSorted(t testing.TB, vs []*semver.Version) {
+	t.Helper()
+	for k := 1; k < len(vs); k++ {
+		if vs[k].LessThan(vs[k-1]) {
+			t.Fatalf("expected versions to be sorted but %s at index %d comes after %s", vs[k], k, vs[k-1])
+		}
+	}
+}
+
+// RequireEquivalentConstraints fails the test when a and b do not admit the
+// same versions, as reported by Constraints.Eq. The failure names a version
+// they disagree on when one is found among probe versions made from the
+// bounds of the ranges either of them admits, their neighbors, and any extra
+// versions passed in. The extra versions are checked even when a and b are
+// equal. As with Eq, constraints relying on MetadataEqual, MetadataOrdered,
+// or LegacyPrereleaseOrder are only equivalent when written with the same
+// clauses.
+func RequireEquivalentConstraints(t testing.TB, a, b *semver.Constraints, extra ...*semver.Version) {
+	t.Helper()
+	if err := disagreement(a, b, extra); err != nil {
+		t.Fatalf("expected equivalent constraints but %s", err)
+	}
+}
+
+// boundProbes returns the versions bounding the ranges admitted by the
+// constraints and the versions next to them.
+func boundProbes(cs ...*semver.Constraints) []*semver.Version {
+	var out []*semver.Version
+	add := func(major, minor, patch uint64, pre string) {
+		s := fmt.Sprintf("%d.%d.%d", major, minor, patch)
+		if pre != "" {
+			s += "-" + pre
+		}
+		out = append(out, semver.MustParse(s))
+	}
+
+	for _, c := range cs {
+		for _, b := range semver.Explain(c, nil).Branches {
+			var ranges []string
+			ranges = append(ranges, b.Releases...)
+			ranges = append(ranges, b.Prereleases...)
+			ranges = append(ranges, b.Exclusions...)
+			for _, r := range ranges {
+				for _, f := range strings.Fields(r) {
+					v, err := semver.NewVersion(strings.TrimLeft(f, "<>="))
+					if err != nil {
+						continue
+					}
+					out = append(out, v)
+					maj, min, pat := v.Major(), v.Minor(), v.Patch()
+					add(maj, min, pat, "")
+					add(maj, min, pat, "0")
+					add(maj, min, pat+1, "")
+					add(maj, min+1, 0, "")
+					add(maj+1, 0, 0, "")
+					if pat > 0 {
+						add(maj, min, pat-1, "")
+					}
+				}
+			}
+		}
+	}
+	add(0, 0, 0, "")
+	return out
+}
@@ -0,0 +1,121 @@
+package semvertest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jesseduffield/semver/v3"
+)
+
+// recorder is a testing.TB that records a failure instead of stopping the
+// test.
+type recorder struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.failed = true
+	r.message = fmt.Sprintf(format, args...)
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.Fatalf(format, args...)
+}
+
+func TestFixtures(t *testing.T) {
+	vs := Versions(t, "1.2.3", "v2")
+	if vs[0].String() != "1.2.3" || vs[1].String() != "2.0.0" {
+		t.Errorf("unexpected versions %s", vs)
+	}
+	if c := Constraint(t, "^1.2"); c.String() != "^1.2" {
+		t.Errorf("unexpected constraint %s", c)
+	}
+
+	r := &recorder{TB: t}
+	Version(r, "nope")
+	if !r.failed {
+		t.Error("expected an invalid version to fail")
+	}
+	r = &recorder{TB: t}
+	Constraint(r, "nope")
+	if !r.failed {
+		t.Error("expected an invalid constraint to fail")
+	}
+}
+
+func TestRequireSatisfies(t *testing.T) {
+	c := Constraint(t, "^1.2")
+	RequireSatisfies(t, Version(t, "1.4.0"), c)
+	RequireNotSatisfies(t, Version(t, "2.0.0"), c)
+
+	r := &recorder{TB: t}
+	RequireSatisfies(r, Version(t, "2.0.0"), c)
+	if !r.failed || r.message != `expected 2.0.0 to satisfy "^1.2": 2.0.0 does not have same major version as 1.2` {
+		t.Errorf("unexpected failure %q", r.message)
+	}
+
+	r = &recorder{TB: t}
+	RequireNotSatisfies(r, Version(t, "1.4.0"), c)
+	if !r.failed {
+		t.Error("expected a satisfying version to fail")
+	}
+}
+
+func TestRequireSorted(t *testing.T) {
+	RequireSorted(t, Versions(t, "1.0.0", "1.2.3-beta", "1.2.3", "1.2.3+build", "1.10.0"))
+	RequireSorted(t, nil)
+
+	r := &recorder{TB: t}
+	RequireSorted(r, Versions(t, "1.0.0", "1.10.0", "1.2.0"))
+	if !r.failed || r.message != "expected versions to be sorted but 1.2.0 at index 2 comes after 1.10.0" {
+		t.Errorf("unexpected failure %q", r.message)
+	}
+}
+
+func TestRequireEquivalentConstraints(t *testing.T) {
+	tests := []struct {
+		a, b       string
+		equivalent bool
+	}{
+		{"^1.2", ">=1.2.0, <2.0.0", true},
+		{"~1.2.3", ">=1.2.3, <1.3", true},
+		{"1.2 - 1.4", ">=1.2, <1.5", true},
+		{"^1.2", ">=1.2.0, <=2.0.0", false},
+		{">=1.2, !=1.4.2", ">=1.2", false},
+		{"^1.2.3-beta", "^1.2.3", false},
+		{"~1.2 || ~1.3", ">=1.2, <1.4", true},
+	}
+
+	for _, tc := range tests {
+		r := &recorder{TB: t}
+		RequireEquivalentConstraints(r, Constraint(t, tc.a), Constraint(t, tc.b))
+		if r.failed == tc.equivalent {
+			t.Errorf("expected %q and %q equivalent to be %t: %s", tc.a, tc.b, tc.equivalent, r.message)
+		}
+	}
+
+	// Constraints relying on the metadata of versions are only equivalent
+	// when written the same way.
+	opts := semver.MatchOptions{Metadata: semver.MetadataEqual}
+	a, _ := semver.NewConstraintWithOptions("=1.2.3+abc", opts)
+	b, _ := semver.NewConstraintWithOptions("=1.2.3+def", opts)
+	r := &recorder{TB: t}
+	if RequireEquivalentConstraints(r, a, b); !r.failed {
+		t.Error("expected =1.2.3+abc and =1.2.3+def with MetadataEqual not to be equivalent")
+	}
+	r = &recorder{TB: t}
+	if RequireEquivalentConstraints(r, a, a); r.failed {
+		t.Errorf("unexpected failure %q", r.message)
+	}
+
+	// Extra versions are checked as well.
+	r = &recorder{TB: t}
+	RequireEquivalentConstraints(r, Constraint(t, "*"), Constraint(t, "*"), semver.MustParse("1.2.3"))
+	if r.failed {
+		t.Errorf("unexpected failure %q", r.message)
+	}
+}
@@ -0,0 +1,55 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Series returns the minor series a version belongs to, such as "1.4" for
+// 1.4.2. A prerelease belongs to the series of the release it precedes, so
+// 1.5.0-rc.1 is in "1.5". Versions before 1.0.0 follow the same rule, with
+// 0.4.2 in "0.4", as breaking changes there bump the minor version and each
+// minor series is a line of its own.
+func Series(v *Version) string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+// InSeries reports if a version belongs to a series. The series is a major
+// and minor version such as "1.4", matching the result of Series, or a major
+// version alone such as "1" for the whole major series. A leading v and a
+// trailing .x or .* are allowed, as in "v1.4.x". An invalid series matches no
+// version.
+func InSeries(v *Version, series string) bool {
+	major, minor, whole, ok := parseSeries(series)
+	if !ok || v.major != major {
+		return false
+	}
+	return whole || v.minor == minor
+}
+
+// parseSeries parses a series as accepted by InSeries. whole is true when the
+// series is a major version alone.
+func parseSeries(series string) (major, minor uint64, whole, ok bool) {
+	series = strings.TrimPrefix(series, "v")
+	series = strings.TrimSuffix(series, ".x")
+	series = strings.TrimSuffix(series, ".X")
+	series = strings.TrimSuffix(series, ".*")
+
+	parts := strings.Split(series, ".")
+	if len(parts) > 2 {
+		return 0, 0, false, false
+	}
+	var n [2]uint64
+	for k, p := range parts {
+		if p == "" || !containsOnly(p, num) || (len(p) > 1 && p[0] == '0') {
+			return 0, 0, false, false
+		}
+		var err error
+		n[k], err = strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return 0, 0, false, false
+		}
+	}
+	return n[0], n[1], len(parts) == 1, true
+}
@@ -0,0 +1,56 @@
+package semver
+
+import "testing"
+
+func TestSeries(t *testing.T) {
+	tests := []struct {
+		version, series string
+	}{
+		{"1.4.2", "1.4"},
+		{"v1.4.2+build", "1.4"},
+		{"1.5.0-rc.1", "1.5"},
+		{"0.4.2", "0.4"},
+		{"0.0.3", "0.0"},
+		{"10.20.30", "10.20"},
+	}
+
+	for _, tc := range tests {
+		if got := Series(MustParse(tc.version)); got != tc.series {
+			t.Errorf("expected the series of %s to be %s but got %s", tc.version, tc.series, got)
+		}
+		if !InSeries(MustParse(tc.version), tc.series) {
+			t.Errorf("expected %s to be in its own series %s", tc.version, tc.series)
+		}
+	}
+}
+
+func TestInSeries(t *testing.T) {
+	tests := []struct {
+		version, series string
+		expected        bool
+	}{
+		{"1.4.2", "1.4", true},
+		{"1.4.2", "v1.4", true},
+		{"1.4.2", "1.4.x", true},
+		{"1.4.2", "1.4.*", true},
+		{"1.4.2", "1", true},
+		{"1.4.2", "1.x", true},
+		{"1.5.0-rc.1", "1.5", true},
+		{"1.5.0-rc.1", "1.4", false},
+		{"1.4.2", "1.5", false},
+		{"2.4.2", "1.4", false},
+		{"0.4.2", "0.4", true},
+		{"0.4.2", "0.5", false},
+		{"1.4.2", "1.4.2", false},
+		{"1.4.2", "", false},
+		{"1.4.2", "1.04", false},
+		{"1.4.2", "one.four", false},
+		{"1.4.2", "1..4", false},
+	}
+
+	for _, tc := range tests {
+		if got := InSeries(MustParse(tc.version), tc.series); got != tc.expected {
+			t.Errorf("expected %s in series %q to be %t", tc.version, tc.series, tc.expected)
+		}
+	}
+}
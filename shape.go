@@ -0,0 +1,60 @@
+package semver
+
+// Shape counts the parts of constraints, for capping the complexity of
+// constraints or choosing an algorithm based on their form.
+type Shape struct {
+	// Branches is the number of || separated groups as written.
+	Branches int
+
+	// Clauses is the number of clauses as written, across all groups.
+	Clauses int
+
+	// Ranges is the number of contiguous ranges of versions admitted, those
+	// returned by Ranges and PrereleaseRanges.
+	Ranges int
+
+	// Exclusions is the number of single versions excluded from within the
+	// ranges.
+	Exclusions int
+}
+
+// Shape returns the counts of the parts of the constraints.
+func (cs Constraints) Shape() Shape {
+	s := Shape{Branches: len(cs.constraints)}
+	for _, group := range cs.constraints {
+		s.Clauses += len(group)
+	}
+	for _, rs := range [][]*Range{cs.Ranges(), cs.PrereleaseRanges()} {
+		s.Ranges += len(rs)
+		for _, r := range rs {
+			s.Exclusions += len(r.excluded)
+		}
+	}
+	return s
+}
+
+// Size returns the number of ranges and exclusions making up the versions the
+// constraints admit. It measures what the constraints admit rather than how
+// they are written, so `^1.2` and `>=1.2, <2, >=1.0` have the same size.
+func (cs Constraints) Size() int {
+	s := cs.Shape()
+	return s.Ranges + s.Exclusions
+}
+
+// Depth returns the depth of the constraints as written, ignoring levels
+// holding a single item. It is 1 for a single clause, 2 for several clauses
+// joined only by AND or only by ||, and 3 when || joins groups of several AND
+// clauses.
+func (cs Constraints) Depth() int {
+	d := 1
+	for _, group := range cs.constraints {
+		if len(group) > 1 {
+			d = 2
+			break
+		}
+	}
+	if len(cs.constraints) > 1 {
+		d++
+	}
+	return d
+}
@@ -0,0 +1,37 @@
+package semver
+
+import "testing"
+
+func TestShape(t *testing.T) {
+	tests := []struct {
+		constraint string
+		shape      Shape
+		size       int
+		depth      int
+	}{
+		{"^1.2", Shape{Branches: 1, Clauses: 1, Ranges: 1}, 1, 1},
+		{">=1.2, <2, >=1.0", Shape{Branches: 1, Clauses: 3, Ranges: 1}, 1, 2},
+		{"^1.2, !=1.4.2", Shape{Branches: 1, Clauses: 2, Ranges: 1, Exclusions: 1}, 2, 2},
+		{"~1.2 || ~1.4", Shape{Branches: 2, Clauses: 2, Ranges: 2}, 2, 2},
+		{"~1.2 || ~1.3", Shape{Branches: 2, Clauses: 2, Ranges: 1}, 1, 2},
+		{">=1.2, <2 || >=3.0.0-0", Shape{Branches: 2, Clauses: 3, Ranges: 3}, 3, 3},
+		{">2, <1", Shape{Branches: 1, Clauses: 2}, 0, 2},
+	}
+
+	for _, tc := range tests {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", tc.constraint, err)
+		}
+
+		if got := c.Shape(); got != tc.shape {
+			t.Errorf("expected %q to have shape %+v but got %+v", tc.constraint, tc.shape, got)
+		}
+		if got := c.Size(); got != tc.size {
+			t.Errorf("expected %q to have size %d but got %d", tc.constraint, tc.size, got)
+		}
+		if got := c.Depth(); got != tc.depth {
+			t.Errorf("expected %q to have depth %d but got %d", tc.constraint, tc.depth, got)
+		}
+	}
+}
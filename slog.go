@@ -0,0 +1,27 @@
+//go:build go1.21
+// +build go1.21
+
+package semver
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer so structured logs record a version as a
+// group of its major, minor, and patch numbers and its prerelease, allowing
+// queries such as all logs with a major version of 2.
+func (v Version) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("major", v.major),
+		slog.Uint64("minor", v.minor),
+		slog.Uint64("patch", v.patch),
+		slog.String("prerelease", v.Prerelease()),
+	)
+}
+
+// LogValue implements slog.LogValuer so structured logs record constraints as
+// a group of their rendering and the number of || branches.
+func (cs Constraints) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("constraint", cs.String()),
+		slog.Int("branches", len(cs.constraints)),
+	)
+}
@@ -0,0 +1,40 @@
+//go:build go1.21
+// +build go1.21
+
+package semver
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	c, err := NewConstraint("^1.2 || >=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("resolved", "version", MustParse("v2.3.4-beta.1+build"), "constraint", c)
+
+	expected := "level=INFO msg=resolved version.major=2 version.minor=3 version.patch=4 version.prerelease=beta.1 constraint.constraint=\"^1.2 || >=3\" constraint.branches=2\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("expected the log line %q but got %q", expected, got)
+	}
+
+	buf.Reset()
+	logger.Info("resolved", "version", *MustParse("1.0.0"))
+	if got := buf.String(); !strings.Contains(got, "version.major=1 ") {
+		t.Errorf("expected a Version value to log as a group but got %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package semver
+
+// SplitAt partitions the versions the constraints admit around v, such as
+// when one policy applies below a migration version and another from it on.
+// below admits the versions c admits that are less than v and atAndAbove
+// those that are v or greater. Both keep the options of c, and branches of c
+// left with no versions on a side are dropped from it. Nil constraints
+// admit no versions, and every version is above a nil v.
+//
+// Under the default PrereleaseExplicit policy a bound only lets prereleases
+// through when it names one, so when v is a release the split is made just
+// below its prereleases. For example, >=1.0.0-0 split at 1.5.0 puts
+// 1.5.0-beta in atAndAbove. Under the other policies the split is exactly at
+// v.
+func SplitAt(c *Constraints, v *Version) (below, atAndAbove *Constraints) {
+	c = orNone(c, nil)
+	below, atAndAbove = &Constraints{opts: c.opts}, &Constraints{opts: c.opts}
+	if v == nil {
+		return below, c
+	}
+
+	for _, d := range Disjuncts(c) {
+		// The bounds are read with the options of the branch. A branch
+		// combined from parts with different options is split exactly at v
+		// by bounds that admit prereleases alike.
+		opts, b := MatchOptions{Prerelease: PrereleaseInclude}, v
+		if o, ok := sharedOptions(d.constraints[0], &c.opts); ok {
+			opts, b = *o, splitBound(d.constraints[0], v, o.Prerelease)
+		}
+		lt, _ := newConstraintWithOptions("<"+b.String(), opts)
+		ge, _ := newConstraintWithOptions(">="+b.String(), opts)
+		// The bounds join the scope of the branch so they do not stop the
+		// prereleases it names.
+		below = unionNonEmpty(below, intersect(d, lt, false))
+		atAndAbove = unionNonEmpty(atAndAbove, intersect(d, ge, false))
+	}
+	return below, atAndAbove
+}
+
+// unionNonEmpty returns the union of a and b, leaving b out when it admits no
+// versions, such as the part of ^1.2 at or above 2.0.0.
+func unionNonEmpty(a, b *Constraints) *Constraints {
+	if may, _ := b.sets(); may.empty() {
+		return a
+	}
+	return Union(a, b)
+}
+
+// sharedOptions returns the options every clause of an AND group is checked
+// with, or false when its clauses have different options.
+func sharedOptions(group []*constraint, o *MatchOptions) (*MatchOptions, bool) {
+	parts := groupParts(group, o, true)
+	return parts[0].opts, len(parts) == 1
+}
+
+// splitBound returns the version to split an AND group at so the bounds
+// added to it do not admit prereleases the group does not.
+func splitBound(group []*constraint, v *Version, policy PrereleasePolicy) *Version {
+	switch {
+	case policy == PrereleaseInclude:
+		return v
+	case v.Prerelease() == "" && policy == PrereleaseExplicit:
+		return newLowestVersion(v.major, v.minor, v.patch)
+	case v.Prerelease() == "" || policy == PrereleaseExplicit:
+		return v
+	}
+
+	// A scoped prerelease bound would admit the prereleases of its release
+	// when the group does not name one of them. The group then admits none of
+	// them, so splitting at the release divides the group the same way.
+	for _, c := range group {
+		if c.con.Prerelease() != "" && c.con.major == v.major && c.con.minor == v.minor && c.con.patch == v.patch {
+			return v
+		}
+	}
+	return newCoreVersion(v.major, v.minor, v.patch)
+}
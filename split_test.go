@@ -0,0 +1,119 @@
+package semver
+
+import "testing"
+
+func TestSplitAt(t *testing.T) {
+	tests := []struct {
+		constraint string
+		at         string
+		below      string
+		above      string
+	}{
+		{"^1.2", "1.5.0", "^1.2 <1.5.0-0", "^1.2 >=1.5.0-0"},
+		{"^1.2 || ^2", "2.1.0", "^1.2 <2.1.0-0 || ^2 <2.1.0-0", "^2 >=2.1.0-0"},
+		{"^1.2", "3.0.0", "^1.2 <3.0.0-0", ""},
+		{">=1.0.0-0", "1.5.0-rc.1", ">=1.0.0-0 <1.5.0-rc.1", ">=1.0.0-0 >=1.5.0-rc.1"},
+	}
+
+	for _, tc := range tests {
+		below, above := SplitAt(mustConstraint(t, tc.constraint), MustParse(tc.at))
+		if below.String() != tc.below || above.String() != tc.above {
+			t.Errorf("%s at %s: expected %q and %q but got %q and %q", tc.constraint, tc.at, tc.below, tc.above, below, above)
+		}
+	}
+}
+
+func TestSplitAtPartitions(t *testing.T) {
+	constraints := []string{"^1.2", "^1.2 || ^2", ">=1.0.0-0", ">=1.2.3-beta <2", "~1.5.0-rc.1", "!=1.5.0", "*", "1.5.0 - 1.6.0-rc.2"}
+	points := []string{"1.5.0", "1.5.0-rc.1", "1.5.0-rc.2", "1.2.3", "0.1.0", "3.0.0"}
+	versions := []string{
+		"0.1.0", "1.0.0-alpha", "1.2.3-beta", "1.2.3", "1.4.9", "1.5.0-alpha", "1.5.0-rc.1", "1.5.0-rc.2",
+		"1.5.0", "1.5.1-beta", "1.5.1", "1.6.0-rc.1", "1.6.0-rc.3", "2.0.0", "2.3.0-rc.1", "2.9.9",
+	}
+
+	for _, policy := range []PrereleasePolicy{PrereleaseExplicit, PrereleaseScoped, PrereleaseInclude} {
+		for _, cs := range constraints {
+			c, err := NewConstraintWithOptions(cs, MatchOptions{Prerelease: policy})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range points {
+				at := MustParse(p)
+				below, above := SplitAt(c, at)
+				if below.Options() != c.Options() || above.Options() != c.Options() {
+					t.Errorf("expected the options of %s to be kept", cs)
+				}
+				for _, s := range versions {
+					v := MustParse(s)
+					b, a := below.Check(v), above.Check(v)
+					if b && a {
+						t.Errorf("policy %d: %s split at %s admits %s on both sides", policy, cs, p, s)
+					}
+					if (b || a) != c.Check(v) {
+						t.Errorf("policy %d: %s split at %s: expected %s to be admitted %t but got %t", policy, cs, p, s, c.Check(v), b || a)
+					}
+					exact := policy != PrereleaseExplicit || at.Prerelease() != "" || v.Prerelease() == "" ||
+						v.major != at.major || v.minor != at.minor || v.patch != at.patch
+					if exact && b && !v.LessThan(at) {
+						t.Errorf("policy %d: %s split at %s: expected %s not to be below", policy, cs, p, s)
+					}
+					if exact && a && v.LessThan(at) {
+						t.Errorf("policy %d: %s split at %s: expected %s not to be at or above", policy, cs, p, s)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSplitAtMixedOptions(t *testing.T) {
+	include, err := NewConstraintWithOptions(">=1.0.0-0 <2", MatchOptions{Prerelease: PrereleaseInclude})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scoped, err := NewConstraintWithOptions(">=1.5.0-rc.1 <3", MatchOptions{Prerelease: PrereleaseScoped})
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := []string{"0.1.0", "1.2.3-beta", "1.4.9", "1.5.0-alpha", "1.5.0-rc.2", "1.5.0", "1.6.0-rc.1", "2.0.0", "2.3.0-rc.1", "2.9.9"}
+
+	for _, c := range []*Constraints{Union(include, scoped), Intersect(include, scoped), Union(Intersect(include, mustConstraint(t, "^1.2")), scoped)} {
+		for _, p := range []string{"1.5.0", "1.5.0-rc.2", "2.0.0"} {
+			below, above := SplitAt(c, MustParse(p))
+			for _, s := range versions {
+				v := MustParse(s)
+				b, a := below.Check(v), above.Check(v)
+				if b && a || (b || a) != c.Check(v) {
+					t.Errorf("%s split at %s: expected %s to be admitted %t on one side but got %t and %t", c, p, s, c.Check(v), b, a)
+				}
+			}
+		}
+	}
+}
+
+func TestSplitAtNil(t *testing.T) {
+	c := mustConstraint(t, "^1.2")
+	below, above := SplitAt(c, nil)
+	if below.Check(MustParse("1.4.0")) || above != c {
+		t.Errorf("expected every version to be above a nil version but got %q and %q", below, above)
+	}
+
+	below, above = SplitAt(nil, MustParse("1.4.0"))
+	if below.Check(MustParse("1.0.0")) || above.Check(MustParse("2.0.0")) {
+		t.Error("expected nil constraints to split into constraints admitting nothing")
+	}
+}
+
+func TestSplitAtPrecedenceOptions(t *testing.T) {
+	c, err := NewConstraintWithOptions("=1.2.3+abc, !=1.2.3+def || ^2", MatchOptions{Metadata: MetadataEqual})
+	if err != nil {
+		t.Fatal(err)
+	}
+	below, above := SplitAt(c, MustParse("2.0.0"))
+	if v := MustParse("1.2.3+abc"); !below.Check(v) || above.Check(v) {
+		t.Errorf("expected %s to be below 2.0.0 in %q and %q", v, below, above)
+	}
+	if v := MustParse("2.1.0"); below.Check(v) || !above.Check(v) {
+		t.Errorf("expected %s to be above 2.0.0 in %q and %q", v, below, above)
+	}
+}
@@ -0,0 +1,139 @@
+package semver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// StreamOpts controls how FilterStream reads and writes versions. The zero
+// value writes the versions satisfying the constraints and counts the lines
+// that are not versions without stopping.
+type StreamOpts struct {
+	// Invert writes the versions not satisfying the constraints instead, as
+	// grep -v does.
+	Invert bool
+
+	// Strict stops at the first line that is not a version, returning a
+	// *StreamError for it.
+	Strict bool
+
+	// Invalid is written the lines that are not versions, one per line. When
+	// nil they are dropped.
+	Invalid io.Writer
+
+	// MaxLineLength is the length of the longest line read. A longer line
+	// stops the filter with bufio.ErrTooLong. The default is
+	// bufio.MaxScanTokenSize.
+	MaxLineLength int
+}
+
+// Stats counts the lines read by FilterStream. Blank lines are not counted.
+type Stats struct {
+	// Matched is the number of versions satisfying the constraints.
+	Matched int
+
+	// Skipped is the number of versions not satisfying the constraints.
+	Skipped int
+
+	// Invalid is the number of lines that are not versions.
+	Invalid int
+}
+
+// StreamError is returned by FilterStream for a line that is not a version
+// when StreamOpts.Strict is set.
+type StreamError struct {
+	Line int
+	Text string
+	Err  error
+}
+
+// Error returns the error along with the line and its number.
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("%s on line %d: %s", e.Text, e.Line, e.Err)
+}
+
+// Unwrap returns the error parsing the line, such as ErrInvalidSemVer.
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// FilterStream reads versions from r, one per line, and writes those
+// satisfying the constraints to w in the same way, for filtering files too
+// large to hold in memory. Surrounding whitespace is trimmed from each line,
+// blank lines are ignored, and the versions are written as they were read.
+// The counts are returned along with the first error reading r or writing to
+// w, and are the counts up to the error when there is one. A nil c admits no
+// versions.
+func FilterStream(c *Constraints, r io.Reader, w io.Writer, opts StreamOpts) (Stats, error) {
+	c = orNone(c, nil)
+	var stats Stats
+
+	s := bufio.NewScanner(r)
+	if opts.MaxLineLength > 0 {
+		// The scanner allows lines as long as the buffer it is given, so the
+		// buffer cannot start larger than the limit.
+		size := 4096
+		if opts.MaxLineLength < size {
+			size = opts.MaxLineLength
+		}
+		s.Buffer(make([]byte, 0, size), opts.MaxLineLength)
+	}
+	out := bufio.NewWriter(w)
+	var invalid *bufio.Writer
+	if opts.Invalid != nil {
+		invalid = bufio.NewWriter(opts.Invalid)
+	}
+
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+
+		v, err := NewVersion(line)
+		if err != nil {
+			stats.Invalid++
+			if opts.Strict {
+				return stats, flushStream(&StreamError{Line: n, Text: line, Err: err}, out, invalid)
+			}
+			if invalid != nil {
+				if _, err := invalid.WriteString(line + "\n"); err != nil {
+					return stats, err
+				}
+			}
+			continue
+		}
+
+		if !c.Check(v) {
+			stats.Skipped++
+			if !opts.Invert {
+				continue
+			}
+		} else {
+			stats.Matched++
+			if opts.Invert {
+				continue
+			}
+		}
+		if _, err := out.WriteString(line + "\n"); err != nil {
+			return stats, err
+		}
+	}
+	return stats, flushStream(s.Err(), out, invalid)
+}
+
+// flushStream flushes the writers, returning err when it is not nil and
+// otherwise the first error flushing.
+func flushStream(err error, out, invalid *bufio.Writer) error {
+	if ferr := out.Flush(); err == nil {
+		err = ferr
+	}
+	if invalid != nil {
+		if ferr := invalid.Flush(); err == nil {
+			err = ferr
+		}
+	}
+	return err
+}
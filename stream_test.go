@@ -0,0 +1,97 @@
+package semver
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const streamInput = `1.2.3
+  v1.4.0
+
+2.0.0
+banana
+1.5.0-rc.1
+1.9.9
+not.a.version
+`
+
+func TestFilterStream(t *testing.T) {
+	c := mustConstraint(t, "^1.2")
+	var out, invalid bytes.Buffer
+	stats, err := FilterStream(c, strings.NewReader(streamInput), &out, StreamOpts{Invalid: &invalid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "1.2.3\nv1.4.0\n1.9.9\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+	if invalid.String() != "banana\nnot.a.version\n" {
+		t.Errorf("unexpected invalid lines %q", invalid.String())
+	}
+	if stats != (Stats{Matched: 3, Skipped: 2, Invalid: 2}) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestFilterStreamInvert(t *testing.T) {
+	var out bytes.Buffer
+	stats, err := FilterStream(mustConstraint(t, "^1.2"), strings.NewReader(streamInput), &out, StreamOpts{Invert: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "2.0.0\n1.5.0-rc.1\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+	if stats != (Stats{Matched: 3, Skipped: 2, Invalid: 2}) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestFilterStreamStrict(t *testing.T) {
+	var out bytes.Buffer
+	stats, err := FilterStream(mustConstraint(t, "^1.2"), strings.NewReader(streamInput), &out, StreamOpts{Strict: true})
+
+	se, ok := err.(*StreamError)
+	if !ok || se.Line != 5 || se.Text != "banana" || se.Err != ErrInvalidSemVer {
+		t.Fatalf("expected a *StreamError for line 5 but got %v", err)
+	}
+	if out.String() != "1.2.3\nv1.4.0\n" {
+		t.Errorf("expected the versions before the error to be written but got %q", out.String())
+	}
+	if stats != (Stats{Matched: 2, Skipped: 1, Invalid: 1}) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestFilterStreamErrors(t *testing.T) {
+	long := "1.2.3-" + strings.Repeat("a", 100) + "\n"
+	_, err := FilterStream(Any(), strings.NewReader(long), &bytes.Buffer{}, StreamOpts{MaxLineLength: 50})
+	if err != bufio.ErrTooLong {
+		t.Errorf("expected bufio.ErrTooLong but got %v", err)
+	}
+
+	if _, err := FilterStream(Any(), strings.NewReader(long), &bytes.Buffer{}, StreamOpts{}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	werr := errors.New("closed")
+	if _, err := FilterStream(Any(), strings.NewReader("1.0.0\n"), failingWriter{werr}, StreamOpts{}); err != werr {
+		t.Errorf("expected the write error but got %v", err)
+	}
+
+	stats, err := FilterStream(nil, strings.NewReader("1.0.0\n"), &bytes.Buffer{}, StreamOpts{})
+	if err != nil || stats != (Stats{Skipped: 1}) {
+		t.Errorf("expected nil constraints to admit nothing but got %+v %v", stats, err)
+	}
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,112 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Violation is a way a string breaks the SemVer 2.0.0 grammar, found by
+// ValidateStrict.
+type Violation struct {
+	// Offset is the byte offset in the string the violation starts at.
+	Offset int
+
+	// Reason describes the violation, such as `minor version "01" has a
+	// leading zero`.
+	Reason string
+}
+
+// String returns the violation along with its offset.
+func (v Violation) String() string {
+	return fmt.Sprintf("offset %d: %s", v.Offset, v.Reason)
+}
+
+// ValidateStrict certifies a string is a version written exactly as the
+// SemVer 2.0.0 specification allows, returning every violation found or nil
+// when there are none. Unlike NewVersion it accepts no v prefix, wildcards,
+// left off minor or patch versions, or surrounding whitespace, and unlike
+// StrictNewVersion it reports all the violations rather than the first and
+// rejects an empty prerelease or metadata, as in 1.2.3-. Numbers too large
+// for a uint64 are reported as well, as the version could not be parsed.
+func ValidateStrict(s string) []Violation {
+	if s == "" {
+		return []Violation{{0, "version is empty"}}
+	}
+
+	var out []Violation
+	add := func(offset int, format string, args ...interface{}) {
+		out = append(out, Violation{offset, fmt.Sprintf(format, args...)})
+	}
+
+	core, pre, metadata := s, "", ""
+	preAt, metaAt := -1, -1
+	if i := strings.IndexByte(core, '+'); i >= 0 {
+		core, metadata, metaAt = core[:i], core[i+1:], i+1
+	}
+	if i := strings.IndexByte(core, '-'); i >= 0 {
+		core, pre, preAt = core[:i], core[i+1:], i+1
+	}
+
+	offset := 0
+	if core != "" && (core[0] == 'v' || core[0] == 'V') {
+		add(0, "the %c prefix is not allowed", core[0])
+		core, offset = core[1:], 1
+	}
+
+	names := []string{"major", "minor", "patch"}
+	segments := strings.Split(core, ".")
+	for k, seg := range segments {
+		if k == len(names) {
+			add(offset, "only major, minor, and patch versions are allowed")
+			break
+		}
+		name := names[k]
+		switch {
+		case seg == "":
+			add(offset, "%s version is empty", name)
+		case isX(seg):
+			add(offset, "%s version is a wildcard", name)
+		case !containsOnly(seg, num):
+			add(offset, "%s version %q is not a number", name, seg)
+		case len(seg) > 1 && seg[0] == '0':
+			add(offset, "%s version %q has a leading zero", name, seg)
+		default:
+			if _, err := strconv.ParseUint(seg, 10, 64); err != nil {
+				add(offset, "%s version %q is too large", name, seg)
+			}
+		}
+		offset += len(seg) + 1
+	}
+	if len(segments) < len(names) {
+		add(offset-1, "%s version is missing", names[len(segments)])
+	}
+
+	if preAt >= 0 {
+		validateStrictIdentifiers(pre, preAt, "prerelease", true, add)
+	}
+	if metaAt >= 0 {
+		validateStrictIdentifiers(metadata, metaAt, "metadata", false, add)
+	}
+	return out
+}
+
+// validateStrictIdentifiers reports the violations in the dot separated
+// identifiers of a prerelease or metadata starting at offset.
+func validateStrictIdentifiers(s string, offset int, name string, numeric bool, add func(int, string, ...interface{})) {
+	if s == "" {
+		add(offset, "%s is empty", name)
+		return
+	}
+	for _, id := range strings.Split(s, ".") {
+		switch {
+		case id == "":
+			add(offset, "%s has an empty identifier", name)
+		case !containsOnly(id, allowed):
+			add(offset, "%s identifier %q has characters other than [0-9A-Za-z-]", name, id)
+		case numeric && len(id) > 1 && id[0] == '0' && containsOnly(id, num):
+			add(offset, "%s identifier %q has a leading zero", name, id)
+		}
+		offset += len(id) + 1
+	}
+}
@@ -0,0 +1,65 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateStrict(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected []Violation
+	}{
+		{"1.2.3", nil},
+		{"0.0.0", nil},
+		{"1.2.3-alpha.1+build.5", nil},
+		{"1.2.3-rc-1+build-5.010", nil},
+		{"1.0.0-0A.is.legal", nil},
+		{"18446744073709551615.0.0", nil},
+
+		{"", []Violation{{0, "version is empty"}}},
+		{"v1.2.3", []Violation{{0, "the v prefix is not allowed"}}},
+		{"V1.2.3", []Violation{{0, "the V prefix is not allowed"}}},
+		{"1.2", []Violation{{3, "patch version is missing"}}},
+		{"1", []Violation{{1, "minor version is missing"}}},
+		{"1.2.3.4", []Violation{{6, "only major, minor, and patch versions are allowed"}}},
+		{"1.x.3", []Violation{{2, "minor version is a wildcard"}}},
+		{"1.2.*", []Violation{{4, "patch version is a wildcard"}}},
+		{"01.2.03", []Violation{{0, `major version "01" has a leading zero`}, {5, `patch version "03" has a leading zero`}}},
+		{"1..3", []Violation{{2, "minor version is empty"}}},
+		{" 1.2.3", []Violation{{0, `major version " 1" is not a number`}}},
+		{"1.2.3 ", []Violation{{4, `patch version "3 " is not a number`}}},
+		{"99999999999999999999.0.0", []Violation{{0, `major version "99999999999999999999" is too large`}}},
+		{"1.2.3-", []Violation{{6, "prerelease is empty"}}},
+		{"1.2.3+", []Violation{{6, "metadata is empty"}}},
+		{"1.2.3-01", []Violation{{6, `prerelease identifier "01" has a leading zero`}}},
+		{"1.2.3-a..b", []Violation{{8, "prerelease has an empty identifier"}}},
+		{"1.2.3-a_b", []Violation{{6, `prerelease identifier "a_b" has characters other than [0-9A-Za-z-]`}}},
+		{"1.2.3+a.b_c", []Violation{{8, `metadata identifier "b_c" has characters other than [0-9A-Za-z-]`}}},
+		{"v1.2-01+", []Violation{
+			{0, "the v prefix is not allowed"},
+			{4, "patch version is missing"},
+			{5, `prerelease identifier "01" has a leading zero`},
+			{8, "metadata is empty"},
+		}},
+	}
+
+	for _, tc := range tests {
+		a := ValidateStrict(tc.s)
+		if !reflect.DeepEqual(a, tc.expected) {
+			t.Errorf("Expected violations %v for %q but got %v", tc.expected, tc.s, a)
+		}
+
+		// Every version certified by ValidateStrict parses strictly.
+		if _, err := StrictNewVersion(tc.s); a == nil && err != nil {
+			t.Errorf("Expected %q to parse strictly but got %s", tc.s, err)
+		}
+	}
+}
+
+func TestViolationString(t *testing.T) {
+	v := Violation{Offset: 6, Reason: "prerelease is empty"}
+	if s := v.String(); s != "offset 6: prerelease is empty" {
+		t.Errorf("Unexpected string %q", s)
+	}
+}
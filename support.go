@@ -0,0 +1,162 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SupportStatus is the support state of a version within a SupportWindow.
+type SupportStatus int
+
+const (
+	// Supported is a version in a supported series.
+	Supported SupportStatus = iota
+
+	// EOL is a version in a series that is no longer supported.
+	EOL
+
+	// Unreleased is a version in a series newer than every release, such as
+	// the next major version being worked on.
+	Unreleased
+)
+
+// String returns a short name for the status.
+func (s SupportStatus) String() string {
+	switch s {
+	case Supported:
+		return "supported"
+	case EOL:
+		return "eol"
+	case Unreleased:
+		return "unreleased"
+	default:
+		return "unknown"
+	}
+}
+
+// SupportWindow is the set of minor series supported out of a release
+// history.
+type SupportWindow struct {
+	series []*Version
+	latest *Version
+}
+
+// NewSupportWindow creates a support window supporting the latest minors
+// minor series that have a release. The releases do not need to be sorted
+// and prereleases among them are ignored, so a series is not supported until
+// it has a release.
+func NewSupportWindow(releases []*Version, minors int) *SupportWindow {
+	w := &SupportWindow{}
+	seen := map[string]bool{}
+	var series []*Version
+	for _, r := range releases {
+		if r.Prerelease() != "" {
+			continue
+		}
+		if w.latest == nil || r.GreaterThan(w.latest) {
+			w.latest = r
+		}
+		if s := Series(r); !seen[s] {
+			seen[s] = true
+			series = append(series, newCoreVersion(r.major, r.minor, 0))
+		}
+	}
+
+	sort.Sort(sort.Reverse(Collection(series)))
+	if minors < len(series) {
+		series = series[:minors]
+	}
+	w.series = series
+	return w
+}
+
+// Series returns the supported series, such as "1.4", newest first.
+func (w *SupportWindow) Series() []string {
+	out := make([]string, len(w.series))
+	for k, s := range w.series {
+		out[k] = Series(s)
+	}
+	return out
+}
+
+// Constraint returns constraints admitting the versions of the supported
+// series, such as `~1.8 || ~1.9`. When no series is supported the constraints
+// admit no version.
+func (w *SupportWindow) Constraint() *Constraints {
+	return seriesConstraint(w.series)
+}
+
+// seriesConstraint returns constraints admitting the versions of the series,
+// each given by its .0 release, or no version when there are none.
+func seriesConstraint(series []*Version) *Constraints {
+	if len(series) == 0 {
+		c, _ := NewConstraint("<0.0.0-0")
+		return c
+	}
+
+	s := make([]string, len(series))
+	for k, v := range series {
+		s[k] = "~" + Series(v)
+	}
+	c, _ := NewConstraint(strings.Join(s, " || "))
+	return c
+}
+
+// Status classifies a version as Supported when it is in a supported series,
+// Unreleased when it is in a series newer than every release, and EOL
+// otherwise. A prerelease is classified by the series of the release it
+// precedes.
+func (w *SupportWindow) Status(v *Version) SupportStatus {
+	for _, s := range w.series {
+		if v.major == s.major && v.minor == s.minor {
+			return Supported
+		}
+	}
+	if w.latest == nil || v.major > w.latest.major || (v.major == w.latest.major && v.minor > w.latest.minor) {
+		return Unreleased
+	}
+	return EOL
+}
+
+// BackportTargets proposes the next patch version of each maintained series
+// for a fix released on the main line in fix. Only series older than the
+// series of fix are affected, as the fix is already in fix's series and any
+// newer one. The next patch follows the latest release of the series in
+// releases, ignoring prereleases, or is the .0 release when the series has
+// none. Each series is a major and minor version such as "1.8", and the
+// targets are returned in ascending order. An error is returned when a series
+// is not a major and minor version.
+func BackportTargets(fix *Version, series []string, releases []*Version) ([]*Version, error) {
+	var targets []*Version
+	seen := map[string]bool{}
+	for _, s := range series {
+		major, minor, whole, ok := parseSeries(s)
+		if !ok || whole {
+			return nil, fmt.Errorf("%s is not a major and minor series", s)
+		}
+		if major > fix.major || (major == fix.major && minor >= fix.minor) {
+			continue
+		}
+
+		target := newCoreVersion(major, minor, 0)
+		if seen[Series(target)] {
+			continue
+		}
+		seen[Series(target)] = true
+
+		var latest *Version
+		for _, r := range releases {
+			if r.Prerelease() == "" && r.major == major && r.minor == minor && (latest == nil || r.GreaterThan(latest)) {
+				latest = r
+			}
+		}
+		if latest != nil {
+			target = newCoreVersion(major, minor, latest.patch+1)
+		}
+		targets = append(targets, target)
+	}
+
+	sort.Sort(Collection(targets))
+	return targets, nil
+}
@@ -0,0 +1,94 @@
+package semver
+
+import "testing"
+
+func TestSupportWindow(t *testing.T) {
+	var releases []*Version
+	for _, r := range []string{"1.9.3", "1.7.0", "2.0.0", "1.8.4", "1.9.0", "2.1.0-rc.1", "1.8.0", "2.0.1"} {
+		releases = append(releases, MustParse(r))
+	}
+
+	w := NewSupportWindow(releases, 3)
+	if s := w.Series(); !equalStrings(s, []string{"2.0", "1.9", "1.8"}) {
+		t.Errorf("expected the supported series 2.0, 1.9, and 1.8 but got %q", s)
+	}
+	if c := w.Constraint().String(); c != "~1.8 || ~1.9 || ~2.0" {
+		t.Errorf("expected the constraint ~1.8 || ~1.9 || ~2.0 but got %q", c)
+	}
+
+	tests := []struct {
+		version  string
+		expected SupportStatus
+	}{
+		{"2.0.1", Supported},
+		{"1.8.0", Supported},
+		{"1.8.9", Supported},
+		{"1.9.4-rc.1", Supported},
+		{"1.7.0", EOL},
+		{"0.9.0", EOL},
+		{"2.1.0-rc.1", Unreleased},
+		{"2.1.0", Unreleased},
+		{"3.0.0", Unreleased},
+	}
+	for _, tc := range tests {
+		if got := w.Status(MustParse(tc.version)); got != tc.expected {
+			t.Errorf("expected %s to be %s but got %s", tc.version, tc.expected, got)
+		}
+	}
+
+	all := NewSupportWindow(releases, 10)
+	if s := all.Series(); len(s) != 4 {
+		t.Errorf("expected every series to be supported but got %q", s)
+	}
+
+	none := NewSupportWindow(nil, 3)
+	if len(none.Series()) != 0 || none.Constraint().Check(MustParse("1.0.0")) {
+		t.Errorf("expected an empty window to support nothing but got %q", none.Constraint())
+	}
+	if got := none.Status(MustParse("1.0.0")); got != Unreleased {
+		t.Errorf("expected 1.0.0 to be unreleased without releases but got %s", got)
+	}
+}
+
+func TestBackportTargets(t *testing.T) {
+	var releases []*Version
+	for _, r := range []string{"1.8.5", "1.8.4", "1.9.2", "1.9.3-rc.1", "2.0.0", "2.1.0", "1.7.9"} {
+		releases = append(releases, MustParse(r))
+	}
+
+	tests := []struct {
+		fix      string
+		series   []string
+		expected []string
+		err      bool
+	}{
+		{"2.1.0", []string{"2.0", "1.9", "1.8"}, []string{"1.8.6", "1.9.3", "2.0.1"}, false},
+		{"2.0.1", []string{"2.0", "1.9", "1.8"}, []string{"1.8.6", "1.9.3"}, false},
+		{"2.1.0", []string{"v1.9.x", "1.9"}, []string{"1.9.3"}, false},
+		{"2.1.0", []string{"1.6"}, []string{"1.6.0"}, false},
+		{"1.8.0", []string{"2.0", "1.9"}, nil, false},
+		{"2.1.0", []string{"1"}, nil, true},
+		{"2.1.0", []string{"1.x.2"}, nil, true},
+	}
+
+	for _, tc := range tests {
+		targets, err := BackportTargets(MustParse(tc.fix), tc.series, releases)
+		if tc.err {
+			if err == nil {
+				t.Errorf("expected an error for the series %q", tc.series)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for the series %q: %s", tc.series, err)
+			continue
+		}
+		got := make([]string, len(targets))
+		for k, v := range targets {
+			got[k] = v.String()
+		}
+		if !equalStrings(got, tc.expected) {
+			t.Errorf("expected the backports of %s to %q to be %q but got %q", tc.fix, tc.series, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package semver
+
+import "regexp"
+
+// TagPolicy matches tags, such as container image tags, on both their
+// version and their form. A tag matches when it starts with Prefix, ends with
+// Suffix, and matches Pattern, and what is left once Prefix and Suffix are
+// removed is a version admitted by Constraint. For example, a policy with the
+// constraint ^1.25 and the suffix -alpine matches 1.25.3-alpine but neither
+// 1.25.3 nor 1.24.0-alpine. Empty fields do not restrict the tags matched.
+type TagPolicy struct {
+	Constraint *Constraints
+	Prefix     string
+	Suffix     string
+
+	// Pattern is matched against the whole tag, including Prefix and Suffix.
+	// Anchor it with ^ and $ to match the entire tag.
+	Pattern *regexp.Regexp
+}
+
+// Matches reports if a tag matches the policy.
+func (p TagPolicy) Matches(tag string) bool {
+	v, ok := p.Version(tag)
+	if !ok {
+		return false
+	}
+	return p.Constraint == nil || p.Constraint.Check(v)
+}
+
+// Version returns the version of a tag with the form required by the policy,
+// whether or not the constraint admits it. The boolean is false when the tag
+// does not have the prefix, suffix, or pattern of the policy or what is left
+// of it is not a version.
+func (p TagPolicy) Version(tag string) (*Version, bool) {
+	if len(tag) < len(p.Prefix)+len(p.Suffix) ||
+		tag[:len(p.Prefix)] != p.Prefix ||
+		tag[len(tag)-len(p.Suffix):] != p.Suffix {
+		return nil, false
+	}
+	if p.Pattern != nil && !p.Pattern.MatchString(tag) {
+		return nil, false
+	}
+
+	v, err := NewVersion(tag[len(p.Prefix) : len(tag)-len(p.Suffix)])
+	if err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// Filter returns the tags matching the policy in their original order.
+func (p TagPolicy) Filter(tags []string) []string {
+	var out []string
+	for _, t := range tags {
+		if p.Matches(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package semver
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestTagPolicy(t *testing.T) {
+	c, err := NewConstraint("^1.25")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		policy   TagPolicy
+		tag      string
+		expected bool
+	}{
+		{TagPolicy{Constraint: c, Suffix: "-alpine"}, "1.25.3-alpine", true},
+		{TagPolicy{Constraint: c, Suffix: "-alpine"}, "v1.26-alpine", true},
+		{TagPolicy{Constraint: c, Suffix: "-alpine"}, "1.25.3", false},
+		{TagPolicy{Constraint: c, Suffix: "-alpine"}, "1.24.0-alpine", false},
+		{TagPolicy{Constraint: c, Suffix: "-alpine"}, "-alpine", false},
+		{TagPolicy{Constraint: c, Prefix: "release-"}, "release-1.25.0", true},
+		{TagPolicy{Constraint: c, Prefix: "release-"}, "1.25.0", false},
+		{TagPolicy{Constraint: c}, "1.25.3-alpine", false},
+		{TagPolicy{Constraint: c, Pattern: regexp.MustCompile(`^1\.25\.\d+$`)}, "1.25.3", true},
+		{TagPolicy{Constraint: c, Pattern: regexp.MustCompile(`^1\.25\.\d+$`)}, "1.26.0", false},
+		{TagPolicy{Suffix: "-slim"}, "3.0.0-slim", true},
+		{TagPolicy{Suffix: "-slim"}, "latest-slim", false},
+		{TagPolicy{}, "2.0.0", true},
+		{TagPolicy{}, "", false},
+	}
+
+	for _, tc := range tests {
+		if got := tc.policy.Matches(tc.tag); got != tc.expected {
+			t.Errorf("expected %q matching %+v to be %t but got %t", tc.tag, tc.policy, tc.expected, got)
+		}
+	}
+
+	p := TagPolicy{Constraint: c, Suffix: "-alpine"}
+	if v, ok := p.Version("1.24.0-alpine"); !ok || v.String() != "1.24.0" {
+		t.Errorf("expected the version 1.24.0 of 1.24.0-alpine but got %v (%t)", v, ok)
+	}
+	tags := []string{"1.25.3-alpine", "1.25.3", "1.24.0-alpine", "1.26.1-alpine"}
+	if got := p.Filter(tags); !equalStrings(got, []string{"1.25.3-alpine", "1.26.1-alpine"}) {
+		t.Errorf("expected the tags 1.25.3-alpine and 1.26.1-alpine but got %q", got)
+	}
+}
@@ -0,0 +1,41 @@
+package semver
+
+import "strings"
+
+// FromTags parses versions from a list of tags, such as the tags of a git
+// repository. Without prefixes every tag is parsed as is, with NewVersion
+// allowing a leading v. With prefixes, such as "release-" or "component/",
+// only tags starting with one of them are parsed, with the first prefix that
+// leaves a version removed. The versions are returned in the order of their
+// tags along with the tags that are not versions, also in order. The
+// original of each version is the tag without its prefix.
+func FromTags(tags []string, prefixes ...string) (Collection, []string) {
+	var versions Collection
+	var rest []string
+	for _, t := range tags {
+		if v := fromTag(t, prefixes); v != nil {
+			versions = append(versions, v)
+		} else {
+			rest = append(rest, t)
+		}
+	}
+	return versions, rest
+}
+
+// fromTag parses the version of a tag with one of the prefixes, or without a
+// prefix when there are none. It returns nil when the tag is not a version.
+func fromTag(tag string, prefixes []string) *Version {
+	if len(prefixes) == 0 {
+		v, _ := NewVersion(tag)
+		return v
+	}
+	for _, p := range prefixes {
+		if !strings.HasPrefix(tag, p) {
+			continue
+		}
+		if v, err := NewVersion(tag[len(p):]); err == nil {
+			return v
+		}
+	}
+	return nil
+}
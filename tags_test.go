@@ -0,0 +1,31 @@
+package semver
+
+import "testing"
+
+func TestFromTags(t *testing.T) {
+	tags := []string{"v1.2.3", "release-1.3.0", "api/v2.0.0", "latest", "1.0", "api/next", "release-v1.4.0-rc.1"}
+
+	tests := []struct {
+		prefixes []string
+		versions []string
+		rest     []string
+	}{
+		{nil, []string{"v1.2.3", "1.0"}, []string{"release-1.3.0", "api/v2.0.0", "latest", "api/next", "release-v1.4.0-rc.1"}},
+		{[]string{"release-"}, []string{"1.3.0", "v1.4.0-rc.1"}, []string{"v1.2.3", "api/v2.0.0", "latest", "1.0", "api/next"}},
+		{[]string{"api/", "v", ""}, []string{"1.2.3", "v2.0.0", "1.0"}, []string{"release-1.3.0", "latest", "api/next", "release-v1.4.0-rc.1"}},
+	}
+
+	for _, tc := range tests {
+		versions, rest := FromTags(tags, tc.prefixes...)
+		var got []string
+		for _, v := range versions {
+			got = append(got, v.Original())
+		}
+		if !equalStrings(got, tc.versions) {
+			t.Errorf("expected the versions %q with the prefixes %q but got %q", tc.versions, tc.prefixes, got)
+		}
+		if !equalStrings(rest, tc.rest) {
+			t.Errorf("expected the leftover tags %q with the prefixes %q but got %q", tc.rest, tc.prefixes, rest)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExpandTemplate replaces the variables of a constraint template, written as
+// ${NAME}, with their values and parses the result, such as "^1.4" for
+// "^${MAJOR}.${MINOR}" with MAJOR 1 and MINOR 4. The values may only hold
+// the characters of a version, so a value cannot add operators or || branches
+// to the constraints. The expanded constraints are cached, so templates
+// expanding to the same constraints share one parse.
+func ExpandTemplate(tmpl string, vars map[string]string) (*Constraints, error) {
+	return defaultConstraintCache.Expand(tmpl, vars)
+}
+
+// Expand expands a constraint template the same way as ExpandTemplate and
+// gets the result from the cache.
+func (cc *ConstraintCache) Expand(tmpl string, vars map[string]string) (*Constraints, error) {
+	c, err := expandTemplate(tmpl, vars)
+	if err != nil {
+		return nil, err
+	}
+	return cc.Get(c)
+}
+
+// expandTemplate replaces the variables of the template with their values.
+func expandTemplate(tmpl string, vars map[string]string) (string, error) {
+	var b strings.Builder
+	b.Grow(len(tmpl))
+	rest := tmpl
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			b.WriteString(rest)
+			return b.String(), nil
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("%s has an unterminated variable", tmpl)
+		}
+
+		name := rest[start+2 : start+end]
+		if !isTemplateName(name) {
+			return "", fmt.Errorf("%s has the invalid variable name %q", tmpl, name)
+		}
+		value, ok := vars[name]
+		if !ok {
+			return "", fmt.Errorf("%s uses the undefined variable %s", tmpl, name)
+		}
+		if !isTemplateValue(value) {
+			return "", fmt.Errorf("the variable %s of %s has the value %q, which is not part of a version", name, tmpl, value)
+		}
+
+		b.WriteString(rest[:start])
+		b.WriteString(value)
+		rest = rest[start+end+1:]
+	}
+}
+
+// isTemplateName reports if s is a variable name made of letters, digits,
+// and underscores that does not start with a digit.
+func isTemplateName(s string) bool {
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// isTemplateValue reports if s only holds characters found within a
+// version, including the wildcards x, X, and *.
+func isTemplateValue(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isRangeChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
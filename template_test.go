@@ -0,0 +1,75 @@
+package semver
+
+import "testing"
+
+func TestExpandTemplate(t *testing.T) {
+	vars := map[string]string{
+		"MAJOR":   "1",
+		"MINOR":   "4",
+		"CHANNEL": "beta",
+		"ANY":     "x",
+		"_v2":     "2.0.0",
+		"EVIL":    "1 || *",
+		"SPACE":   "1 ",
+		"EMPTY":   "",
+	}
+
+	tests := []struct {
+		tmpl     string
+		expected string
+		err      string
+	}{
+		{"^${MAJOR}.${MINOR}", "^1.4", ""},
+		{">=${MAJOR}.${MINOR}.0-${CHANNEL}, <${_v2}", ">=1.4.0-beta <2.0.0", ""},
+		{"${MAJOR}.${ANY}", "1.x", ""},
+		{"~1.2 || ^${MAJOR}", "^1 || ~1.2", ""},
+		{"^1.2", "^1.2", ""},
+		{"^${MAJOR}.${MISSING}", "", "^${MAJOR}.${MISSING} uses the undefined variable MISSING"},
+		{"^${EVIL}", "", `the variable EVIL of ^${EVIL} has the value "1 || *", which is not part of a version`},
+		{"^${SPACE}", "", `the variable SPACE of ^${SPACE} has the value "1 ", which is not part of a version`},
+		{"^${EMPTY}", "", `the variable EMPTY of ^${EMPTY} has the value "", which is not part of a version`},
+		{"^${MAJOR", "", "^${MAJOR has an unterminated variable"},
+		{"^${1X}", "", `^${1X} has the invalid variable name "1X"`},
+		{"^${}", "", `^${} has the invalid variable name ""`},
+		{"^${MAJOR}.${MINOR}.${CHANNEL}", "", "improper constraint: ^1.4.beta"},
+	}
+
+	for _, tc := range tests {
+		c, err := ExpandTemplate(tc.tmpl, vars)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%s: expected the error %q but got %v", tc.tmpl, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.tmpl, err)
+			continue
+		}
+		if c.String() != tc.expected {
+			t.Errorf("%s: expected %q but got %q", tc.tmpl, tc.expected, c)
+		}
+	}
+}
+
+func TestConstraintCacheExpand(t *testing.T) {
+	cc := NewConstraintCache(MatchOptions{}, 0)
+	a, err := cc.Expand("^${MAJOR}.${MINOR}", map[string]string{"MAJOR": "1", "MINOR": "4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b, err := cc.Expand("^1.${MINOR}", map[string]string{"MINOR": "4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if a != b || cc.Len() != 1 {
+		t.Errorf("expected templates expanding to the same constraints to share an entry but got %d entries", cc.Len())
+	}
+
+	if _, err := cc.Expand("^${MAJOR}", nil); err == nil {
+		t.Error("expected an error for an undefined variable")
+	}
+	if cc.Len() != 1 {
+		t.Errorf("expected a template that did not expand not to be cached but got %d entries", cc.Len())
+	}
+}
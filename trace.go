@@ -0,0 +1,119 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// The stages recorded in a Trace while parsing constraints.
+const (
+	// TraceOperator records clauses written with operators registered with
+	// RegisterOperator being expanded. It is only recorded when there were
+	// any.
+	TraceOperator = "operator"
+
+	// TraceCollapse records empty || branches and clauses between commas
+	// being dropped. It is only recorded when there were any.
+	TraceCollapse = "collapse"
+
+	// TraceRewriteRange records hyphen ranges (e.g., 1.2 - 1.4) being
+	// rewritten into comparisons.
+	TraceRewriteRange = "rewrite-range"
+
+	// TraceSplitOr records the input being split on || into groups.
+	TraceSplitOr = "split-or"
+
+	// TraceSplitAnd records a group being split into its AND clauses.
+	TraceSplitAnd = "split-and"
+
+	// TraceParseClause records a single clause being parsed into an operator
+	// and version.
+	TraceParseClause = "parse-clause"
+
+	// TraceResult records the final structure of the parsed constraints.
+	TraceResult = "result"
+)
+
+// TraceStep is a single step taken while parsing constraints.
+type TraceStep struct {
+	// Stage is one of the Trace* stage constants.
+	Stage string
+
+	// Input is the text the step operated on.
+	Input string
+
+	// Output describes what the step produced.
+	Output string
+}
+
+// Trace is the record of how a constraint string was parsed. It is returned
+// by NewConstraintWithTrace to help debug why a constraint was interpreted
+// the way it was.
+type Trace struct {
+	Steps []TraceStep
+}
+
+// NewConstraintWithTrace parses constraints the same way as NewConstraint
+// while recording the rewriting, splitting, and parsing steps taken. The trace
+// is returned even when parsing fails so that the steps leading to the
+// failure can be inspected.
+func NewConstraintWithTrace(c string) (*Constraints, *Trace, error) {
+	t := &Trace{}
+	cs, err := newConstraint(c, t, false)
+	return cs, t, err
+}
+
+// record adds a step to the trace. It is safe to call on a nil trace, which is
+// how parsing without a trace avoids the cost of recording.
+func (t *Trace) record(stage, input, output string) {
+	if t == nil {
+		return
+	}
+	t.Steps = append(t.Steps, TraceStep{Stage: stage, Input: input, Output: output})
+}
+
+// String renders the trace with one step per line.
+func (t *Trace) String() string {
+	var buf bytes.Buffer
+	for _, s := range t.Steps {
+		fmt.Fprintf(&buf, "%s: %q => %s\n", s.Stage, s.Input, s.Output)
+	}
+	return buf.String()
+}
+
+// describe renders how a single clause was interpreted.
+func (c *constraint) describe() string {
+	op := c.origfunc
+	if op == "" {
+		op = "="
+	}
+
+	s := fmt.Sprintf("op=%s version=%s", op, c.con)
+	switch {
+	case c.minorDirty:
+		s += " wildcard=minor"
+	case c.patchDirty:
+		s += " wildcard=patch"
+	case c.dirty:
+		s += " wildcard=major"
+	}
+	return s
+}
+
+// structure renders the constraints as a union of intersections.
+func (cs Constraints) structure() string {
+	buf := make([]string, len(cs.constraints))
+	for k, group := range cs.constraints {
+		buf[k] = "(" + strings.Join(clauseStrings(group), " AND ") + ")"
+	}
+	return strings.Join(buf, " OR ")
+}
+
+func clauseStrings(group []*constraint) []string {
+	s := make([]string, len(group))
+	for k, c := range group {
+		s[k] = c.string()
+	}
+	return s
+}
@@ -0,0 +1,61 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewConstraintWithTrace(t *testing.T) {
+	c, tr, err := NewConstraintWithTrace("1.2 - 1.4, !=1.3.2 || 2.x")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []TraceStep{
+		{TraceRewriteRange, "1.2 - 1.4, !=1.3.2 || 2.x", ">= 1.2, <= 1.4, !=1.3.2 || 2.x"},
+		{TraceSplitOr, ">= 1.2, <= 1.4, !=1.3.2 || 2.x", `[">= 1.2, <= 1.4, !=1.3.2 " " 2.x"]`},
+		{TraceSplitAnd, ">= 1.2, <= 1.4, !=1.3.2 ", `[">= 1.2" "<= 1.4" "!=1.3.2"]`},
+		{TraceParseClause, ">= 1.2", "op=>= version=1.2.0 wildcard=patch"},
+		{TraceParseClause, "<= 1.4", "op=<= version=1.4.0 wildcard=patch"},
+		{TraceParseClause, "!=1.3.2", "op=!= version=1.3.2"},
+		{TraceSplitAnd, " 2.x", `[" 2.x"]`},
+		{TraceParseClause, " 2.x", "op== version=2.0.0 wildcard=minor"},
+		{TraceResult, ">= 1.2, <= 1.4, !=1.3.2 || 2.x", "(>=1.2 AND <=1.4 AND !=1.3.2) OR (2.x)"},
+	}
+	if !reflect.DeepEqual(tr.Steps, expected) {
+		t.Errorf("unexpected trace:\n%s", tr)
+	}
+
+	if c.String() != ">=1.2 <=1.4 !=1.3.2 || 2.x" {
+		t.Errorf("unexpected constraint %q", c.String())
+	}
+}
+
+func TestNewConstraintWithTraceError(t *testing.T) {
+	_, tr, err := NewConstraintWithTrace(">= 1.2 || foo")
+	if err == nil {
+		t.Fatal("expected an error parsing the constraint")
+	}
+
+	if len(tr.Steps) == 0 || tr.Steps[len(tr.Steps)-1].Stage != TraceParseClause {
+		t.Errorf("expected the trace to end with the last clause parsed but got:\n%s", tr)
+	}
+}
+
+func TestNewConstraintWithTraceCollapse(t *testing.T) {
+	_, tr, err := NewConstraintWithTrace("^1,, || ^2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := TraceStep{TraceCollapse, "^1,, || ^2", "^1|| ^2"}
+	if len(tr.Steps) == 0 || tr.Steps[0] != want {
+		t.Errorf("expected the trace to start with %+v but got:\n%s", want, tr)
+	}
+
+	_, tr, _ = NewConstraintWithTrace("^1 || ^2")
+	for _, s := range tr.Steps {
+		if s.Stage == TraceCollapse {
+			t.Errorf("expected no collapse step for input without empty parts but got:\n%s", tr)
+		}
+	}
+}
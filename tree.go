@@ -0,0 +1,54 @@
+package semver
+
+// TreeKind is the kind of a node in the tree returned by
+// Constraints.MarshalTree.
+type TreeKind string
+
+const (
+	// TreeOr is a node admitting versions admitted by any of its children.
+	TreeOr TreeKind = "or"
+
+	// TreeAnd is a node admitting versions admitted by all of its children.
+	TreeAnd TreeKind = "and"
+
+	// TreeClause is a leaf node holding a single clause such as `>=1.2`.
+	TreeClause TreeKind = "clause"
+)
+
+// TreeNode is a node in the tree structure of constraints. It can be
+// marshaled to JSON.
+type TreeNode struct {
+	Kind TreeKind `json:"kind"`
+
+	// Children are the nodes combined by a TreeOr or TreeAnd node.
+	Children []TreeNode `json:"children,omitempty"`
+
+	// Operator is the operator of a TreeClause node as written, such as ">="
+	// or "^". It is empty for a clause written without an operator.
+	Operator string `json:"operator,omitempty"`
+
+	// Version is the version of a TreeClause node as written, such as "1.2"
+	// or "1.x".
+	Version string `json:"version,omitempty"`
+}
+
+// MarshalTree returns the structure of the constraints as a tree. The root is
+// always a TreeOr node holding a TreeAnd node for each || separated branch,
+// each of which holds a TreeClause node for each of its clauses. Nodes are in
+// the order they were written. Hyphen ranges, such as `1.2 - 1.4`, are
+// returned as the two clauses they are rewritten into.
+func (cs Constraints) MarshalTree() TreeNode {
+	root := TreeNode{Kind: TreeOr, Children: make([]TreeNode, len(cs.constraints))}
+	for k, group := range cs.constraints {
+		and := TreeNode{Kind: TreeAnd, Children: make([]TreeNode, len(group))}
+		for kk, c := range group {
+			and.Children[kk] = TreeNode{
+				Kind:     TreeClause,
+				Operator: c.origfunc,
+				Version:  c.orig,
+			}
+		}
+		root.Children[k] = and
+	}
+	return root
+}
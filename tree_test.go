@@ -0,0 +1,54 @@
+package semver
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalTree(t *testing.T) {
+	c, err := NewConstraint(">= 1.2, <2 || 1.2 - 1.4 || 3.x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := TreeNode{Kind: TreeOr, Children: []TreeNode{
+		{Kind: TreeAnd, Children: []TreeNode{
+			{Kind: TreeClause, Operator: ">=", Version: "1.2"},
+			{Kind: TreeClause, Operator: "<", Version: "2"},
+		}},
+		{Kind: TreeAnd, Children: []TreeNode{
+			{Kind: TreeClause, Operator: ">=", Version: "1.2"},
+			{Kind: TreeClause, Operator: "<=", Version: "1.4"},
+		}},
+		{Kind: TreeAnd, Children: []TreeNode{
+			{Kind: TreeClause, Version: "3.x"},
+		}},
+	}}
+	got := c.MarshalTree()
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected tree %+v but got %+v", expected, got)
+	}
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// encoding/json escapes < and > by default.
+	ej := `{"kind":"or","children":[` +
+		`{"kind":"and","children":[{"kind":"clause","operator":"\u003e=","version":"1.2"},{"kind":"clause","operator":"\u003c","version":"2"}]},` +
+		`{"kind":"and","children":[{"kind":"clause","operator":"\u003e=","version":"1.2"},{"kind":"clause","operator":"\u003c=","version":"1.4"}]},` +
+		`{"kind":"and","children":[{"kind":"clause","version":"3.x"}]}]}`
+	if string(b) != ej {
+		t.Errorf("expected JSON %s but got %s", ej, b)
+	}
+
+	var back TreeNode
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, expected) {
+		t.Errorf("expected the JSON to unmarshal to %+v but got %+v", expected, back)
+	}
+}
@@ -0,0 +1,120 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tuple is a set of versions that are released together, such as an API
+// version and a schema version. Tuples are ordered by their first version,
+// then their second, and so on.
+type Tuple []*Version
+
+// NewTuple parses each of the versions with NewVersion into a Tuple.
+func NewTuple(versions ...string) (Tuple, error) {
+	t := make(Tuple, len(versions))
+	for k, s := range versions {
+		v, err := NewVersion(s)
+		if err != nil {
+			return nil, fmt.Errorf("version %d of the tuple: %s", k+1, err)
+		}
+		t[k] = v
+	}
+	return t, nil
+}
+
+// String returns the versions separated by commas, such as "1.2.0, 3.4.1".
+func (t Tuple) String() string {
+	s := make([]string, len(t))
+	for k, v := range t {
+		if v != nil {
+			s[k] = v.String()
+		}
+	}
+	return strings.Join(s, ", ")
+}
+
+// Compare compares the tuples a version at a time, returning -1, 0, or 1 in
+// the same way as Version.Compare. When one tuple is a prefix of the other
+// the shorter tuple is less.
+func (t Tuple) Compare(o Tuple) int {
+	for k := 0; k < len(t) && k < len(o); k++ {
+		if d := t[k].Compare(o[k]); d != 0 {
+			return d
+		}
+	}
+	switch {
+	case len(t) < len(o):
+		return -1
+	case len(t) > len(o):
+		return 1
+	}
+	return 0
+}
+
+// LessThan reports if the tuple is less than o.
+func (t Tuple) LessThan(o Tuple) bool {
+	return t.Compare(o) < 0
+}
+
+// Equal reports if the tuples are equal.
+func (t Tuple) Equal(o Tuple) bool {
+	return t.Compare(o) == 0
+}
+
+// TupleConstraints constrains each version of a Tuple with the constraints
+// in the same position.
+type TupleConstraints []*Constraints
+
+// NewTupleConstraint parses each of the constraints with NewConstraint into
+// TupleConstraints.
+func NewTupleConstraint(constraints ...string) (TupleConstraints, error) {
+	tc := make(TupleConstraints, len(constraints))
+	for k, s := range constraints {
+		c, err := NewConstraint(s)
+		if err != nil {
+			return nil, fmt.Errorf("constraint %d of the tuple: %s", k+1, err)
+		}
+		tc[k] = c
+	}
+	return tc, nil
+}
+
+// String returns the constraints separated by commas, such as "^1.2, ~3.4".
+func (tc TupleConstraints) String() string {
+	s := make([]string, len(tc))
+	for k, c := range tc {
+		if c != nil {
+			s[k] = c.String()
+		}
+	}
+	return strings.Join(s, ", ")
+}
+
+// Matches reports if every version of the tuple satisfies the constraints in
+// the same position. An error is returned when the tuple does not have a
+// version for each of the constraints, or when one of its versions is nil.
+// Nil constraints admit no versions.
+func (tc TupleConstraints) Matches(t Tuple) (bool, error) {
+	if len(t) != len(tc) {
+		return false, fmt.Errorf("a tuple of %d versions does not match %d constraints", len(t), len(tc))
+	}
+	for _, v := range t {
+		if v == nil {
+			return false, &MatchError{Constraint: tc.String(), Err: ErrNilVersion}
+		}
+	}
+	for k, v := range t {
+		if c := tc[k]; c == nil || !c.Check(v) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Check reports if the tuple matches the constraints, treating an error from
+// Matches as not matching.
+func (tc TupleConstraints) Check(t Tuple) bool {
+	ok, _ := tc.Matches(t)
+	return ok
+}
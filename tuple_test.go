@@ -0,0 +1,94 @@
+package semver
+
+import (
+	"sort"
+	"testing"
+)
+
+func mustTuple(t *testing.T, versions ...string) Tuple {
+	t.Helper()
+	tu, err := NewTuple(versions...)
+	if err != nil {
+		t.Fatalf("unable to parse the tuple %q: %s", versions, err)
+	}
+	return tu
+}
+
+func TestTupleCompare(t *testing.T) {
+	tests := []struct {
+		a, b     []string
+		expected int
+	}{
+		{[]string{"1.2.0", "3.0.0"}, []string{"1.2.0", "3.0.0"}, 0},
+		{[]string{"1.2.0", "3.0.0"}, []string{"v1.2", "3"}, 0},
+		{[]string{"1.2.0", "3.0.0"}, []string{"1.2.0", "3.1.0"}, -1},
+		{[]string{"1.3.0", "1.0.0"}, []string{"1.2.0", "9.0.0"}, 1},
+		{[]string{"1.2.0"}, []string{"1.2.0", "1.0.0"}, -1},
+		{[]string{"1.2.0", "0.0.1"}, []string{"1.2.0"}, 1},
+		{[]string{}, []string{}, 0},
+	}
+
+	for _, tc := range tests {
+		a, b := mustTuple(t, tc.a...), mustTuple(t, tc.b...)
+		if got := a.Compare(b); got != tc.expected {
+			t.Errorf("expected (%s) compared to (%s) to be %d but got %d", a, b, tc.expected, got)
+		}
+		if a.LessThan(b) != (tc.expected < 0) || a.Equal(b) != (tc.expected == 0) {
+			t.Errorf("expected LessThan and Equal of (%s) and (%s) to agree with Compare", a, b)
+		}
+	}
+
+	tuples := []Tuple{mustTuple(t, "2.0.0", "1.0.0"), mustTuple(t, "1.0.0", "2.0.0"), mustTuple(t, "1.0.0", "1.5.0")}
+	sort.Slice(tuples, func(i, j int) bool { return tuples[i].LessThan(tuples[j]) })
+	if s := tuples[0].String() + "; " + tuples[1].String() + "; " + tuples[2].String(); s != "1.0.0, 1.5.0; 1.0.0, 2.0.0; 2.0.0, 1.0.0" {
+		t.Errorf("unexpected order %s", s)
+	}
+}
+
+func TestNewTupleErrors(t *testing.T) {
+	if _, err := NewTuple("1.2.0", "banana"); err == nil || err.Error() != "version 2 of the tuple: Invalid Semantic Version" {
+		t.Errorf("expected an error for the second version but got %v", err)
+	}
+	if _, err := NewTupleConstraint("^1", "<<2"); err == nil {
+		t.Error("expected an error for the second constraint")
+	}
+}
+
+func TestTupleConstraintsMatches(t *testing.T) {
+	tc, err := NewTupleConstraint("^1.2", "~3.4")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tc.String() != "^1.2, ~3.4" {
+		t.Errorf("expected ^1.2, ~3.4 but got %s", tc)
+	}
+
+	tests := []struct {
+		versions []string
+		expected bool
+	}{
+		{[]string{"1.5.0", "3.4.9"}, true},
+		{[]string{"1.5.0", "3.5.0"}, false},
+		{[]string{"2.0.0", "3.4.0"}, false},
+	}
+	for _, tt := range tests {
+		tu := mustTuple(t, tt.versions...)
+		ok, err := tc.Matches(tu)
+		if err != nil || ok != tt.expected {
+			t.Errorf("expected (%s) to match %t but got %t %v", tu, tt.expected, ok, err)
+		}
+		if tc.Check(tu) != tt.expected {
+			t.Errorf("expected Check of (%s) to be %t", tu, tt.expected)
+		}
+	}
+
+	if _, err := tc.Matches(mustTuple(t, "1.5.0")); err == nil || err.Error() != "a tuple of 1 versions does not match 2 constraints" {
+		t.Errorf("expected an error for a tuple of the wrong length but got %v", err)
+	}
+	if _, err := tc.Matches(Tuple{MustParse("2.0.0"), nil}); !isNilVersionError(err) {
+		t.Errorf("expected ErrNilVersion but got %v", err)
+	}
+	if ok, err := (TupleConstraints{nil}).Matches(mustTuple(t, "1.0.0")); ok || err != nil {
+		t.Errorf("expected nil constraints to admit nothing but got %t %v", ok, err)
+	}
+}
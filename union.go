@@ -0,0 +1,289 @@
+package semver
+
+import (
+	"sort"
+	"sync"
+)
+
+// groupArray tracks the use of a backing array of || separated groups shared
+// by constraints built with Union. Each of the constraints uses a prefix of
+// the array, and the groups past the longest prefix in use are free. Union
+// appends in place when the constraints it extends use the longest prefix,
+// so building a union of n constraints one at a time copies each group a
+// constant number of times on average rather than n times. The groups
+// themselves are never modified once built, so they are shared freely.
+type groupArray struct {
+	mu   sync.Mutex
+	used int
+}
+
+// Union returns constraints admitting the versions either a or b admits,
+// such as `^1.2 || ^2.0` for ^1.2 and ^2.0. Neither a nor b is modified, and
+// the result shares memory with a where it can, so a large union can be built
+// incrementally by calling Union with each new part. A nil a or b admits no
+// versions, so the union of nil and b is the same as b.
+//
+// When a and b were created with different options each of their clauses is
+// still checked with the options it was created with, so the union admits a
+// version exactly when a or b does. Options then returns the options chosen
+// by combineOptions, and String cannot show which clauses use which options.
+//
+// Union is commutative and associative: the result renders the same way,
+// admits the same versions, and has the same options whatever order the
+// parts are combined in.
+func Union(a, b *Constraints) *Constraints {
+	a, b = orNone(a, b), orNone(b, a)
+	opts := combineOptions(a.opts, b.opts)
+	out := &Constraints{opts: opts}
+	n := len(a.constraints) + len(b.constraints)
+	ag, bg := keepOptions(a, opts), keepOptions(b, opts)
+
+	if arr := a.groups; arr != nil && a.opts == opts && n <= cap(a.constraints) {
+		arr.mu.Lock()
+		if arr.used == len(a.constraints) {
+			arr.used = n
+			arr.mu.Unlock()
+			out.constraints = append(a.constraints, bg...)
+			out.groups = arr
+			return out
+		}
+		arr.mu.Unlock()
+	}
+
+	// Copy into a new array with room to grow.
+	groups := make([][]*constraint, n, 2*n)
+	copy(groups, ag)
+	copy(groups[len(ag):], bg)
+	out.constraints = groups
+	out.groups = &groupArray{used: n}
+	return out
+}
+
+// Intersect returns constraints admitting the versions both a and b admit,
+// such as `>=1.2, <1.5` for >=1.2 and <1.5. Each || separated group of a is
+// joined with each group of b, so `1.x || 2.x` intersected with `<1.5 || 2.4.x`
+// has four groups. The clauses of each joined group are ordered by their
+// version, dropping repeated clauses. Neither a nor b is modified. A nil a or
+// b admits no versions, so intersecting with it results in constraints that
+// admit none.
+//
+// Options are kept for each clause in the same way as with Union, so the
+// intersection admits a version exactly when both a and b do. With
+// PrereleaseScoped the clauses joined from each group keep the prereleases
+// that group names, so `>=1.0.0-beta` intersected with `<2.0.0` does not admit
+// 1.0.0-rc.1. Intersect is commutative and associative in the same way as
+// Union.
+func Intersect(a, b *Constraints) *Constraints {
+	return intersect(a, b, true)
+}
+
+// intersect returns the intersection of a and b. When apart is false the
+// clauses of b checked with PrereleaseScoped join the scope of the groups of
+// a rather than keeping their own, which suits bounds that narrow the groups
+// without naming prereleases of their own.
+func intersect(a, b *Constraints, apart bool) *Constraints {
+	a, b = orNone(a, b), orNone(b, a)
+	opts := combineOptions(a.opts, b.opts)
+	ag, bg := keepOptions(a, opts), keepOptions(b, opts)
+	groups := make([][]*constraint, 0, len(ag)*len(bg))
+	for _, ga := range ag {
+		for _, gb := range bg {
+			groups = append(groups, joinGroups(ga, gb, &opts, apart))
+		}
+	}
+	return &Constraints{constraints: groups, opts: opts}
+}
+
+// keepOptions returns the groups of c for constraints with the options opts.
+// When c has other options its clauses are copied to hold the options of c,
+// so they are still checked the way they were created. Clauses that already
+// hold options of their own keep them.
+func keepOptions(c *Constraints, opts MatchOptions) [][]*constraint {
+	if c.opts == opts {
+		return c.constraints
+	}
+	o := c.opts
+	out := make([][]*constraint, len(c.constraints))
+	for k, group := range c.constraints {
+		g := make([]*constraint, len(group))
+		for i, cl := range group {
+			if cl.opts == nil {
+				z := *cl
+				z.opts = &o
+				cl = &z
+			}
+			g[i] = cl
+		}
+		out[k] = g
+	}
+	return out
+}
+
+// joinGroups returns the clauses of a and b ordered by their version, their
+// text, their options, and then their scope, with repeated clauses dropped,
+// so the result is the same whichever order a and b are joined in. Clauses
+// without options of their own have the options o.
+//
+// With PrereleaseScoped a group only admits the prereleases it names, so when
+// apart is true the clauses of b checked with it are given scopes apart from
+// those of a. A prerelease named by one group then does not let it through
+// the other. Otherwise they join the last scope of a.
+func joinGroups(a, b []*constraint, o *MatchOptions, apart bool) []*constraint {
+	g := make([]*constraint, 0, len(a)+len(b))
+	offset := maxScope(a)
+	if apart {
+		offset++
+	}
+	g = append(g, a...)
+	g = append(g, scoped(b, offset, o)...)
+	sort.SliceStable(g, func(i, j int) bool {
+		if d := g[i].con.Compare(g[j].con); d != 0 {
+			return d < 0
+		}
+		if si, sj := g[i].string(), g[j].string(); si != sj {
+			return si < sj
+		}
+		if oi, oj := *clauseOptions(g[i], o), *clauseOptions(g[j], o); oi != oj {
+			return optionsLess(oi, oj)
+		}
+		return g[i].scope < g[j].scope
+	})
+
+	out := g[:0]
+	for k, c := range g {
+		if k == 0 || !sameClause(c, g[k-1], o) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// sameClause reports if two clauses are written the same way and are checked
+// with the same options and scope.
+func sameClause(a, b *constraint, o *MatchOptions) bool {
+	return a.string() == b.string() && *clauseOptions(a, o) == *clauseOptions(b, o) && a.scope == b.scope
+}
+
+// maxScope returns the greatest scope of the clauses in an AND group.
+func maxScope(group []*constraint) int {
+	m := 0
+	for _, c := range group {
+		if c.scope > m {
+			m = c.scope
+		}
+	}
+	return m
+}
+
+// scoped returns the clauses of an AND group with the scopes of those checked
+// with PrereleaseScoped moved up by offset, copying the clauses moved. Clauses checked with another
+// policy have no scope, so they are returned as they are.
+func scoped(group []*constraint, offset int, o *MatchOptions) []*constraint {
+	out := make([]*constraint, len(group))
+	for k, c := range group {
+		if offset != 0 && clauseOptions(c, o).Prerelease == PrereleaseScoped {
+			z := *c
+			z.scope += offset
+			c = &z
+		}
+		out[k] = c
+	}
+	return out
+}
+
+// combineOptions returns the options for the result of combining constraints
+// with the options a and b, which are those reported by Options and used for
+// the clauses without options of their own. When they differ the options
+// that are not the defaults are used, and when neither are the defaults the
+// options greater comparing their fields in order, so the choice does not
+// depend on the order a and b are given in. The clauses of the other side
+// keep their options, as done by keepOptions.
+func combineOptions(a, b MatchOptions) MatchOptions {
+	if optionsLess(a, b) {
+		return b
+	}
+	return a
+}
+
+// optionsLess orders options by comparing their fields in order. The default
+// options are less than any others.
+func optionsLess(a, b MatchOptions) bool {
+	fa, fb := optionFields(a), optionFields(b)
+	for k := range fa {
+		if fa[k] != fb[k] {
+			return fa[k] < fb[k]
+		}
+	}
+	return false
+}
+
+// optionFields returns the fields of options as integers, in order.
+func optionFields(o MatchOptions) [8]int {
+	return [8]int{
+		int(o.Prerelease), int(o.Metadata), int(o.Caret), int(o.Tilde),
+		int(o.Partial), int(o.Pessimistic), boolInt(o.FoldPrereleaseCase), boolInt(o.LegacyPrereleaseOrder),
+	}
+}
+
+// boolInt returns 1 for true and 0 for false.
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// orNone returns c or, when c is nil, constraints admitting no versions with
+// the options of other.
+func orNone(c, other *Constraints) *Constraints {
+	if c != nil {
+		return c
+	}
+	if other == nil {
+		return &Constraints{}
+	}
+	return &Constraints{opts: other.opts}
+}
+
+// And returns constraints admitting the versions both cs and o admit. It is
+// the same as Intersect(cs, o), so constraints can be narrowed a step at a
+// time without joining strings and parsing them again.
+func (cs *Constraints) And(o *Constraints) *Constraints {
+	return Intersect(cs, o)
+}
+
+// Or returns constraints admitting the versions either cs or o admits. It is
+// the same as Union(cs, o).
+func (cs *Constraints) Or(o *Constraints) *Constraints {
+	return Union(cs, o)
+}
+
+// AndString parses o with the options of cs and returns the constraints
+// admitting the versions both admit. Only o is parsed.
+func (cs *Constraints) AndString(o string) (*Constraints, error) {
+	oc, err := cs.parseWithOptions(o)
+	if err != nil {
+		return nil, err
+	}
+	return Intersect(cs, oc), nil
+}
+
+// OrString parses o with the options of cs and returns the constraints
+// admitting the versions either admits. Only o is parsed.
+func (cs *Constraints) OrString(o string) (*Constraints, error) {
+	oc, err := cs.parseWithOptions(o)
+	if err != nil {
+		return nil, err
+	}
+	return Union(cs, oc), nil
+}
+
+// parseWithOptions parses c with the options of cs, or the default options
+// when cs is nil.
+func (cs *Constraints) parseWithOptions(c string) (*Constraints, error) {
+	var opts MatchOptions
+	if cs != nil {
+		opts = cs.opts
+	}
+	return NewConstraintWithOptions(c, opts)
+}
@@ -0,0 +1,432 @@
+package semver
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"testing/quick"
+)
+
+func TestUnion(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected string
+		admits   []string
+		rejects  []string
+	}{
+		{"^1.2", "^2.0", "^1.2 || ^2.0", []string{"1.2.0", "1.9.9", "2.4.0"}, []string{"1.1.0", "3.0.0"}},
+		{">=1, <2 || >=3", "2.5.x", ">=1 <2 || 2.5.x || >=3", []string{"1.5.0", "2.5.1", "3.1.0"}, []string{"2.4.0"}},
+		{"1.0.0", "1.0.0", "1.0.0 || 1.0.0", []string{"1.0.0"}, []string{"1.0.1"}},
+	}
+
+	for _, tc := range tests {
+		u := Union(mustConstraint(t, tc.a), mustConstraint(t, tc.b))
+		if s := u.String(); s != tc.expected {
+			t.Errorf("Expected the union of %s and %s to be %s but got %s", tc.a, tc.b, tc.expected, s)
+		}
+		for _, v := range tc.admits {
+			if !u.Check(MustParse(v)) {
+				t.Errorf("Expected the union of %s and %s to admit %s", tc.a, tc.b, v)
+			}
+		}
+		for _, v := range tc.rejects {
+			if u.Check(MustParse(v)) {
+				t.Errorf("Expected the union of %s and %s not to admit %s", tc.a, tc.b, v)
+			}
+		}
+	}
+}
+
+func TestUnionSharing(t *testing.T) {
+	base := Union(mustConstraint(t, "^1"), mustConstraint(t, "^2"))
+	x := Union(base, mustConstraint(t, "^3"))
+	y := Union(base, mustConstraint(t, "^4"))
+	z := Union(x, mustConstraint(t, "^5"))
+
+	expected := map[*Constraints]string{
+		base: "^1 || ^2",
+		x:    "^1 || ^2 || ^3",
+		y:    "^1 || ^2 || ^4",
+		z:    "^1 || ^2 || ^3 || ^5",
+	}
+	for c, e := range expected {
+		if s := c.String(); s != e {
+			t.Errorf("Expected %s but got %s", e, s)
+		}
+	}
+	if &x.constraints[0] != &base.constraints[0] || &z.constraints[0] != &x.constraints[0] {
+		t.Error("Expected unions extending the longest prefix to share their backing array")
+	}
+	if &y.constraints[0] == &base.constraints[0] {
+		t.Error("Expected a union extending a shorter prefix to be copied")
+	}
+}
+
+func TestUnionConcurrent(t *testing.T) {
+	base := Union(mustConstraint(t, "^1"), mustConstraint(t, "^2"))
+	results := make([]*Constraints, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := NewConstraint(fmt.Sprintf("^%d", i+10))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			results[i] = Union(base, c)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, c := range results {
+		if e := fmt.Sprintf("^1 || ^2 || ^%d", i+10); c.String() != e {
+			t.Errorf("Expected %s but got %s", e, c)
+		}
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected string
+		admits   []string
+		rejects  []string
+	}{
+		{">=1.2", "<1.5", ">=1.2 <1.5", []string{"1.2.0", "1.4.9"}, []string{"1.1.0", "1.5.0"}},
+		{"1.x || 2.x", "<1.5 || 2.4.x", "1.x <1.5 || 2.x 2.4.x || 1.x 2.4.x || <1.5 2.x", []string{"1.4.0", "2.4.1"}, []string{"1.5.0", "2.3.0"}},
+		{"^1", "^2", "^1 ^2", nil, []string{"1.5.0", "2.0.0"}},
+		{"<2, >=1.2", ">=1.2", ">=1.2 <2", []string{"1.2.0"}, []string{"2.0.0"}},
+	}
+
+	for _, tc := range tests {
+		c := Intersect(mustConstraint(t, tc.a), mustConstraint(t, tc.b))
+		if s := c.String(); s != tc.expected {
+			t.Errorf("Expected the intersection of %s and %s to be %s but got %s", tc.a, tc.b, tc.expected, s)
+		}
+		for _, v := range tc.admits {
+			if !c.Check(MustParse(v)) {
+				t.Errorf("Expected the intersection of %s and %s to admit %s", tc.a, tc.b, v)
+			}
+		}
+		for _, v := range tc.rejects {
+			if c.Check(MustParse(v)) {
+				t.Errorf("Expected the intersection of %s and %s not to admit %s", tc.a, tc.b, v)
+			}
+		}
+	}
+}
+
+func TestUnionIntersectOrderIndependent(t *testing.T) {
+	same := func(x, y *Constraints, v *Version) bool {
+		return x.String() == y.String() && x.Options() == y.Options() && x.Check(v) == y.Check(v)
+	}
+
+	commutative := func(a, b *Constraints, v *Version) bool {
+		return same(Union(a, b), Union(b, a), v) && same(Intersect(a, b), Intersect(b, a), v)
+	}
+	if err := quick.Check(commutative, nil); err != nil {
+		t.Error(err)
+	}
+
+	associative := func(a, b, c *Constraints, v *Version) bool {
+		return same(Union(Union(a, b), c), Union(a, Union(b, c)), v) &&
+			same(Intersect(Intersect(a, b), c), Intersect(a, Intersect(b, c)), v)
+	}
+	if err := quick.Check(associative, nil); err != nil {
+		t.Error(err)
+	}
+
+	// Options are chosen the same way whatever the order.
+	opts := []MatchOptions{
+		{},
+		{Caret: CaretMajor},
+		{Prerelease: PrereleaseInclude},
+		{Prerelease: PrereleaseInclude, FoldPrereleaseCase: true},
+		{LegacyPrereleaseOrder: true},
+	}
+	for _, oa := range opts {
+		for _, ob := range opts {
+			for _, oc := range opts {
+				a, _ := NewConstraintWithOptions("^1.2", oa)
+				b, _ := NewConstraintWithOptions(">=1.4 <3", ob)
+				c, _ := NewConstraintWithOptions("!=1.5.0", oc)
+				v := MustParse("1.5.0")
+				if !same(Union(a, b), Union(b, a), v) || !same(Intersect(a, b), Intersect(b, a), v) {
+					t.Errorf("Expected combining %+v and %+v not to depend on their order", oa, ob)
+				}
+				if !same(Union(Union(a, b), c), Union(a, Union(b, c)), v) ||
+					!same(Intersect(Intersect(a, b), c), Intersect(a, Intersect(b, c)), v) {
+					t.Errorf("Expected combining %+v, %+v, and %+v not to depend on their grouping", oa, ob, oc)
+				}
+			}
+		}
+	}
+}
+
+func TestUnionIntersectOptions(t *testing.T) {
+	a, err := NewConstraintWithOptions("^0.2", MatchOptions{Caret: CaretMajor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := mustConstraint(t, "^0.0.5")
+	versions := []string{"0.0.5", "0.0.9", "0.2.0", "0.9.0", "1.0.0"}
+
+	// Whichever order they are combined in, each side is checked with its
+	// own options.
+	for _, pair := range [][2]*Constraints{{a, b}, {b, a}} {
+		u, i := Union(pair[0], pair[1]), Intersect(pair[0], pair[1])
+		if u.Options() != a.Options() || i.Options() != a.Options() {
+			t.Error("Expected the result to have the options that are not the defaults")
+		}
+		for _, s := range versions {
+			v := MustParse(s)
+			if u.Check(v) != (a.Check(v) || b.Check(v)) {
+				t.Errorf("Expected %s to admit %s only when a side does", u, s)
+			}
+			if i.Check(v) != (a.Check(v) && b.Check(v)) {
+				t.Errorf("Expected %s to admit %s only when both sides do", i, s)
+			}
+		}
+		if !u.Check(MustParse("0.9.0")) || u.Check(MustParse("0.0.9")) {
+			t.Errorf("Expected %s to admit 0.9.0 by CaretMajor and not 0.0.9", u)
+		}
+	}
+
+	c := Intersect(a, mustConstraint(t, "*"))
+	if !c.Check(MustParse("0.9.0")) {
+		t.Errorf("Expected %s to admit 0.9.0 with CaretMajor", c)
+	}
+}
+
+func TestUnionIntersectMixedOptions(t *testing.T) {
+	major := MatchOptions{Caret: CaretMajor}
+	c := func(s string, o MatchOptions) *Constraints {
+		cs, err := NewConstraintWithOptions(s, o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cs
+	}
+
+	v := MustParse("0.0.9")
+	if u := Union(c("^2", major), mustConstraint(t, "^0.0.5")); u.Check(v) {
+		t.Errorf("Expected %s not to admit %s as neither side does", u, v)
+	}
+	if i := Intersect(c(">=0.0.1", major), mustConstraint(t, "^0.0.5")); i.Check(v) {
+		t.Errorf("Expected %s not to admit %s as ^0.0.5 does not", i, v)
+	}
+
+	// Appending in place to a shared array keeps the options as well.
+	u := Union(Union(c("^2", major), c("^3", major)), mustConstraint(t, "^0.0.5"))
+	if u.Check(v) || !u.Check(MustParse("0.0.5")) || !u.Check(MustParse("3.9.0")) {
+		t.Errorf("Expected %s to admit exactly what its parts do", u)
+	}
+
+	// The sets read each clause with its own options too.
+	if u := Union(c("^0.2", major), mustConstraint(t, "^0.2")); !u.Eq(c("^0.2", major)) {
+		t.Errorf("Expected %s to be equal to ^0.2 with CaretMajor", u)
+	}
+	if e := Explain(Intersect(c(">=0.0.1", major), mustConstraint(t, "^0.0.5")), v); e.Matched {
+		t.Errorf("Expected Explain not to match %s", v)
+	}
+
+	opts := []MatchOptions{
+		{},
+		major,
+		{Prerelease: PrereleaseInclude},
+		{Prerelease: PrereleaseScoped},
+		{Metadata: MetadataEqual},
+		{Partial: PartialZero},
+	}
+	constraints := []string{"^2", "^0.0.5", ">=0.0.1", ">=1.2.3-beta.1 <1.3", "=1.2.3+abc", "~1.2 || ^0.1", "!=1.2.x"}
+	versions := []string{"0.0.5", "0.0.9", "0.1.0", "0.1.4-rc.1", "1.2.3-beta.2", "1.2.3+abc", "1.2.3+def", "1.2.5", "1.3.0-rc.1", "2.5.0"}
+
+	for _, oa := range opts {
+		for _, ob := range opts {
+			if oa == ob {
+				continue
+			}
+			for _, sa := range constraints {
+				for _, sb := range constraints {
+					a, b := c(sa, oa), c(sb, ob)
+					u, i := Union(a, b), Intersect(a, b)
+					for _, s := range versions {
+						v := MustParse(s)
+						inA, inB := a.Check(v), b.Check(v)
+						if u.Check(v) != (inA || inB) {
+							t.Errorf("Expected the union of %s with %+v and %s with %+v to admit %s: %t", sa, oa, sb, ob, s, inA || inB)
+						}
+						if i.Check(v) != (inA && inB) {
+							t.Errorf("Expected the intersection of %s with %+v and %s with %+v to admit %s: %t", sa, oa, sb, ob, s, inA && inB)
+						}
+						if ok, _ := u.Validate(v); ok != (inA || inB) {
+							t.Errorf("Expected Validate of the union of %s and %s to agree with Check for %s", sa, sb, s)
+						}
+						if ok, _ := i.Validate(v); ok != (inA && inB) {
+							t.Errorf("Expected Validate of the intersection of %s and %s to agree with Check for %s", sa, sb, s)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestUnionIntersectNil(t *testing.T) {
+	a, err := NewConstraintWithOptions("^1.2", MatchOptions{Caret: CaretMajor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := MustParse("1.4.0")
+
+	for _, c := range []*Constraints{Union(nil, a), Union(a, nil)} {
+		if c.String() != "^1.2" || c.Options() != a.Options() {
+			t.Errorf("Expected the union with nil to be %s with its options but got %s", a, c)
+		}
+		if !c.Check(v) {
+			t.Errorf("Expected %s to admit %s", c, v)
+		}
+	}
+
+	for _, c := range []*Constraints{Intersect(nil, a), Intersect(a, nil), Union(nil, nil), Intersect(nil, nil)} {
+		if c.Check(v) {
+			t.Errorf("Expected %q to admit no versions", c)
+		}
+		if ok, _ := c.Validate(v); ok {
+			t.Errorf("Expected %q to validate no versions", c)
+		}
+		if c.Status(v) != BAD {
+			t.Errorf("Expected %q to have a BAD status", c)
+		}
+	}
+	for _, c := range []*Constraints{Union(nil, nil), Union(a, nil), Intersect(a, nil)} {
+		if ok, err := c.Matches(nil); ok || !isNilVersionError(err) {
+			t.Errorf("Expected %q not to match nil but got %t %v", c, ok, err)
+		}
+	}
+	if Intersect(nil, a).Options() != a.Options() {
+		t.Error("Expected the intersection with nil to have the options of the other constraints")
+	}
+}
+
+func TestAndOr(t *testing.T) {
+	c := mustConstraint(t, "^1.2")
+	if got := c.And(mustConstraint(t, "<1.5")).String(); got != "^1.2 <1.5" {
+		t.Errorf("Expected ^1.2 <1.5 but got %s", got)
+	}
+	if got := c.Or(mustConstraint(t, "^2")).String(); got != "^1.2 || ^2" {
+		t.Errorf("Expected ^1.2 || ^2 but got %s", got)
+	}
+
+	narrowed, err := c.AndString("<1.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	widened, err := narrowed.OrString("~2.1 || ~2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := widened.String(); got != "^1.2 <1.5 || ~2.1 || ~2.3" {
+		t.Errorf("Expected ^1.2 <1.5 || ~2.1 || ~2.3 but got %s", got)
+	}
+	if c.String() != "^1.2" || narrowed.String() != "^1.2 <1.5" {
+		t.Error("Expected the constraints combined not to be modified")
+	}
+
+	if _, err := c.AndString("<<1"); err == nil {
+		t.Error("Expected an error for invalid constraints")
+	}
+	if _, err := c.OrString(""); err == nil {
+		t.Error("Expected an error for empty constraints")
+	}
+
+	var none *Constraints
+	if got, err := none.OrString("^3"); err != nil || got.String() != "^3" {
+		t.Errorf("Expected the union of nil and ^3 to be ^3 but got %v %v", got, err)
+	}
+	if got, err := none.AndString("^3"); err != nil || got.Check(MustParse("3.0.0")) {
+		t.Errorf("Expected the intersection with nil to admit nothing but got %v %v", got, err)
+	}
+}
+
+func TestAndStringOptions(t *testing.T) {
+	a, err := NewConstraintWithOptions(">=0.1", MatchOptions{Caret: CaretMajor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := a.AndString("^0.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Options() != a.Options() || !c.Check(MustParse("0.9.0")) {
+		t.Errorf("Expected ^0.2 to be parsed with CaretMajor so %s admits 0.9.0", c)
+	}
+}
+
+func TestIntersectScopedPrereleases(t *testing.T) {
+	scoped := MatchOptions{Prerelease: PrereleaseScoped}
+	a, _ := NewConstraintWithOptions(">=1.0.0-beta", scoped)
+	b, _ := NewConstraintWithOptions("<2.0.0", scoped)
+	v := MustParse("1.0.0-rc.1")
+	if i := Intersect(a, b); i.Check(v) {
+		t.Errorf("Expected %s not to admit %s as <2.0.0 does not", i, v)
+	}
+	if c, _ := a.AndString("<2.0.0"); c.Check(v) {
+		t.Errorf("Expected %s not to admit %s as <2.0.0 does not", c, v)
+	}
+
+	// A prerelease both sides name is admitted, however the clauses repeat.
+	c, _ := NewConstraintWithOptions(">=1.0.0-beta <2", scoped)
+	if i := Intersect(a, c); !i.Check(v) || !Intersect(c, c).Check(v) {
+		t.Errorf("Expected %s to admit %s as both sides do", i, v)
+	}
+}
+
+func TestIntersectRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	all := allMatchOptions()
+	for _, oa := range all {
+		// Each side is checked with the same options and then with others
+		// chosen at random.
+		for _, ob := range []MatchOptions{oa, all[r.Intn(len(all))]} {
+			for n := 0; n < 5; n++ {
+				sa, sb := randomSmallConstraint(r), randomSmallConstraint(r)
+				a, err := NewConstraintWithOptions(sa, oa)
+				if err != nil {
+					t.Fatalf("unexpected error parsing %q: %s", sa, err)
+				}
+				b, err := NewConstraintWithOptions(sb, ob)
+				if err != nil {
+					t.Fatalf("unexpected error parsing %q: %s", sb, err)
+				}
+				i := Intersect(a, b)
+				and, err := a.AndString(sb)
+				if err != nil {
+					t.Fatalf("unexpected error intersecting with %q: %s", sb, err)
+				}
+				may, must := i.sets()
+				for k := 0; k < 20; k++ {
+					v := randomSmallVersion(r)
+					want := a.Check(v) && b.Check(v)
+					// The sides may fold prereleases differently, so the
+					// sets are only compared for versions with none to fold.
+					if foldPrerelease(v) == v && (must.contains(v) && !want || want && !may.contains(v)) {
+						t.Errorf("Expected the sets of the intersection of %q and %q to bracket %s", sa, sb, v)
+					}
+					if i.Check(v) != want || a.And(b).Check(v) != want {
+						t.Errorf("Expected the intersection of %q with %+v and %q with %+v to admit %s: %t", sa, oa, sb, ob, v, want)
+					}
+					if ok, _ := i.Validate(v); ok != want {
+						t.Errorf("Expected Validate of the intersection of %q and %q to agree with Check for %s", sa, sb, v)
+					}
+					if ob == oa && and.Check(v) != want {
+						t.Errorf("Expected %q AndString %q with %+v to admit %s: %t", sa, sb, oa, v, want)
+					}
+				}
+			}
+		}
+	}
+}
@@ -6,14 +6,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
-// The compiled version of the regex created at init() is cached here so it
-// only needs to be created once.
-var versionRegex *regexp.Regexp
+// The compiled version of the regex is cached here so it only needs to be
+// created once, the first time a version is parsed.
+var versionRegex = newLazyRegexp("^" + semVerRegex + "$")
 
 var (
 	// ErrInvalidSemVer is returned a version is found to be invalid when
@@ -36,6 +36,10 @@ var (
 
 	// ErrInvalidPrerelease is returned when the pre-release is an invalid format
 	ErrInvalidPrerelease = errors.New("Invalid Prerelease string")
+
+	// ErrNilVersion is returned when a nil version is validated against
+	// constraints.
+	ErrNilVersion = errors.New("Version is nil")
 )
 
 // semVerRegex is the regular expression used to parse a semantic version.
@@ -46,13 +50,19 @@ const semVerRegex string = `v?([0-9]+)(\.[0-9]+)?(\.[0-9]+)?` +
 // Version represents a single semantic version.
 type Version struct {
 	major, minor, patch uint64
-	pre                 string
-	metadata            string
-	original            string
-}
 
-func init() {
-	versionRegex = regexp.MustCompile("^" + semVerRegex + "$")
+	// text is the original string the version was parsed from, followed by
+	// the prerelease and metadata when the original does not end with them.
+	// The original, prerelease, and metadata are located in text by offsets
+	// rather than kept as strings of their own, so a Version takes 56 bytes
+	// rather than 72. This adds up when holding millions of them.
+	text string
+
+	// origEnd is the end of the original in text, preStart is the start of
+	// the prerelease, and metaStart is the start of the metadata, which runs
+	// to the end of text. The prerelease ends at the + before the metadata or,
+	// without metadata, at the end of text.
+	origEnd, preStart, metaStart uint32
 }
 
 const num string = "0123456789"
@@ -64,12 +74,29 @@ const allowed string = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-" +
 // If you want to coerce a version, such as 1 or 1.2, and perse that as the 1.x
 // releases of semver provided use the NewSemver() function.
 func StrictNewVersion(v string) (*Version, error) {
+	h := currentHooks()
+	if h == nil {
+		return strictNewVersion(v)
+	}
+	start := time.Now()
+	sv, err := strictNewVersion(v)
+	parseHook(h, ParseStrictVersion, v, start, false, err)
+	return sv, err
+}
+
+func strictNewVersion(v string) (*Version, error) {
 	// Parsing here does not use RegEx in order to increase performance and reduce
 	// allocations.
 
 	if len(v) == 0 {
 		return nil, ErrEmptyString
 	}
+	if err := checkVersionLimits(v); err != nil {
+		return nil, err
+	}
+	if err := checkASCII(v); err != nil {
+		return nil, err
+	}
 
 	// Split the parts into [0]major, [1]minor, and [2]patch,prerelease,build
 	parts := strings.SplitN(v, ".", 3)
@@ -77,9 +104,8 @@ func StrictNewVersion(v string) (*Version, error) {
 		return nil, ErrInvalidSemVer
 	}
 
-	sv := &Version{
-		original: v,
-	}
+	sv := &Version{}
+	var pre, metadata string
 
 	// check for prerelease or build metadata
 	var extra []string
@@ -88,14 +114,14 @@ func StrictNewVersion(v string) (*Version, error) {
 		extra = strings.SplitN(parts[2], "+", 2)
 		if len(extra) > 1 {
 			// build metadata found
-			sv.metadata = extra[1]
+			metadata = extra[1]
 			parts[2] = extra[0]
 		}
 
 		extra = strings.SplitN(parts[2], "-", 2)
 		if len(extra) > 1 {
 			// prerelease found
-			sv.pre = extra[1]
+			pre = extra[1]
 			parts[2] = extra[0]
 		}
 	}
@@ -129,19 +155,21 @@ func StrictNewVersion(v string) (*Version, error) {
 		return nil, err
 	}
 
+	sv.set(pre, metadata, v)
+
 	// No prerelease or build metadata found so returning now as a fastpath.
-	if sv.pre == "" && sv.metadata == "" {
+	if pre == "" && metadata == "" {
 		return sv, nil
 	}
 
-	if sv.pre != "" {
-		if err = validatePrerelease(sv.pre); err != nil {
+	if pre != "" {
+		if err = validatePrerelease(pre); err != nil {
 			return nil, err
 		}
 	}
 
-	if sv.metadata != "" {
-		if err = validateMetadata(sv.metadata); err != nil {
+	if metadata != "" {
+		if err = validateMetadata(metadata); err != nil {
 			return nil, err
 		}
 	}
@@ -154,16 +182,30 @@ func StrictNewVersion(v string) (*Version, error) {
 // attempts to convert it to SemVer. If you want  to validate it was a strict
 // semantic version at parse time see StrictNewVersion().
 func NewVersion(v string) (*Version, error) {
+	h := currentHooks()
+	if h == nil {
+		return newVersion(v)
+	}
+	start := time.Now()
+	sv, err := newVersion(v)
+	parseHook(h, ParseVersion, v, start, false, err)
+	return sv, err
+}
+
+func newVersion(v string) (*Version, error) {
+	if err := checkVersionLimits(v); err != nil {
+		return nil, err
+	}
 	m := versionRegex.FindStringSubmatch(v)
 	if m == nil {
+		if err := checkASCII(v); err != nil {
+			return nil, err
+		}
 		return nil, ErrInvalidSemVer
 	}
 
-	sv := &Version{
-		metadata: m[8],
-		pre:      m[5],
-		original: v,
-	}
+	sv := &Version{}
+	sv.set(m[5], m[8], v)
 
 	var err error
 	sv.major, err = strconv.ParseUint(m[1], 10, 64)
@@ -192,14 +234,14 @@ func NewVersion(v string) (*Version, error) {
 	// Perform some basic due diligence on the extra parts to ensure they are
 	// valid.
 
-	if sv.pre != "" {
-		if err = validatePrerelease(sv.pre); err != nil {
+	if m[5] != "" {
+		if err = validatePrerelease(m[5]); err != nil {
 			return nil, err
 		}
 	}
 
-	if sv.metadata != "" {
-		if err = validateMetadata(sv.metadata); err != nil {
+	if m[8] != "" {
+		if err = validateMetadata(m[8]); err != nil {
 			return nil, err
 		}
 	}
@@ -207,6 +249,18 @@ func NewVersion(v string) (*Version, error) {
 	return sv, nil
 }
 
+// LooksLikeVersion reports if a string has the form of a version NewVersion
+// parses, without parsing it. It checks the string byte by byte and does not
+// allocate, so it is a cheap way to discard branch names, commit hashes, and
+// other strings that are not versions from a large list before parsing the
+// rest. Every string NewVersion parses looks like a version. The few strings
+// that look like one but fail to parse have a segment too large for a
+// uint64 or a numeric prerelease identifier with a leading zero.
+func LooksLikeVersion(s string) bool {
+	_, _, _, ok := scanVersion(s)
+	return ok
+}
+
 // MustParse parses a given version and panics on error.
 func MustParse(v string) *Version {
 	sv, err := NewVersion(v)
@@ -225,19 +279,23 @@ func (v Version) String() string {
 	var buf bytes.Buffer
 
 	fmt.Fprintf(&buf, "%d.%d.%d", v.major, v.minor, v.patch)
-	if v.pre != "" {
-		fmt.Fprintf(&buf, "-%s", v.pre)
+	if pre := v.Prerelease(); pre != "" {
+		fmt.Fprintf(&buf, "-%s", pre)
 	}
-	if v.metadata != "" {
-		fmt.Fprintf(&buf, "+%s", v.metadata)
+	if metadata := v.Metadata(); metadata != "" {
+		fmt.Fprintf(&buf, "+%s", metadata)
 	}
 
 	return buf.String()
 }
 
-// Original returns the original value passed in to be parsed.
+// Original returns the original value passed in to be parsed. It is empty
+// for a nil version.
 func (v *Version) Original() string {
-	return v.original
+	if v == nil {
+		return ""
+	}
+	return v.text[:v.origEnd]
 }
 
 // Major returns the major version.
@@ -257,20 +315,74 @@ func (v Version) Patch() uint64 {
 
 // Prerelease returns the pre-release version.
 func (v Version) Prerelease() string {
-	return v.pre
+	end := v.metaStart
+	if int(end) < len(v.text) {
+		end--
+	}
+	return v.text[v.preStart:end]
 }
 
 // Metadata returns the metadata on the version.
 func (v Version) Metadata() string {
-	return v.metadata
+	return v.text[v.metaStart:]
+}
+
+// set sets the prerelease, metadata, and original of the version. The
+// original is kept as text when it ends with the prerelease and metadata, as
+// it does for every parsed version, and is otherwise followed by them.
+func (v *Version) set(pre, metadata, original string) {
+	text := original
+	if !hasSuffixParts(original, pre, metadata) {
+		if pre != "" {
+			text += "-" + pre
+		}
+		if metadata != "" {
+			text += "+" + metadata
+		}
+	}
+
+	v.text = text
+	v.origEnd = uint32(len(original))
+	v.metaStart = uint32(len(text) - len(metadata))
+	preEnd := v.metaStart
+	if metadata != "" {
+		preEnd--
+	}
+	v.preStart = preEnd - uint32(len(pre))
+}
+
+// setCanonical sets the prerelease and metadata of the version along with an
+// original of its canonical form after the prefix.
+func (v *Version) setCanonical(pre, metadata, prefix string) {
+	v.set(pre, metadata, "")
+	v.set(pre, metadata, prefix+v.String())
+}
+
+// hasSuffixParts reports if s ends with the prerelease and metadata in the
+// form -pre+metadata, leaving out either when it is empty.
+func hasSuffixParts(s, pre, metadata string) bool {
+	if metadata != "" {
+		n := len(s) - len(metadata) - 1
+		if n < 0 || s[n] != '+' || s[n+1:] != metadata {
+			return false
+		}
+		s = s[:n]
+	}
+	if pre != "" {
+		n := len(s) - len(pre) - 1
+		if n < 0 || s[n] != '-' || s[n+1:] != pre {
+			return false
+		}
+	}
+	return true
 }
 
 // originalVPrefix returns the original 'v' prefix if any.
 func (v Version) originalVPrefix() string {
 
 	// Note, only lowercase v is supported as a prefix by the parser.
-	if v.original != "" && v.original[:1] == "v" {
-		return v.original[:1]
+	if original := v.Original(); original != "" && original[:1] == "v" {
+		return original[:1]
 	}
 	return ""
 }
@@ -286,15 +398,10 @@ func (v Version) IncPatch() Version {
 	// Pre-release versions have a lower precedence than the associated normal version.
 	// according to http://semver.org/#spec-item-10
 	// Build metadata SHOULD be ignored when determining version precedence.
-	if v.pre != "" {
-		vNext.metadata = ""
-		vNext.pre = ""
-	} else {
-		vNext.metadata = ""
-		vNext.pre = ""
+	if v.Prerelease() == "" {
 		vNext.patch = v.patch + 1
 	}
-	vNext.original = v.originalVPrefix() + "" + vNext.String()
+	vNext.setCanonical("", "", v.originalVPrefix())
 	return vNext
 }
 
@@ -305,11 +412,9 @@ func (v Version) IncPatch() Version {
 // Unsets prerelease status.
 func (v Version) IncMinor() Version {
 	vNext := v
-	vNext.metadata = ""
-	vNext.pre = ""
 	vNext.patch = 0
 	vNext.minor = v.minor + 1
-	vNext.original = v.originalVPrefix() + "" + vNext.String()
+	vNext.setCanonical("", "", v.originalVPrefix())
 	return vNext
 }
 
@@ -321,12 +426,10 @@ func (v Version) IncMinor() Version {
 // Unsets prerelease status.
 func (v Version) IncMajor() Version {
 	vNext := v
-	vNext.metadata = ""
-	vNext.pre = ""
 	vNext.patch = 0
 	vNext.minor = 0
 	vNext.major = v.major + 1
-	vNext.original = v.originalVPrefix() + "" + vNext.String()
+	vNext.setCanonical("", "", v.originalVPrefix())
 	return vNext
 }
 
@@ -339,8 +442,7 @@ func (v Version) SetPrerelease(prerelease string) (Version, error) {
 			return vNext, err
 		}
 	}
-	vNext.pre = prerelease
-	vNext.original = v.originalVPrefix() + "" + vNext.String()
+	vNext.setCanonical(prerelease, v.Metadata(), v.originalVPrefix())
 	return vNext, nil
 }
 
@@ -353,8 +455,7 @@ func (v Version) SetMetadata(metadata string) (Version, error) {
 			return vNext, err
 		}
 	}
-	vNext.metadata = metadata
-	vNext.original = v.originalVPrefix() + "" + vNext.String()
+	vNext.setCanonical(v.Prerelease(), metadata, v.originalVPrefix())
 	return vNext, nil
 }
 
@@ -382,7 +483,20 @@ func (v *Version) Equal(o *Version) bool {
 // lower than the version without a prerelease. Compare always takes into account
 // prereleases. If you want to work with ranges using typical range syntaxes that
 // skip prereleases if the range is not looking for them use constraints.
+//
+// A nil version is smaller than any other version and equal to another nil
+// version, so a nil never causes a panic.
 func (v *Version) Compare(o *Version) int {
+	return v.compare(o, false)
+}
+
+// compare compares two versions. When legacy is true prerelease identifiers
+// are compared with the rules used by earlier releases of this package.
+func (v *Version) compare(o *Version, legacy bool) int {
+	if v == nil || o == nil {
+		return compareNil(v, o)
+	}
+
 	// Compare the major, minor, and patch version for differences. If a
 	// difference is found return the comparison.
 	if d := compareSegment(v.Major(), o.Major()); d != 0 {
@@ -396,7 +510,7 @@ func (v *Version) Compare(o *Version) int {
 	}
 
 	// At this point the major, minor, and patch versions are the same.
-	ps := v.pre
+	ps := v.Prerelease()
 	po := o.Prerelease()
 
 	if ps == "" && po == "" {
@@ -409,7 +523,29 @@ func (v *Version) Compare(o *Version) int {
 		return -1
 	}
 
-	return comparePrerelease(ps, po)
+	return comparePrerelease(ps, po, legacy)
+}
+
+// compareNil orders versions when at least one of them is nil. A nil version
+// is smaller than any other.
+func compareNil(v, o *Version) int {
+	switch {
+	case v == o:
+		return 0
+	case v == nil:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// CompareWithOptions compares this version to another one the same way as
+// Compare while applying the options. For example, the FoldPrereleaseCase
+// option makes 1.0.0-RC.1 equal to 1.0.0-rc.1 and the MetadataOrdered policy
+// orders versions that only differ by their metadata. Options that only
+// apply to constraints are ignored.
+func (v *Version) CompareWithOptions(o *Version, opts MatchOptions) int {
+	return opts.compare(v, o)
 }
 
 // UnmarshalJSON implements JSON.Unmarshaler interface.
@@ -422,12 +558,7 @@ func (v *Version) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		return err
 	}
-	v.major = temp.major
-	v.minor = temp.minor
-	v.patch = temp.patch
-	v.pre = temp.pre
-	v.metadata = temp.metadata
-	v.original = temp.original
+	*v = *temp
 	return nil
 }
 
@@ -444,12 +575,7 @@ func (v *Version) Scan(value interface{}) error {
 	if err != nil {
 		return err
 	}
-	v.major = temp.major
-	v.minor = temp.minor
-	v.patch = temp.patch
-	v.pre = temp.pre
-	v.metadata = temp.metadata
-	v.original = temp.original
+	*v = *temp
 	return nil
 }
 
@@ -469,7 +595,10 @@ func compareSegment(v, o uint64) int {
 	return 0
 }
 
-func comparePrerelease(v, o string) int {
+// comparePrerelease compares prereleases following section 11 of the spec.
+// When legacy is true the rules used by earlier releases of this package are
+// used instead. See MatchOptions.LegacyPrereleaseOrder for the differences.
+func comparePrerelease(v, o string, legacy bool) int {
 
 	// split the prelease versions by their part. The separator, per the spec,
 	// is a .
@@ -500,7 +629,7 @@ func comparePrerelease(v, o string) int {
 			otemp = oparts[i]
 		}
 
-		d := comparePrePart(stemp, otemp)
+		d := comparePrePart(stemp, otemp, legacy)
 		if d != 0 {
 			return d
 		}
@@ -512,7 +641,7 @@ func comparePrerelease(v, o string) int {
 	return 0
 }
 
-func comparePrePart(s, o string) int {
+func comparePrePart(s, o string, legacy bool) int {
 	// Fastpath if they are equal
 	if s == o {
 		return 0
@@ -534,6 +663,51 @@ func comparePrePart(s, o string) int {
 		return -1
 	}
 
+	if legacy {
+		return compareLegacyPrePart(s, o)
+	}
+
+	// Identifiers consisting of only digits are numeric and are compared
+	// numerically no matter their size. Numeric identifiers always have lower
+	// precedence than alphanumeric ones. A - (e.g., -99) makes an identifier
+	// alphanumeric.
+	sn := containsOnly(s, num)
+	on := containsOnly(o, num)
+	switch {
+	case sn && on:
+		return compareNumeric(s, o)
+	case sn:
+		return -1
+	case on:
+		return 1
+	}
+
+	// Alphanumeric identifiers are compared lexically in ASCII sort order.
+	if s > o {
+		return 1
+	}
+	return -1
+}
+
+// compareNumeric compares two strings of digits by their numeric value.
+// Numbers can only be equal with differing strings when one has leading
+// zeros, which build metadata allows.
+func compareNumeric(s, o string) int {
+	s = strings.TrimLeft(s, "0")
+	o = strings.TrimLeft(o, "0")
+	if len(s) != len(o) {
+		if len(s) > len(o) {
+			return 1
+		}
+		return -1
+	}
+	return strings.Compare(s, o)
+}
+
+// compareLegacyPrePart compares two non-empty, differing prerelease
+// identifiers the way earlier releases of this package did. Numeric
+// identifiers too large for a uint64 are treated as alphanumeric.
+func compareLegacyPrePart(s, o string) int {
 	// When comparing strings "99" is greater than "103". To handle
 	// cases like this we need to detect numbers and compare them. According
 	// to the semver spec, numbers are always positive. If there is a - at the
@@ -561,8 +735,10 @@ func comparePrePart(s, o string) int {
 	if si > oi {
 		return 1
 	}
-	return -1
-
+	if si < oi {
+		return -1
+	}
+	return 0
 }
 
 // Like strings.ContainsAny but does an only instead of any.
@@ -579,6 +755,9 @@ func containsOnly(s string, comp string) bool {
 func validatePrerelease(p string) error {
 	eparts := strings.Split(p, ".")
 	for _, p := range eparts {
+		if p == "" {
+			return ErrInvalidPrerelease
+		}
 		if containsOnly(p, num) {
 			if len(p) > 1 && p[0] == '0' {
 				return ErrSegmentStartsZero
@@ -591,6 +770,38 @@ func validatePrerelease(p string) error {
 	return nil
 }
 
+// trimPrereleaseZeros removes the leading zeros of the numeric prerelease
+// identifiers of a version, so 1.0.0-01+build.01 becomes 1.0.0-1+build.01.
+func trimPrereleaseZeros(v string) string {
+	start := strings.IndexByte(v, '-')
+	if start < 0 {
+		return v
+	}
+	end := strings.IndexByte(v, '+')
+	if end < 0 {
+		end = len(v)
+	} else if end < start {
+		return v
+	}
+	return v[:start+1] + normalizePrerelease(v[start+1:end]) + v[end:]
+}
+
+// normalizePrerelease removes the leading zeros of the numeric identifiers of
+// a prerelease, so 01.alpha.00 becomes 1.alpha.0.
+func normalizePrerelease(p string) string {
+	parts := strings.Split(p, ".")
+	for k, id := range parts {
+		if len(id) > 1 && id[0] == '0' && containsOnly(id, num) {
+			id = strings.TrimLeft(id, "0")
+			if id == "" {
+				id = "0"
+			}
+			parts[k] = id
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
 // From the spec, "Build metadata MAY be denoted by
 // appending a plus sign and a series of dot separated identifiers immediately
 // following the patch or pre-release version. Identifiers MUST comprise only
@@ -598,7 +809,7 @@ func validatePrerelease(p string) error {
 func validateMetadata(m string) error {
 	eparts := strings.Split(m, ".")
 	for _, p := range eparts {
-		if !containsOnly(p, allowed) {
+		if p == "" || !containsOnly(p, allowed) {
 			return ErrInvalidMetadata
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"unsafe"
 )
 
 func TestStrictNewVersion(t *testing.T) {
@@ -99,6 +100,112 @@ func TestNewVersion(t *testing.T) {
 	}
 }
 
+func TestLooksLikeVersion(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected bool
+	}{
+		{"1.2.3", true},
+		{"v1.2.3", true},
+		{"1", true},
+		{"v1.2", true},
+		{"1.2.3-beta.1+build.5", true},
+		{"1.2-5", true},
+		{"0123456", true},
+		{"", false},
+		{"v", false},
+		{"main", false},
+		{"release/1.2", false},
+		{"feature-1.2", false},
+		{"deadbeefcafe", false},
+		{"1.2.3.4", false},
+		{"1.2.3-", false},
+		{"1.2.3-a..b", false},
+		{"1.2.3+", false},
+		{"1.2.3 ", false},
+		{"V1.2.3", false},
+		{"1.2.3-é", false},
+
+		// These look like versions but fail to parse.
+		{"99999999999999999999.0.0", true},
+		{"1.2.3-01", true},
+	}
+
+	for _, tc := range tests {
+		if a := LooksLikeVersion(tc.s); a != tc.expected {
+			t.Errorf("Expected LooksLikeVersion(%q) to be %t but got %t", tc.s, tc.expected, a)
+		}
+		if _, err := NewVersion(tc.s); err == nil && !tc.expected {
+			t.Errorf("Expected %q not to parse as it does not look like a version", tc.s)
+		}
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		LooksLikeVersion("1.2.3-beta.1+build.5")
+		LooksLikeVersion("deadbeefcafe")
+	})
+	if allocs != 0 {
+		t.Errorf("Expected LooksLikeVersion not to allocate but got %v allocations", allocs)
+	}
+}
+
+func TestPrereleaseLeadingZeros(t *testing.T) {
+	tests := []struct {
+		version    string
+		prerelease string
+	}{
+		{"1.0.0-01", "1"},
+		{"1.0.0-00", "0"},
+		{"1.0.0-alpha.010", "alpha.10"},
+		{"1.0.0-0a.01.0", "0a.1.0"},
+		{"v1.02-001+build.01", "1"},
+	}
+
+	for _, tc := range tests {
+		// Only the lenient parser accepts leading zeros.
+		if _, err := StrictNewVersion(tc.version); err == nil {
+			t.Errorf("Expected %q to fail to parse strictly", tc.version)
+		}
+		if _, err := NewVersion(tc.version); err != ErrSegmentStartsZero {
+			t.Errorf("Expected ErrSegmentStartsZero for %q but got %v", tc.version, err)
+		}
+
+		v, _, err := LenientNewVersion(tc.version)
+		if err != nil {
+			t.Errorf("Expected %q to parse but got %s", tc.version, err)
+			continue
+		}
+		if v.Prerelease() != tc.prerelease {
+			t.Errorf("Expected prerelease %q for %q but got %q", tc.prerelease, tc.version, v.Prerelease())
+		}
+		if v.Original() != tc.version {
+			t.Errorf("Expected original %q but got %q", tc.version, v.Original())
+		}
+	}
+
+	if _, err := StrictNewVersion("1.0.0-01"); err != ErrSegmentStartsZero {
+		t.Errorf("Expected ErrSegmentStartsZero but got %v", err)
+	}
+
+	// Leading zeros do not change the order of versions.
+	lenient := func(s string) *Version {
+		v, _, err := LenientNewVersion(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	order := []string{"1.0.0-0", "1.0.0-01", "1.0.0-2", "1.0.0-010", "1.0.0-alpha.9", "1.0.0-alpha.010", "1.0.0"}
+	for k := 0; k+1 < len(order); k++ {
+		if c := lenient(order[k]).Compare(lenient(order[k+1])); c != -1 {
+			t.Errorf("Expected %s to come before %s but got %d", order[k], order[k+1], c)
+		}
+	}
+	if !lenient("1.0.0-01").Equal(MustParse("1.0.0-1")) {
+		t.Error("Expected 1.0.0-01 to equal 1.0.0-1")
+	}
+}
+
 func TestOriginal(t *testing.T) {
 	tests := []string{
 		"1.2.3",
@@ -132,6 +239,49 @@ func TestOriginal(t *testing.T) {
 	}
 }
 
+func TestVersionSize(t *testing.T) {
+	// The prerelease and metadata are offsets into the original rather than
+	// strings of their own. See BenchmarkVersionMemory.
+	if s := unsafe.Sizeof(Version{}); s != 56 && unsafe.Sizeof(uintptr(0)) == 8 {
+		t.Errorf("Expected a Version to take 56 bytes but it takes %d", s)
+	}
+}
+
+func TestVersionText(t *testing.T) {
+	tests := []struct {
+		pre, metadata, original string
+	}{
+		{"", "", ""},
+		{"", "", "1.2.3"},
+		{"beta.1", "", "v1.2.3-beta.1"},
+		{"", "build.5", "1.2.3+build.5"},
+		{"beta.1", "build.5", "1.2.3-beta.1+build.5"},
+		{"beta.1", "build-5", "1.2-beta.1+build-5"},
+
+		// Originals not ending with the prerelease and metadata, as for
+		// versions read in other formats.
+		{"", "", "1.2.3-beta"},
+		{"beta", "", "1.2.3-BETA"},
+		{"", "gke.1", "v1.27.3-gke.1"},
+		{"rc.1", "eks", "1.28.0-rc.1-eks"},
+		{"a", "b", "1.2.3+b-a"},
+	}
+
+	for _, tc := range tests {
+		var v Version
+		v.set(tc.pre, tc.metadata, tc.original)
+		if a := v.Prerelease(); a != tc.pre {
+			t.Errorf("Expected prerelease %q for %q but got %q", tc.pre, tc.original, a)
+		}
+		if a := v.Metadata(); a != tc.metadata {
+			t.Errorf("Expected metadata %q for %q but got %q", tc.metadata, tc.original, a)
+		}
+		if a := v.Original(); a != tc.original {
+			t.Errorf("Expected original %q but got %q", tc.original, a)
+		}
+	}
+}
+
 func TestParts(t *testing.T) {
 	v, err := NewVersion("1.2.3-beta.1+build.123")
 	if err != nil {
@@ -345,6 +495,41 @@ func TestEqual(t *testing.T) {
 	}
 }
 
+func TestCompareNil(t *testing.T) {
+	var none *Version
+	v := MustParse("0.0.0-0")
+
+	if d := none.Compare(v); d != -1 {
+		t.Errorf("Expected nil to be less than %s but got %d", v, d)
+	}
+	if d := v.Compare(none); d != 1 {
+		t.Errorf("Expected %s to be greater than nil but got %d", v, d)
+	}
+	if d := none.Compare(nil); d != 0 {
+		t.Errorf("Expected nil to equal nil but got %d", d)
+	}
+	if !none.Equal(nil) || v.Equal(none) || none.Equal(v) {
+		t.Error("Expected nil to only equal nil")
+	}
+	if !none.LessThan(v) || !v.GreaterThan(none) {
+		t.Error("Expected nil to be less than any version")
+	}
+	if none.Original() != "" {
+		t.Errorf("Expected nil to have an empty original but got %q", none.Original())
+	}
+
+	opts := MatchOptions{FoldPrereleaseCase: true, Metadata: MetadataEqual}
+	if d := none.CompareWithOptions(v, opts); d != -1 {
+		t.Errorf("Expected nil to be less than %s with options but got %d", v, d)
+	}
+	if d := v.CompareWithOptions(none, opts); d != 1 {
+		t.Errorf("Expected %s to be greater than nil with options but got %d", v, d)
+	}
+	if !opts.equal(none, nil) || opts.equal(v, nil) {
+		t.Error("Expected nil to only equal nil with options")
+	}
+}
+
 func TestInc(t *testing.T) {
 	tests := []struct {
 		v1               string
@@ -587,6 +772,8 @@ func TestValidatePrerelease(t *testing.T) {
 		{"alpha.01", ErrSegmentStartsZero},
 		{"foo☃︎", ErrInvalidPrerelease},
 		{"alpha.0-1", nil},
+		{"alpha..1", ErrInvalidPrerelease},
+		{"alpha.", ErrInvalidPrerelease},
 	}
 
 	for _, tc := range tests {
@@ -607,6 +794,8 @@ func TestValidateMetadata(t *testing.T) {
 		{"foo☃︎", ErrInvalidMetadata},
 		{"alpha.0-1", nil},
 		{"al-pha.1Phe70CgWe050H9K1mJwRUqTNQXZRERwLOEg37wpXUb4JgzgaD5YkL52ABnoyiE", nil},
+		{".123", ErrInvalidMetadata},
+		{"build..1", ErrInvalidMetadata},
 	}
 
 	for _, tc := range tests {
@@ -615,3 +804,96 @@ func TestValidateMetadata(t *testing.T) {
 		}
 	}
 }
+
+func TestCompareWithOptions(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		opts     MatchOptions
+		expected int
+	}{
+		{"1.0.0-RC.1", "1.0.0-rc.1", MatchOptions{}, -1},
+		{"1.0.0-RC.1", "1.0.0-rc.1", MatchOptions{FoldPrereleaseCase: true}, 0},
+		{"1.0.0-RC.2", "1.0.0-rc.1", MatchOptions{FoldPrereleaseCase: true}, 1},
+		{"1.0.0-beta", "1.0.0-RC.1", MatchOptions{FoldPrereleaseCase: true}, -1},
+		{"1.2.3+build.10", "1.2.3+build.9", MatchOptions{}, 0},
+		{"1.2.3+build.10", "1.2.3+build.9", MatchOptions{Metadata: MetadataOrdered}, 1},
+	}
+
+	for _, tc := range tests {
+		v1 := MustParse(tc.v1)
+		v2 := MustParse(tc.v2)
+
+		if a := v1.CompareWithOptions(v2, tc.opts); a != tc.expected {
+			t.Errorf("Comparison of %q and %q with %+v failed. Expected %d, got %d", tc.v1, tc.v2, tc.opts, tc.expected, a)
+		}
+	}
+
+	// The versions themselves must not be changed by folding.
+	v := MustParse("1.0.0-RC.1")
+	v.CompareWithOptions(MustParse("1.0.0-rc.1"), MatchOptions{FoldPrereleaseCase: true})
+	if v.Prerelease() != "RC.1" {
+		t.Errorf("expected prerelease to be unchanged but got %q", v.Prerelease())
+	}
+}
+
+func TestCompareSpecPrecedence(t *testing.T) {
+	// The examples from section 11 of the spec in increasing precedence.
+	chain := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+	}
+
+	for i := range chain {
+		for j := range chain {
+			v1 := MustParse(chain[i])
+			v2 := MustParse(chain[j])
+
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			if a := v1.Compare(v2); a != expected {
+				t.Errorf("Comparison of %q and %q failed. Expected %d, got %d", chain[i], chain[j], expected, a)
+			}
+		}
+	}
+}
+
+func TestComparePrereleaseNumericSize(t *testing.T) {
+	tests := []struct {
+		v1     string
+		v2     string
+		spec   int
+		legacy int
+	}{
+		{"1.0.0-99999999999999999999", "1.0.0-100000000000000000000", -1, 1},
+		{"1.0.0-18446744073709551616", "1.0.0-alpha", -1, -1},
+		{"1.0.0-18446744073709551616", "1.0.0-9", 1, 1},
+		{"1.0.0-beta.11", "1.0.0-beta.2", 1, 1},
+		{"1.0.0--99", "1.0.0-99", 1, 1},
+	}
+
+	for _, tc := range tests {
+		v1 := MustParse(tc.v1)
+		v2 := MustParse(tc.v2)
+
+		if a := v1.Compare(v2); a != tc.spec {
+			t.Errorf("Comparison of %q and %q failed. Expected %d, got %d", tc.v1, tc.v2, tc.spec, a)
+		}
+		if a := v1.CompareWithOptions(v2, MatchOptions{LegacyPrereleaseOrder: true}); a != tc.legacy {
+			t.Errorf("Legacy comparison of %q and %q failed. Expected %d, got %d", tc.v1, tc.v2, tc.legacy, a)
+		}
+	}
+}